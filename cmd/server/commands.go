@@ -17,6 +17,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -27,6 +28,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
 	"github.com/livekit/protocol/utils"
 	"github.com/livekit/protocol/utils/guid"
 
@@ -180,12 +182,16 @@ func listNodes(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	nodeLabels, err := router.ListNodeLabels()
+	if err != nil {
+		return err
+	}
 
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetRowLine(true)
 	table.SetAutoWrapText(false)
 	table.SetHeader([]string{
-		"ID", "IP Address", "Region",
+		"ID", "IP Address", "Region\nLabels",
 		"CPUs", "CPU Usage\nLoad Avg",
 		"Memory Used/Total",
 		"Rooms", "Clients\nTracks In/Out",
@@ -209,6 +215,14 @@ func listNodes(c *cli.Context) error {
 		// Id and state
 		idAndState := fmt.Sprintf("%s\n(%s)", node.Id, node.State.Enum().String())
 
+		// Region and labels
+		labels := make([]string, 0, len(nodeLabels[livekit.NodeID(node.Id)]))
+		for k, v := range nodeLabels[livekit.NodeID(node.Id)] {
+			labels = append(labels, k+"="+v)
+		}
+		sort.Strings(labels)
+		regionAndLabels := strings.Join(append([]string{node.Region}, labels...), "\n")
+
 		// System stats
 		cpus := strconv.Itoa(int(stats.NumCpus))
 		cpuUsageAndLoadAvg := fmt.Sprintf("%.2f %%\n%.2f %.2f %.2f", stats.CpuLoad*100,
@@ -233,7 +247,7 @@ func listNodes(c *cli.Context) error {
 			time.Unix(stats.UpdatedAt, 0).UTC().Format("2006-01-02 15:04:05"))
 
 		table.Append([]string{
-			idAndState, node.Ip, node.Region,
+			idAndState, node.Ip, regionAndLabels,
 			cpus, cpuUsageAndLoadAvg,
 			memUsage,
 			rooms, clientsAndTracks,
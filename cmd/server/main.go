@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"net"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/livekit/livekit-server/pkg/rtc"
 	"github.com/livekit/livekit-server/pkg/telemetry/prometheus"
+	"github.com/livekit/livekit-server/pkg/telemetry/tracing"
 	"github.com/livekit/protocol/logger"
 
 	"github.com/livekit/livekit-server/pkg/config"
@@ -276,6 +278,18 @@ func startServer(c *cli.Context) error {
 		return err
 	}
 
+	shutdownTracing, err := tracing.Init(&conf.Tracing, currentNode.NodeID(), currentNode.Region())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Errorw("could not flush traces", err)
+		}
+	}()
+
 	server, err := service.InitializeServer(conf, currentNode)
 	if err != nil {
 		return err
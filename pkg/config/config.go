@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"slices"
 	"strings"
 	"time"
 
@@ -56,6 +57,7 @@ type Config struct {
 	Prometheus     PrometheusConfig         `yaml:"prometheus,omitempty"`
 	RTC            RTCConfig                `yaml:"rtc,omitempty"`
 	Redis          redisLiveKit.RedisConfig `yaml:"redis,omitempty"`
+	Store          StoreConfig              `yaml:"store,omitempty"`
 	Audio          sfu.AudioConfig          `yaml:"audio,omitempty"`
 	Video          VideoConfig              `yaml:"video,omitempty"`
 	Room           RoomConfig               `yaml:"room,omitempty"`
@@ -67,6 +69,8 @@ type Config struct {
 	KeyFile        string                   `yaml:"key_file,omitempty"`
 	Keys           map[string]string        `yaml:"keys,omitempty"`
 	Region         string                   `yaml:"region,omitempty"`
+	NodeLabels     map[string]string        `yaml:"node_labels,omitempty"`
+	GeoIP          GeoIPConfig              `yaml:"geoip,omitempty"`
 	SignalRelay    SignalRelayConfig        `yaml:"signal_relay,omitempty"`
 	PSRPC          rpc.PSRPCConfig          `yaml:"psrpc,omitempty"`
 	// Deprecated: LogLevel is deprecated
@@ -74,6 +78,13 @@ type Config struct {
 	Logging  LoggingConfig `yaml:"logging,omitempty"`
 	Limit    LimitConfig   `yaml:"limit,omitempty"`
 
+	Analytics       AnalyticsConfig       `yaml:"analytics,omitempty"`
+	Tracing         TracingConfig         `yaml:"tracing,omitempty"`
+	SignalRecording SignalRecordingConfig `yaml:"signal_recording,omitempty"`
+	PlainRTP        PlainRTPConfig        `yaml:"plain_rtp,omitempty"`
+	RTSP            RTSPConfig            `yaml:"rtsp,omitempty"`
+	MediaFiles      MediaFilesConfig      `yaml:"media_files,omitempty"`
+
 	Development bool `yaml:"development,omitempty"`
 
 	Metric metric.MetricConfig `yaml:"metric,omitempty"`
@@ -167,6 +178,8 @@ type RoomConfig struct {
 	CreateRoomEnabled  bool               `yaml:"create_room_enabled,omitempty"`
 	CreateRoomTimeout  time.Duration      `yaml:"create_room_timeout,omitempty"`
 	CreateRoomAttempts int                `yaml:"create_room_attempts,omitempty"`
+	// move rooms to other nodes when draining
+	MigrateOnDrain bool `yaml:"migrate_on_drain,omitempty"`
 	// deprecated, moved to limits
 	MaxMetadataSize uint32 `yaml:"max_metadata_size,omitempty"`
 	// deprecated, moved to limits
@@ -174,6 +187,17 @@ type RoomConfig struct {
 	// deprecated, moved to limits
 	MaxParticipantIdentityLength int                                   `yaml:"max_participant_identity_length,omitempty"`
 	RoomConfigurations           map[string]*livekit.RoomConfiguration `yaml:"room_configurations,omitempty"`
+	// node label constraints of rooms created with a named room configuration, keyed by configuration name
+	Placement map[string]RoomPlacementConfig `yaml:"placement,omitempty"`
+}
+
+type RoomPlacementConfig struct {
+	// rooms are only placed on nodes with all of these labels
+	RequiredLabels map[string]string `yaml:"required_labels,omitempty"`
+	// nodes with all of these labels are preferred when available
+	PreferredLabels map[string]string `yaml:"preferred_labels,omitempty"`
+	// rooms are not placed on nodes in these regions
+	ExcludedRegions []string `yaml:"excluded_regions,omitempty"`
 }
 
 type CodecSpec struct {
@@ -205,11 +229,35 @@ type WebHookConfig struct {
 }
 
 type NodeSelectorConfig struct {
-	Kind         string         `yaml:"kind,omitempty"`
-	SortBy       string         `yaml:"sort_by,omitempty"`
-	CPULoadLimit float32        `yaml:"cpu_load_limit,omitempty"`
-	SysloadLimit float32        `yaml:"sysload_limit,omitempty"`
-	Regions      []RegionConfig `yaml:"regions,omitempty"`
+	Kind         string               `yaml:"kind,omitempty"`
+	SortBy       string               `yaml:"sort_by,omitempty"`
+	CPULoadLimit float32              `yaml:"cpu_load_limit,omitempty"`
+	SysloadLimit float32              `yaml:"sysload_limit,omitempty"`
+	Regions      []RegionConfig       `yaml:"regions,omitempty"`
+	Script       ScriptSelectorConfig `yaml:"script,omitempty"`
+	// rooms created with an API key are not placed on nodes in the regions listed for the key
+	APIKeyExcludedRegions map[string][]string `yaml:"api_key_excluded_regions,omitempty"`
+}
+
+// ScriptSelectorConfig holds expressions evaluated against each node by the script selector
+type ScriptSelectorConfig struct {
+	// must evaluate to a bool, nodes for which it is false are not selected
+	Filter string `yaml:"filter,omitempty"`
+	// must evaluate to a number, the node with the highest score is selected
+	Score string `yaml:"score,omitempty"`
+}
+
+// GeoIPConfig configures locating clients from their address
+type GeoIPConfig struct {
+	// MaxMind format city database, e.g. GeoLite2-City.mmdb
+	DatabaseFile string `yaml:"database_file,omitempty"`
+}
+
+// StoreConfig configures the store used when Redis is not configured
+type StoreConfig struct {
+	// Path of a database file to persist ingress, egress, SIP and agent dispatch state to.
+	// When empty, state is kept in memory and lost on restart.
+	Path string `yaml:"path,omitempty"`
 }
 
 type SignalRelayConfig struct {
@@ -226,11 +274,19 @@ type RegionConfig struct {
 	Name string  `yaml:"name,omitempty"`
 	Lat  float64 `yaml:"lat,omitempty"`
 	Lon  float64 `yaml:"lon,omitempty"`
+	// regions to fall back to, in order, when no node is available in this region.
+	// only used for the region of the current node, regions not listed are then ordered by distance
+	Failover []string `yaml:"failover,omitempty"`
+	// no new rooms are placed in the region once its nodes host that many rooms or clients in total
+	MaxRooms   int32 `yaml:"max_rooms,omitempty"`
+	MaxClients int32 `yaml:"max_clients,omitempty"`
 }
 
 type LimitConfig struct {
-	NumTracks              int32   `yaml:"num_tracks,omitempty"`
-	BytesPerSec            float32 `yaml:"bytes_per_sec,omitempty"`
+	NumTracks   int32   `yaml:"num_tracks,omitempty"`
+	BytesPerSec float32 `yaml:"bytes_per_sec,omitempty"`
+	// budget of outgoing bytes per second, participants are not admitted when they would take the node over it
+	EgressBytesPerSec      float32 `yaml:"egress_bytes_per_sec,omitempty"`
 	SubscriptionLimitVideo int32   `yaml:"subscription_limit_video,omitempty"`
 	SubscriptionLimitAudio int32   `yaml:"subscription_limit_audio,omitempty"`
 	MaxMetadataSize        uint32  `yaml:"max_metadata_size,omitempty"`
@@ -272,6 +328,107 @@ type IngressConfig struct {
 
 type SIPConfig struct{}
 
+// AnalyticsConfig configures local sinks for analytics stats and events, so they can be
+// retained without LiveKit Cloud. Any number of sinks can be enabled at the same time.
+type AnalyticsConfig struct {
+	File AnalyticsFileConfig `yaml:"file,omitempty"`
+	HTTP AnalyticsHTTPConfig `yaml:"http,omitempty"`
+	OTLP AnalyticsOTLPConfig `yaml:"otlp,omitempty"`
+}
+
+type AnalyticsBatchConfig struct {
+	// max number of records sent in a single batch
+	BatchSize int `yaml:"batch_size,omitempty"`
+	// max amount of time records are held before being flushed
+	FlushInterval time.Duration `yaml:"flush_interval,omitempty"`
+	// max number of records to hold while the sink is unavailable, older records are dropped
+	MaxPending int `yaml:"max_pending,omitempty"`
+}
+
+// AnalyticsFileConfig writes records as JSON lines into a file, rotating it when it gets too large
+type AnalyticsFileConfig struct {
+	// path of the active file, rotated files are suffixed with a timestamp
+	Path string `yaml:"path,omitempty"`
+	// size in megabytes after which the file is rotated
+	MaxSizeMB int `yaml:"max_size_mb,omitempty"`
+	// number of rotated files to keep, 0 keeps all
+	MaxBackups int `yaml:"max_backups,omitempty"`
+
+	AnalyticsBatchConfig `yaml:",inline"`
+}
+
+// AnalyticsHTTPConfig posts batches of records as JSON to a generic HTTP endpoint
+type AnalyticsHTTPConfig struct {
+	URL     string            `yaml:"url,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	Timeout time.Duration     `yaml:"timeout,omitempty"`
+
+	AnalyticsBatchConfig `yaml:",inline"`
+}
+
+// AnalyticsOTLPConfig exports records as OpenTelemetry log records over OTLP/HTTP
+type AnalyticsOTLPConfig struct {
+	// base URL of the collector, e.g. http://localhost:4318, records are sent to /v1/logs
+	Endpoint string            `yaml:"endpoint,omitempty"`
+	Headers  map[string]string `yaml:"headers,omitempty"`
+	Timeout  time.Duration     `yaml:"timeout,omitempty"`
+
+	AnalyticsBatchConfig `yaml:",inline"`
+}
+
+// TracingConfig enables OpenTelemetry tracing of the join, publish and API paths
+type TracingConfig struct {
+	// exporter to send spans to, "otlp" or "stdout", tracing is disabled when empty
+	Exporter string `yaml:"exporter,omitempty"`
+	// OTLP/HTTP collector endpoint, e.g. localhost:4318
+	Endpoint string            `yaml:"endpoint,omitempty"`
+	Headers  map[string]string `yaml:"headers,omitempty"`
+	// use http instead of https to reach the collector
+	Insecure bool `yaml:"insecure,omitempty"`
+	// fraction of new traces that are sampled, traces started by a remote parent follow the parent's decision
+	SampleRatio float64 `yaml:"sample_ratio,omitempty"`
+}
+
+// SignalRecordingConfig records the signalling of participant sessions to reproduce negotiation issues offline.
+// Recordings contain the SDP and ICE candidates of clients.
+type SignalRecordingConfig struct {
+	// directory to write recordings to, one file per participant session, recording is disabled when empty
+	Directory string `yaml:"directory,omitempty"`
+	// only record participants with these identities, all participants are recorded when empty
+	Identities []string `yaml:"identities,omitempty"`
+}
+
+func (c SignalRecordingConfig) IsEnabledFor(identity string) bool {
+	if c.Directory == "" {
+		return false
+	}
+	return len(c.Identities) == 0 || slices.Contains(c.Identities, identity)
+}
+
+// PlainRTPConfig enables publishing RTP from server-side producers, e. g. ffmpeg or GStreamer, on plain UDP
+// sockets, without ICE and DTLS. Producers must be able to reach the node directly at its node IP.
+type PlainRTPConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// UDP ports of plain transports, an ephemeral port is used when not set
+	PortRangeStart uint16 `yaml:"port_range_start,omitempty"`
+	PortRangeEnd   uint16 `yaml:"port_range_end,omitempty"`
+}
+
+// RTSPConfig enables a built-in RTSP server for subscribing to tracks, e. g. from CCTV and monitoring tools,
+// at rtsp://host:port/room/participant/track. RTP is delivered interleaved on the RTSP connection or over UDP.
+type RTSPConfig struct {
+	Enabled bool   `yaml:"enabled,omitempty"`
+	Port    uint32 `yaml:"port,omitempty"`
+}
+
+// MediaFilesConfig enables publishing media files read by the server, e. g. hold music or announcements,
+// as tracks of a server-owned participant.
+type MediaFilesConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// files are looked up in this directory, the working directory when not set
+	Directory string `yaml:"directory,omitempty"`
+}
+
 type APIConfig struct {
 	// amount of time to wait for API to execute, default 2s
 	ExecutionTimeout time.Duration `yaml:"execution_timeout,omitempty"`
@@ -328,6 +485,9 @@ var DefaultConfig = Config{
 		},
 	},
 	Audio: sfu.DefaultAudioConfig,
+	RTSP: RTSPConfig{
+		Port: 8554,
+	},
 	Video: VideoConfig{
 		DynacastPauseDelay:   5 * time.Second,
 		StreamTrackerManager: sfu.DefaultStreamTrackerManagerConfig,
@@ -376,6 +536,36 @@ var DefaultConfig = Config{
 		StreamBufferSize: 1000,
 		ConnectAttempts:  3,
 	},
+	Analytics: AnalyticsConfig{
+		File: AnalyticsFileConfig{
+			MaxSizeMB:  100,
+			MaxBackups: 10,
+			AnalyticsBatchConfig: AnalyticsBatchConfig{
+				BatchSize:     100,
+				FlushInterval: time.Second,
+				MaxPending:    10000,
+			},
+		},
+		HTTP: AnalyticsHTTPConfig{
+			Timeout: 10 * time.Second,
+			AnalyticsBatchConfig: AnalyticsBatchConfig{
+				BatchSize:     500,
+				FlushInterval: 5 * time.Second,
+				MaxPending:    10000,
+			},
+		},
+		OTLP: AnalyticsOTLPConfig{
+			Timeout: 10 * time.Second,
+			AnalyticsBatchConfig: AnalyticsBatchConfig{
+				BatchSize:     500,
+				FlushInterval: 5 * time.Second,
+				MaxPending:    10000,
+			},
+		},
+	},
+	Tracing: TracingConfig{
+		SampleRatio: 1,
+	},
 	PSRPC:  rpc.DefaultPSRPCConfig,
 	Keys:   map[string]string{},
 	Metric: metric.DefaultMetricConfig,
@@ -129,6 +129,7 @@ type Router interface {
 	RemoveDeadNodes() error
 
 	ListNodes() ([]*livekit.Node, error)
+	ListNodeLabels() (map[livekit.NodeID]map[string]string, error)
 
 	GetNodeForRoom(ctx context.Context, roomName livekit.RoomName) (*livekit.Node, error)
 	SetNodeForRoom(ctx context.Context, roomName livekit.RoomName, nodeId livekit.NodeID) error
@@ -190,6 +191,10 @@ type ParticipantInit struct {
 	SubscriberAllowPause *bool
 	DisableICELite       bool
 	CreateRoom           *livekit.CreateRoomRequest
+	// publish and subscribe on a single peer connection
+	UseSinglePeerConnection bool
+	// region closest to the client, only used to place the room and not sent to the node hosting it
+	ClientRegion string
 }
 
 func (pi *ParticipantInit) MarshalLogObject(e zapcore.ObjectEncoder) error {
@@ -217,6 +222,8 @@ func (pi *ParticipantInit) MarshalLogObject(e zapcore.ObjectEncoder) error {
 	logBoolPtr("SubscriberAllowPause", pi.SubscriberAllowPause)
 	logBoolPtr("DisableICELite", &pi.DisableICELite)
 	e.AddObject("CreateRoom", logger.Proto(pi.CreateRoom))
+	logBoolPtr("UseSinglePeerConnection", &pi.UseSinglePeerConnection)
+	e.AddString("ClientRegion", pi.ClientRegion)
 	return nil
 }
 
@@ -0,0 +1,66 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/psrpc/pkg/metadata"
+
+	"github.com/livekit/livekit-server/pkg/routing"
+)
+
+func TestParticipantInitStartSession(t *testing.T) {
+	pi := &routing.ParticipantInit{
+		Identity:       "p1",
+		AutoSubscribe:  true,
+		Grants:         &auth.ClaimGrants{Identity: "p1"},
+		DisableICELite: true,
+		CreateRoom:     &livekit.CreateRoomRequest{Name: "room"},
+	}
+
+	ss, err := pi.ToStartSession("room", "CO_1")
+	require.NoError(t, err)
+
+	// goes through the wire between signal and RTC nodes
+	b, err := proto.Marshal(ss)
+	require.NoError(t, err)
+	received := &livekit.StartSession{}
+	require.NoError(t, proto.Unmarshal(b, received))
+
+	decoded, err := routing.ParticipantInitFromStartSession(received, "us")
+	require.NoError(t, err)
+	require.Equal(t, pi.Identity, decoded.Identity)
+	require.True(t, decoded.AutoSubscribe)
+	require.True(t, decoded.DisableICELite)
+}
+
+func TestSinglePeerConnectionMetadata(t *testing.T) {
+	// metadata of the outgoing request is received as the header of the incoming one
+	receive := func(ctx context.Context) context.Context {
+		return metadata.NewContextWithIncomingHeader(context.Background(), &metadata.Header{
+			Metadata: metadata.OutgoingContextMetadata(ctx),
+		})
+	}
+
+	require.False(t, routing.IsSinglePeerConnection(receive(context.Background())))
+	require.True(t, routing.IsSinglePeerConnection(receive(routing.WithSinglePeerConnection(context.Background()))))
+}
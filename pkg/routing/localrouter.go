@@ -91,6 +91,12 @@ func (r *LocalRouter) ListNodes() ([]*livekit.Node, error) {
 	}, nil
 }
 
+func (r *LocalRouter) ListNodeLabels() (map[livekit.NodeID]map[string]string, error) {
+	return map[livekit.NodeID]map[string]string{
+		r.currentNode.NodeID(): r.currentNode.Labels(),
+	}, nil
+}
+
 func (r *LocalRouter) CreateRoom(ctx context.Context, req *livekit.CreateRoomRequest) (res *livekit.Room, err error) {
 	return r.CreateRoomWithNodeID(ctx, req, r.currentNode.NodeID())
 }
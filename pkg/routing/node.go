@@ -15,7 +15,9 @@
 package routing
 
 import (
+	"maps"
 	"runtime"
+	"strconv"
 	"sync"
 	"time"
 
@@ -25,6 +27,7 @@ import (
 	"github.com/livekit/protocol/utils/guid"
 
 	"github.com/livekit/livekit-server/pkg/config"
+	"github.com/livekit/livekit-server/pkg/routing/selector"
 	"github.com/livekit/livekit-server/pkg/telemetry/prometheus"
 )
 
@@ -35,6 +38,7 @@ type LocalNode interface {
 	NodeType() livekit.NodeType
 	NodeIP() string
 	Region() string
+	Labels() map[string]string
 	SetState(state livekit.NodeState)
 	SetStats(stats *livekit.NodeStats)
 	UpdateNodeStats() bool
@@ -42,8 +46,9 @@ type LocalNode interface {
 }
 
 type LocalNodeImpl struct {
-	lock sync.RWMutex
-	node *livekit.Node
+	lock   sync.RWMutex
+	node   *livekit.Node
+	labels map[string]string
 
 	// previous stats for computing averages
 	prevStats *livekit.NodeStats
@@ -68,6 +73,13 @@ func NewLocalNode(conf *config.Config) (*LocalNodeImpl, error) {
 	if conf != nil {
 		l.node.Ip = conf.RTC.NodeIP
 		l.node.Region = conf.Region
+		l.labels = maps.Clone(conf.NodeLabels)
+		if conf.Limit.EgressBytesPerSec > 0 {
+			if l.labels == nil {
+				l.labels = make(map[string]string)
+			}
+			l.labels[selector.EgressBudgetLabel] = strconv.FormatFloat(float64(conf.Limit.EgressBytesPerSec), 'f', -1, 32)
+		}
 	}
 	return l, nil
 }
@@ -119,6 +131,13 @@ func (l *LocalNodeImpl) Region() string {
 	return l.node.Region
 }
 
+func (l *LocalNodeImpl) Labels() map[string]string {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return maps.Clone(l.labels)
+}
+
 func (l *LocalNodeImpl) SetState(state livekit.NodeState) {
 	l.lock.Lock()
 	defer l.lock.Unlock()
@@ -17,6 +17,8 @@ package routing
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"runtime/pprof"
 	"time"
 
@@ -41,6 +43,9 @@ const (
 	// hash of node_id => Node proto
 	NodesKey = "nodes"
 
+	// hash of node_id => JSON encoded labels of the node
+	NodeLabelsKey = "node_labels"
+
 	// hash of room_name => node_id
 	NodeRoomKey = "room_node_map"
 )
@@ -79,11 +84,24 @@ func (r *RedisRouter) RegisterNode() error {
 	if err := r.rc.HSet(r.ctx, NodesKey, string(r.currentNode.NodeID()), data).Err(); err != nil {
 		return errors.Wrap(err, "could not register node")
 	}
+
+	if labels := r.currentNode.Labels(); len(labels) != 0 {
+		data, err := json.Marshal(labels)
+		if err != nil {
+			return err
+		}
+		if err := r.rc.HSet(r.ctx, NodeLabelsKey, string(r.currentNode.NodeID()), data).Err(); err != nil {
+			return errors.Wrap(err, "could not register node labels")
+		}
+	}
 	return nil
 }
 
 func (r *RedisRouter) UnregisterNode() error {
 	// could be called after Stop(), so we'd want to use an unrelated context
+	if err := r.rc.HDel(context.Background(), NodeLabelsKey, string(r.currentNode.NodeID())).Err(); err != nil {
+		return err
+	}
 	return r.rc.HDel(context.Background(), NodesKey, string(r.currentNode.NodeID())).Err()
 }
 
@@ -97,6 +115,9 @@ func (r *RedisRouter) RemoveDeadNodes() error {
 			if err := r.rc.HDel(context.Background(), NodesKey, n.Id).Err(); err != nil {
 				return err
 			}
+			if err := r.rc.HDel(context.Background(), NodeLabelsKey, n.Id).Err(); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -111,7 +132,12 @@ func (r *RedisRouter) GetNodeForRoom(_ context.Context, roomName livekit.RoomNam
 		return nil, errors.Wrap(err, "could not get node for room")
 	}
 
-	return r.GetNode(livekit.NodeID(nodeID))
+	node, err := r.GetNode(livekit.NodeID(nodeID))
+	if err == ErrNotFound {
+		// the node hosting the room is no longer registered
+		return nil, fmt.Errorf("%w: %w", ErrNodeNotFound, ErrNotFound)
+	}
+	return node, err
 }
 
 func (r *RedisRouter) SetNodeForRoom(_ context.Context, roomName livekit.RoomName, nodeID livekit.NodeID) error {
@@ -155,6 +181,22 @@ func (r *RedisRouter) ListNodes() ([]*livekit.Node, error) {
 	return nodes, nil
 }
 
+func (r *RedisRouter) ListNodeLabels() (map[livekit.NodeID]map[string]string, error) {
+	items, err := r.rc.HGetAll(r.ctx, NodeLabelsKey).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list node labels")
+	}
+	nodeLabels := make(map[livekit.NodeID]map[string]string, len(items))
+	for nodeID, item := range items {
+		var labels map[string]string
+		if err := json.Unmarshal([]byte(item), &labels); err != nil {
+			return nil, err
+		}
+		nodeLabels[livekit.NodeID(nodeID)] = labels
+	}
+	return nodeLabels, nil
+}
+
 func (r *RedisRouter) CreateRoom(ctx context.Context, req *livekit.CreateRoomRequest) (res *livekit.Room, err error) {
 	rtcNode, err := r.GetNodeForRoom(ctx, livekit.RoomName(req.Name))
 	if err != nil {
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/livekit/livekit-server/pkg/config"
+	"github.com/livekit/livekit-server/pkg/telemetry/tracing"
 	"github.com/livekit/protocol/livekit"
 	"github.com/livekit/protocol/rpc"
 	"github.com/livekit/psrpc"
@@ -40,6 +41,7 @@ func NewRoomManagerClient(clientParams rpc.ClientParams, config config.RoomConfi
 		psrpc.WithClientChannelSize(clientParams.BufferSize),
 		middleware.WithClientMetrics(clientParams.Observer),
 		rpc.WithClientLogger(clientParams.Logger),
+		tracing.WithClientTracing(),
 	)
 	if err != nil {
 		return nil, err
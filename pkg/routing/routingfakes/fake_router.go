@@ -64,6 +64,18 @@ type FakeRouter struct {
 	getRegionReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ListNodeLabelsStub        func() (map[livekit.NodeID]map[string]string, error)
+	listNodeLabelsMutex       sync.RWMutex
+	listNodeLabelsArgsForCall []struct {
+	}
+	listNodeLabelsReturns struct {
+		result1 map[livekit.NodeID]map[string]string
+		result2 error
+	}
+	listNodeLabelsReturnsOnCall map[int]struct {
+		result1 map[livekit.NodeID]map[string]string
+		result2 error
+	}
 	ListNodesStub        func() ([]*livekit.Node, error)
 	listNodesMutex       sync.RWMutex
 	listNodesArgsForCall []struct {
@@ -421,6 +433,62 @@ func (fake *FakeRouter) GetRegionReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeRouter) ListNodeLabels() (map[livekit.NodeID]map[string]string, error) {
+	fake.listNodeLabelsMutex.Lock()
+	ret, specificReturn := fake.listNodeLabelsReturnsOnCall[len(fake.listNodeLabelsArgsForCall)]
+	fake.listNodeLabelsArgsForCall = append(fake.listNodeLabelsArgsForCall, struct {
+	}{})
+	stub := fake.ListNodeLabelsStub
+	fakeReturns := fake.listNodeLabelsReturns
+	fake.recordInvocation("ListNodeLabels", []interface{}{})
+	fake.listNodeLabelsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRouter) ListNodeLabelsCallCount() int {
+	fake.listNodeLabelsMutex.RLock()
+	defer fake.listNodeLabelsMutex.RUnlock()
+	return len(fake.listNodeLabelsArgsForCall)
+}
+
+func (fake *FakeRouter) ListNodeLabelsCalls(stub func() (map[livekit.NodeID]map[string]string, error)) {
+	fake.listNodeLabelsMutex.Lock()
+	defer fake.listNodeLabelsMutex.Unlock()
+	fake.ListNodeLabelsStub = stub
+}
+
+func (fake *FakeRouter) ListNodeLabelsReturns(result1 map[livekit.NodeID]map[string]string, result2 error) {
+	fake.listNodeLabelsMutex.Lock()
+	defer fake.listNodeLabelsMutex.Unlock()
+	fake.ListNodeLabelsStub = nil
+	fake.listNodeLabelsReturns = struct {
+		result1 map[livekit.NodeID]map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRouter) ListNodeLabelsReturnsOnCall(i int, result1 map[livekit.NodeID]map[string]string, result2 error) {
+	fake.listNodeLabelsMutex.Lock()
+	defer fake.listNodeLabelsMutex.Unlock()
+	fake.ListNodeLabelsStub = nil
+	if fake.listNodeLabelsReturnsOnCall == nil {
+		fake.listNodeLabelsReturnsOnCall = make(map[int]struct {
+			result1 map[livekit.NodeID]map[string]string
+			result2 error
+		})
+	}
+	fake.listNodeLabelsReturnsOnCall[i] = struct {
+		result1 map[livekit.NodeID]map[string]string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRouter) ListNodes() ([]*livekit.Node, error) {
 	fake.listNodesMutex.Lock()
 	ret, specificReturn := fake.listNodesReturnsOnCall[len(fake.listNodesArgsForCall)]
@@ -855,6 +923,8 @@ func (fake *FakeRouter) Invocations() map[string][][]interface{} {
 	defer fake.getNodeForRoomMutex.RUnlock()
 	fake.getRegionMutex.RLock()
 	defer fake.getRegionMutex.RUnlock()
+	fake.listNodeLabelsMutex.RLock()
+	defer fake.listNodeLabelsMutex.RUnlock()
 	fake.listNodesMutex.RLock()
 	defer fake.listNodesMutex.RUnlock()
 	fake.registerNodeMutex.RLock()
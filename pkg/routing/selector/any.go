@@ -24,10 +24,14 @@ type AnySelector struct {
 }
 
 func (s *AnySelector) SelectNode(nodes []*livekit.Node) (*livekit.Node, error) {
+	return s.SelectNodeForRoom(nodes, RoomPlacement{})
+}
+
+func (s *AnySelector) SelectNodeForRoom(nodes []*livekit.Node, placement RoomPlacement) (*livekit.Node, error) {
 	nodes = GetAvailableNodes(nodes)
 	if len(nodes) == 0 {
 		return nil, ErrNoAvailableNodes
 	}
 
-	return SelectSortedNode(nodes, s.SortBy)
+	return SelectSortedNodeWithLabels(nodes, s.SortBy, placement.NodeLabels)
 }
@@ -0,0 +1,115 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"hash/fnv"
+
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/config"
+)
+
+// ConsistentHashSelector places rooms using rendezvous hashing of the room name over nodes,
+// so a room maps to the same node as long as it is available and only rooms of a node that
+// joins or leaves move. Nodes that reached their limits are never selected, nodes over
+// SysloadLimit or CPULoadLimit are skipped unless all nodes are.
+// Without a room name, it falls back to selecting a node using SortBy.
+type ConsistentHashSelector struct {
+	SysloadLimit float32
+	CPULoadLimit float32
+	Limit        config.LimitConfig
+	SortBy       string
+}
+
+func (s *ConsistentHashSelector) filterNodes(nodes []*livekit.Node) ([]*livekit.Node, error) {
+	nodes = GetAvailableNodes(nodes)
+
+	nodesWithinLimits := make([]*livekit.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if !LimitsReached(s.Limit, node.Stats) {
+			nodesWithinLimits = append(nodesWithinLimits, node)
+		}
+	}
+	if len(nodesWithinLimits) == 0 {
+		return nil, ErrNoAvailableNodes
+	}
+
+	nodesLowLoad := make([]*livekit.Node, 0, len(nodesWithinLimits))
+	for _, node := range nodesWithinLimits {
+		if !s.isLoaded(node) {
+			nodesLowLoad = append(nodesLowLoad, node)
+		}
+	}
+	if len(nodesLowLoad) > 0 {
+		return nodesLowLoad, nil
+	}
+	return nodesWithinLimits, nil
+}
+
+func (s *ConsistentHashSelector) isLoaded(node *livekit.Node) bool {
+	if node.Stats == nil {
+		return false
+	}
+	if s.SysloadLimit > 0 && GetNodeSysload(node) >= s.SysloadLimit {
+		return true
+	}
+	return s.CPULoadLimit > 0 && node.Stats.CpuLoad >= s.CPULoadLimit
+}
+
+func (s *ConsistentHashSelector) HasCapacity(node *livekit.Node) bool {
+	return !LimitsReached(s.Limit, node.Stats) && !s.isLoaded(node)
+}
+
+func (s *ConsistentHashSelector) SelectNode(nodes []*livekit.Node) (*livekit.Node, error) {
+	return s.SelectNodeForRoom(nodes, RoomPlacement{})
+}
+
+func (s *ConsistentHashSelector) SelectNodeForRoom(nodes []*livekit.Node, placement RoomPlacement) (*livekit.Node, error) {
+	nodes, err := s.filterNodes(nodes)
+	if err != nil {
+		return nil, err
+	}
+	if placement.RoomName == "" {
+		return SelectSortedNodeWithLabels(nodes, s.SortBy, placement.NodeLabels)
+	}
+
+	var selected *livekit.Node
+	var maxWeight uint64
+	for _, node := range nodes {
+		weight := rendezvousWeight(placement.RoomName, node.Id)
+		if selected == nil || weight > maxWeight || (weight == maxWeight && node.Id < selected.Id) {
+			selected = node
+			maxWeight = weight
+		}
+	}
+	return selected, nil
+}
+
+func rendezvousWeight(roomName livekit.RoomName, nodeID string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(roomName))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(nodeID))
+
+	// fnv does not spread similar inputs well, finish with the splitmix64 mixer
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/config"
+	"github.com/livekit/livekit-server/pkg/routing/selector"
+)
+
+func TestConsistentHashSelector_SelectNode(t *testing.T) {
+	newNodes := func(count int) []*livekit.Node {
+		nodes := make([]*livekit.Node, 0, count)
+		for i := 0; i < count; i++ {
+			nodes = append(nodes, &livekit.Node{
+				Id:    fmt.Sprintf("node-%d", i),
+				State: livekit.NodeState_SERVING,
+				Stats: &livekit.NodeStats{UpdatedAt: time.Now().Unix(), NumCpus: 1},
+			})
+		}
+		return nodes
+	}
+	selectNodes := func(sel *selector.ConsistentHashSelector, nodes []*livekit.Node, numRooms int) map[livekit.RoomName]string {
+		placement := make(map[livekit.RoomName]string)
+		for i := 0; i < numRooms; i++ {
+			roomName := livekit.RoomName(fmt.Sprintf("room-%d", i))
+			node, err := selector.SelectNodeForRoom(sel, nodes, selector.RoomPlacement{RoomName: roomName})
+			require.NoError(t, err)
+			placement[roomName] = node.Id
+		}
+		return placement
+	}
+
+	sel := &selector.ConsistentHashSelector{SysloadLimit: 0.9, CPULoadLimit: 0.9, SortBy: "random"}
+
+	t.Run("places rooms consistently and spreads them", func(t *testing.T) {
+		nodes := newNodes(4)
+		placement := selectNodes(sel, nodes, 400)
+		require.Equal(t, placement, selectNodes(sel, nodes, 400))
+
+		roomsPerNode := make(map[string]int)
+		for _, nodeID := range placement {
+			roomsPerNode[nodeID]++
+		}
+		require.Len(t, roomsPerNode, 4)
+		for _, count := range roomsPerNode {
+			require.Greater(t, count, 50)
+		}
+	})
+
+	t.Run("only rooms of a removed node move", func(t *testing.T) {
+		nodes := newNodes(4)
+		before := selectNodes(sel, nodes, 400)
+		after := selectNodes(sel, nodes[:3], 400)
+
+		for roomName, nodeID := range before {
+			if nodeID != nodes[3].Id {
+				require.Equal(t, nodeID, after[roomName])
+			}
+		}
+	})
+
+	t.Run("skips overloaded nodes", func(t *testing.T) {
+		nodes := newNodes(2)
+		nodes[0].Stats.CpuLoad = 0.95
+		for _, nodeID := range selectNodes(sel, nodes, 20) {
+			require.Equal(t, nodes[1].Id, nodeID)
+		}
+
+		// falls back to overloaded nodes when all are
+		nodes[1].Stats.LoadAvgLast1Min = 0.95
+		node, err := selector.SelectNodeForRoom(sel, nodes, selector.RoomPlacement{RoomName: "room"})
+		require.NoError(t, err)
+		require.NotNil(t, node)
+	})
+
+	t.Run("never selects nodes over limits", func(t *testing.T) {
+		nodes := newNodes(2)
+		nodes[0].Stats.NumTracksIn = 100
+		limitSel := &selector.ConsistentHashSelector{Limit: config.LimitConfig{NumTracks: 100}, SortBy: "random"}
+		for _, nodeID := range selectNodes(limitSel, nodes, 20) {
+			require.Equal(t, nodes[1].Id, nodeID)
+		}
+
+		nodes[1].Stats.NumTracksOut = 100
+		_, err := selector.SelectNodeForRoom(limitSel, nodes, selector.RoomPlacement{RoomName: "room"})
+		require.ErrorIs(t, err, selector.ErrNoAvailableNodes)
+	})
+}
@@ -44,11 +44,19 @@ func (s *CPULoadSelector) filterNodes(nodes []*livekit.Node) ([]*livekit.Node, e
 	return nodes, nil
 }
 
+func (s *CPULoadSelector) HasCapacity(node *livekit.Node) bool {
+	return node.Stats == nil || node.Stats.CpuLoad < s.CPULoadLimit
+}
+
 func (s *CPULoadSelector) SelectNode(nodes []*livekit.Node) (*livekit.Node, error) {
+	return s.SelectNodeForRoom(nodes, RoomPlacement{})
+}
+
+func (s *CPULoadSelector) SelectNodeForRoom(nodes []*livekit.Node, placement RoomPlacement) (*livekit.Node, error) {
 	nodes, err := s.filterNodes(nodes)
 	if err != nil {
 		return nil, err
 	}
 
-	return SelectSortedNode(nodes, s.SortBy)
+	return SelectSortedNodeWithLabels(nodes, s.SortBy, placement.NodeLabels)
 }
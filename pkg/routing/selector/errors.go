@@ -18,6 +18,8 @@ import "errors"
 
 var (
 	ErrNoAvailableNodes           = errors.New("could not find any available nodes")
+	ErrNoNodesWithLabels          = errors.New("could not find any available nodes with required labels")
+	ErrNoNodesInAllowedRegions    = errors.New("could not find any available nodes outside of excluded regions")
 	ErrCurrentRegionNotSet        = errors.New("current region cannot be blank")
 	ErrCurrentRegionUnknownLatLon = errors.New("unknown lat and lon for the current region")
 	ErrSortByNotSet               = errors.New("sort by option cannot be blank")
@@ -16,6 +16,7 @@ package selector
 
 import (
 	"errors"
+	"slices"
 
 	"github.com/livekit/protocol/livekit"
 	"github.com/livekit/protocol/logger"
@@ -30,6 +31,72 @@ type NodeSelector interface {
 	SelectNode(nodes []*livekit.Node) (*livekit.Node, error)
 }
 
+// RoomNodeSelector is implemented by selectors that take the room being placed into account
+type RoomNodeSelector interface {
+	SelectNodeForRoom(nodes []*livekit.Node, placement RoomPlacement) (*livekit.Node, error)
+}
+
+// ReasonNodeSelector is implemented by selectors that report why a node was selected
+type ReasonNodeSelector interface {
+	SelectNodeWithReason(nodes []*livekit.Node, placement RoomPlacement) (*livekit.Node, string, error)
+}
+
+// CapacityNodeSelector is implemented by selectors that select loaded nodes when all nodes are,
+// nodes with the preferred labels of a placement are only selected over others when they have capacity
+type CapacityNodeSelector interface {
+	HasCapacity(node *livekit.Node) bool
+}
+
+// reported by selectors which do not implement ReasonNodeSelector
+const SelectionReasonSelected = "selected"
+
+// SelectNodeForRoom selects a node to host a room among nodes matching its region and label constraints,
+// passing the placement to selectors which support it
+func SelectNodeForRoom(s NodeSelector, nodes []*livekit.Node, placement RoomPlacement) (*livekit.Node, error) {
+	node, _, err := SelectNodeForRoomWithReason(s, nodes, placement)
+	return node, err
+}
+
+// SelectNodeForRoomWithReason is like SelectNodeForRoom, also returning why the node was selected.
+// Nodes with the preferred labels of the placement are tried first, the others when the selector takes none of them.
+func SelectNodeForRoomWithReason(s NodeSelector, nodes []*livekit.Node, placement RoomPlacement) (*livekit.Node, string, error) {
+	if placement.AllNodes == nil {
+		placement.AllNodes = nodes
+	}
+	nodes, err := FilterNodesByRegion(nodes, placement)
+	if err != nil {
+		return nil, "", err
+	}
+	tiers, err := TierNodesByLabels(nodes, placement)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for i, tier := range tiers {
+		if cs, ok := s.(CapacityNodeSelector); ok && i < len(tiers)-1 {
+			tier = slices.DeleteFunc(slices.Clone(tier), func(node *livekit.Node) bool { return !cs.HasCapacity(node) })
+			if len(tier) == 0 {
+				continue
+			}
+		}
+
+		var node *livekit.Node
+		reason := SelectionReasonSelected
+		switch rs := s.(type) {
+		case ReasonNodeSelector:
+			node, reason, err = rs.SelectNodeWithReason(tier, placement)
+		case RoomNodeSelector:
+			node, err = rs.SelectNodeForRoom(tier, placement)
+		default:
+			node, err = s.SelectNode(tier)
+		}
+		if err == nil {
+			return node, reason, nil
+		}
+	}
+	return nil, "", err
+}
+
 func CreateNodeSelector(conf *config.Config) (NodeSelector, error) {
 	kind := conf.NodeSelector.Kind
 	if kind == "" {
@@ -55,6 +122,15 @@ func CreateNodeSelector(conf *config.Config) (NodeSelector, error) {
 		}
 		s.SysloadLimit = conf.NodeSelector.SysloadLimit
 		return s, nil
+	case "consistenthash":
+		return &ConsistentHashSelector{
+			SysloadLimit: conf.NodeSelector.SysloadLimit,
+			CPULoadLimit: conf.NodeSelector.CPULoadLimit,
+			Limit:        conf.Limit,
+			SortBy:       conf.NodeSelector.SortBy,
+		}, nil
+	case "script":
+		return NewScriptSelector(conf.NodeSelector.Script.Filter, conf.NodeSelector.Script.Score, conf.Region, conf.NodeSelector.SortBy)
 	case "random":
 		logger.Warnw("random node selector is deprecated, please switch to \"any\" or another selector", nil)
 		return &AnySelector{conf.NodeSelector.SortBy}, nil
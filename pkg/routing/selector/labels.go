@@ -0,0 +1,105 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"slices"
+
+	"github.com/livekit/protocol/livekit"
+)
+
+// NodeLabels maps node IDs to the labels configured on those nodes
+type NodeLabels map[livekit.NodeID]map[string]string
+
+// RoomPlacement describes the room a node is being selected for
+type RoomPlacement struct {
+	RoomName livekit.RoomName
+	// nodes without all of these labels are not selected
+	RequiredLabels map[string]string
+	// nodes with all of these labels are selected over others
+	PreferredLabels map[string]string
+	// labels of candidate nodes
+	NodeLabels NodeLabels
+	// nodes in these regions are not selected
+	ExcludedRegions []string
+	// region of the client the room is created for, if known
+	Region string
+	// all nodes, before the region and label constraints of the placement are applied
+	AllNodes []*livekit.Node
+}
+
+func (p RoomPlacement) HasLabelConstraints() bool {
+	return len(p.RequiredLabels) != 0 || len(p.PreferredLabels) != 0
+}
+
+// TierNodesByLabels returns available nodes having the required labels of the placement, in tiers to select from
+// in order. When some of them also have the preferred labels, those are a tier of their own before all of them,
+// so that other nodes are selected when none of the preferred ones can take the room.
+func TierNodesByLabels(nodes []*livekit.Node, placement RoomPlacement) ([][]*livekit.Node, error) {
+	if !placement.HasLabelConstraints() {
+		return [][]*livekit.Node{nodes}, nil
+	}
+
+	nodes = GetAvailableNodes(nodes)
+	if len(nodes) == 0 {
+		return nil, ErrNoAvailableNodes
+	}
+
+	var required, preferred []*livekit.Node
+	for _, node := range nodes {
+		labels := placement.NodeLabels[livekit.NodeID(node.Id)]
+		if !hasLabels(labels, placement.RequiredLabels) {
+			continue
+		}
+		required = append(required, node)
+		if len(placement.PreferredLabels) != 0 && hasLabels(labels, placement.PreferredLabels) {
+			preferred = append(preferred, node)
+		}
+	}
+	if len(required) == 0 {
+		return nil, ErrNoNodesWithLabels
+	}
+	if len(preferred) != 0 && len(preferred) != len(required) {
+		return [][]*livekit.Node{preferred, required}, nil
+	}
+	return [][]*livekit.Node{required}, nil
+}
+
+func hasLabels(labels map[string]string, expected map[string]string) bool {
+	for k, v := range expected {
+		if l, ok := labels[k]; !ok || l != v {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterNodesByRegion returns nodes outside of the regions excluded by the placement
+func FilterNodesByRegion(nodes []*livekit.Node, placement RoomPlacement) ([]*livekit.Node, error) {
+	if len(placement.ExcludedRegions) == 0 {
+		return nodes, nil
+	}
+
+	var allowed []*livekit.Node
+	for _, node := range nodes {
+		if !slices.Contains(placement.ExcludedRegions, node.Region) {
+			allowed = append(allowed, node)
+		}
+	}
+	if len(allowed) == 0 {
+		return nil, ErrNoNodesInAllowedRegions
+	}
+	return allowed, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/routing/selector"
+)
+
+func TestTierNodesByLabels(t *testing.T) {
+	newNode := func(id string) *livekit.Node {
+		return &livekit.Node{
+			Id:    id,
+			State: livekit.NodeState_SERVING,
+			Stats: &livekit.NodeStats{UpdatedAt: time.Now().Unix()},
+		}
+	}
+	standard := newNode("standard")
+	premium := newNode("premium")
+	premiumHighBW := newNode("premium-highbw")
+	nodes := []*livekit.Node{standard, premium, premiumHighBW}
+	nodeLabels := selector.NodeLabels{
+		"standard":       {"tier": "standard"},
+		"premium":        {"tier": "premium"},
+		"premium-highbw": {"tier": "premium", "hw": "highbw"},
+	}
+
+	t.Run("no constraints", func(t *testing.T) {
+		tiers, err := selector.TierNodesByLabels(nodes, selector.RoomPlacement{NodeLabels: nodeLabels})
+		require.NoError(t, err)
+		require.Equal(t, [][]*livekit.Node{nodes}, tiers)
+	})
+
+	t.Run("required labels", func(t *testing.T) {
+		tiers, err := selector.TierNodesByLabels(nodes, selector.RoomPlacement{
+			RequiredLabels: map[string]string{"tier": "premium"},
+			NodeLabels:     nodeLabels,
+		})
+		require.NoError(t, err)
+		require.Equal(t, [][]*livekit.Node{{premium, premiumHighBW}}, tiers)
+
+		_, err = selector.TierNodesByLabels(nodes, selector.RoomPlacement{
+			RequiredLabels: map[string]string{"tier": "enterprise"},
+			NodeLabels:     nodeLabels,
+		})
+		require.ErrorIs(t, err, selector.ErrNoNodesWithLabels)
+	})
+
+	t.Run("preferred labels", func(t *testing.T) {
+		tiers, err := selector.TierNodesByLabels(nodes, selector.RoomPlacement{
+			RequiredLabels:  map[string]string{"tier": "premium"},
+			PreferredLabels: map[string]string{"hw": "highbw"},
+			NodeLabels:      nodeLabels,
+		})
+		require.NoError(t, err)
+		require.Equal(t, [][]*livekit.Node{{premiumHighBW}, {premium, premiumHighBW}}, tiers)
+
+		// all nodes when none is preferred
+		tiers, err = selector.TierNodesByLabels(nodes, selector.RoomPlacement{
+			PreferredLabels: map[string]string{"hw": "gpu"},
+			NodeLabels:      nodeLabels,
+		})
+		require.NoError(t, err)
+		require.Equal(t, [][]*livekit.Node{nodes}, tiers)
+	})
+
+	t.Run("preferred nodes at capacity", func(t *testing.T) {
+		loaded := newNode("premium-highbw")
+		loaded.Stats.NumCpus = 1
+		loaded.Stats.LoadAvgLast1Min = 2
+		sel := &selector.SystemLoadSelector{SysloadLimit: 0.9, SortBy: "random"}
+		placement := selector.RoomPlacement{
+			RequiredLabels:  map[string]string{"tier": "premium"},
+			PreferredLabels: map[string]string{"hw": "highbw"},
+			NodeLabels:      nodeLabels,
+		}
+
+		node, err := selector.SelectNodeForRoom(sel, []*livekit.Node{standard, premium, loaded}, placement)
+		require.NoError(t, err)
+		require.Equal(t, premium, node)
+
+		node, err = selector.SelectNodeForRoom(sel, nodes, placement)
+		require.NoError(t, err)
+		require.Equal(t, premiumHighBW, node)
+		// preferred nodes refused by the selector
+		script, err := selector.NewScriptSelector(`n.id != "premium-highbw"`, "", "", "random")
+		require.NoError(t, err)
+		node, err = selector.SelectNodeForRoom(script, nodes, placement)
+		require.NoError(t, err)
+		require.Equal(t, premium, node)
+	})
+
+	t.Run("script selector sees labels", func(t *testing.T) {
+		sel, err := selector.NewScriptSelector(`n.labels.tier == "standard"`, "", "", "random")
+		require.NoError(t, err)
+
+		node, err := selector.SelectNodeForRoom(sel, nodes, selector.RoomPlacement{NodeLabels: nodeLabels})
+		require.NoError(t, err)
+		require.Equal(t, standard, node)
+	})
+}
@@ -22,12 +22,24 @@ import (
 	"github.com/livekit/livekit-server/pkg/config"
 )
 
-// RegionAwareSelector prefers available nodes that are closest to the region of the current instance
+// reasons reported by RegionAwareSelector
+const (
+	SelectionReasonCurrentRegion  = "current_region"
+	SelectionReasonClientRegion   = "client_region"
+	SelectionReasonFailoverRegion = "failover_region"
+	SelectionReasonNearestRegion  = "nearest_region"
+	SelectionReasonAnyRegion      = "any_region"
+)
+
+// RegionAwareSelector prefers available nodes in the region of the current instance, then in its failover
+// regions in order, then in the closest region. Regions at capacity are skipped.
+// When the placement has the region of the client, it is preferred over the region of the current instance.
 type RegionAwareSelector struct {
 	SystemLoadSelector
 	CurrentRegion   string
 	regionDistances map[string]float64
 	regions         []config.RegionConfig
+	failover        []string
 	SortBy          string
 }
 
@@ -57,21 +69,70 @@ func NewRegionAwareSelector(currentRegion string, regions []config.RegionConfig,
 	}
 
 	if currentRC != nil {
-		for _, region := range regions {
-			s.regionDistances[region.Name] = distanceBetween(currentRC.Lat, currentRC.Lon, region.Lat, region.Lon)
-		}
+		s.regionDistances = s.distancesFrom(currentRC)
+		s.failover = currentRC.Failover
 	}
 
 	return s, nil
 }
 
+func (s *RegionAwareSelector) distancesFrom(from *config.RegionConfig) map[string]float64 {
+	distances := make(map[string]float64, len(s.regions))
+	for _, region := range s.regions {
+		distances[region.Name] = distanceBetween(from.Lat, from.Lon, region.Lat, region.Lon)
+	}
+	return distances
+}
+
 func (s *RegionAwareSelector) SelectNode(nodes []*livekit.Node) (*livekit.Node, error) {
-	nodes, err := s.SystemLoadSelector.filterNodes(nodes)
+	node, _, err := s.SelectNodeWithReason(nodes, RoomPlacement{})
+	return node, err
+}
+
+func (s *RegionAwareSelector) SelectNodeForRoom(nodes []*livekit.Node, placement RoomPlacement) (*livekit.Node, error) {
+	node, _, err := s.SelectNodeWithReason(nodes, placement)
+	return node, err
+}
+
+func (s *RegionAwareSelector) SelectNodeWithReason(nodes []*livekit.Node, placement RoomPlacement) (*livekit.Node, string, error) {
+	allNodes := placement.AllNodes
+	if allNodes == nil {
+		allNodes = nodes
+	}
+	nodes, err := s.SystemLoadSelector.filterNodes(s.filterRegionsAtCapacity(nodes, allNodes))
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	fromRegion, firstReason, failover, distances := s.CurrentRegion, SelectionReasonCurrentRegion, s.failover, s.regionDistances
+	if placement.Region != "" && placement.Region != s.CurrentRegion {
+		for _, region := range s.regions {
+			if region.Name == placement.Region {
+				fromRegion, firstReason, failover, distances = region.Name, SelectionReasonClientRegion, region.Failover, s.distancesFrom(&region)
+				break
+			}
+		}
+	}
+
+	// find nodes in preferred regions, in order
+	for i, region := range append([]string{fromRegion}, failover...) {
+		var regionNodes []*livekit.Node
+		for _, node := range nodes {
+			if node.Region == region {
+				regionNodes = append(regionNodes, node)
+			}
+		}
+		if len(regionNodes) > 0 {
+			reason := SelectionReasonFailoverRegion
+			if i == 0 {
+				reason = firstReason
+			}
+			node, err := SelectSortedNodeWithLabels(regionNodes, s.SortBy, placement.NodeLabels)
+			return node, reason, err
+		}
 	}
 
-	// find nodes nearest to current region
+	// find nodes nearest to preferred region
 	var nearestNodes []*livekit.Node
 	nearestRegion := ""
 	minDist := math.MaxFloat64
@@ -80,7 +141,7 @@ func (s *RegionAwareSelector) SelectNode(nodes []*livekit.Node) (*livekit.Node,
 			nearestNodes = append(nearestNodes, node)
 			continue
 		}
-		if dist, ok := s.regionDistances[node.Region]; ok {
+		if dist, ok := distances[node.Region]; ok {
 			if dist < minDist {
 				minDist = dist
 				nearestRegion = node.Region
@@ -90,11 +151,61 @@ func (s *RegionAwareSelector) SelectNode(nodes []*livekit.Node) (*livekit.Node,
 		}
 	}
 
+	reason := SelectionReasonAnyRegion
 	if len(nearestNodes) > 0 {
 		nodes = nearestNodes
+		reason = SelectionReasonNearestRegion
 	}
 
-	return SelectSortedNode(nodes, s.SortBy)
+	node, err := SelectSortedNodeWithLabels(nodes, s.SortBy, placement.NodeLabels)
+	return node, reason, err
+}
+
+// filterRegionsAtCapacity removes nodes in regions which host as many rooms or clients as configured for the region,
+// counted over all nodes of the region and not only those the room can be placed on
+func (s *RegionAwareSelector) filterRegionsAtCapacity(nodes []*livekit.Node, allNodes []*livekit.Node) []*livekit.Node {
+	atCapacity := make(map[string]bool)
+	for _, region := range s.regions {
+		if region.MaxRooms <= 0 && region.MaxClients <= 0 {
+			continue
+		}
+
+		var numRooms, numClients int32
+		for _, node := range allNodes {
+			if node.Region == region.Name && node.Stats != nil && IsAvailable(node) {
+				numRooms += node.Stats.NumRooms
+				numClients += node.Stats.NumClients
+			}
+		}
+		if (region.MaxRooms > 0 && numRooms >= region.MaxRooms) ||
+			(region.MaxClients > 0 && numClients >= region.MaxClients) {
+			atCapacity[region.Name] = true
+		}
+	}
+	if len(atCapacity) == 0 {
+		return nodes
+	}
+
+	filtered := make([]*livekit.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if !atCapacity[node.Region] {
+			filtered = append(filtered, node)
+		}
+	}
+	return filtered
+}
+
+// ClosestRegion returns the name of the region closest to a location, or an empty string without regions
+func ClosestRegion(regions []config.RegionConfig, lat, lon float64) string {
+	closest := ""
+	minDist := math.MaxFloat64
+	for _, region := range regions {
+		if dist := distanceBetween(lat, lon, region.Lat, region.Lon); dist < minDist {
+			minDist = dist
+			closest = region.Name
+		}
+	}
+	return closest
 }
 
 // haversine(θ) function
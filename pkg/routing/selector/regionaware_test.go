@@ -145,6 +145,110 @@ func TestRegionAwareRouting(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, node)
 	})
+
+	t.Run("follows failover order before distance", func(t *testing.T) {
+		failoverRC := append([]config.RegionConfig{}, rc...)
+		failoverRC[2].Failover = []string{regionEast}
+
+		expectedNode := newTestNodeInRegion(regionEast, true)
+		nodes := []*livekit.Node{
+			newTestNodeInRegion(regionSeattle, false),
+			newTestNodeInRegion(regionWest, true),
+			expectedNode,
+		}
+		s, err := selector.NewRegionAwareSelector(regionSeattle, failoverRC, sortBy)
+		require.NoError(t, err)
+		s.SysloadLimit = loadLimit
+
+		node, reason, err := selector.SelectNodeForRoomWithReason(s, nodes, selector.RoomPlacement{})
+		require.NoError(t, err)
+		require.Equal(t, expectedNode, node)
+		require.Equal(t, selector.SelectionReasonFailoverRegion, reason)
+
+		// nearest region is used when failover regions are excluded
+		node, reason, err = selector.SelectNodeForRoomWithReason(s, nodes, selector.RoomPlacement{ExcludedRegions: []string{regionEast}})
+		require.NoError(t, err)
+		require.Equal(t, regionWest, node.Region)
+		require.Equal(t, selector.SelectionReasonNearestRegion, reason)
+
+		_, _, err = selector.SelectNodeForRoomWithReason(s, nodes, selector.RoomPlacement{ExcludedRegions: []string{regionEast, regionWest, regionSeattle}})
+		require.ErrorIs(t, err, selector.ErrNoNodesInAllowedRegions)
+	})
+
+	t.Run("prefers region of the client", func(t *testing.T) {
+		nodes := []*livekit.Node{
+			newTestNodeInRegion(regionEast, true),
+			newTestNodeInRegion(regionWest, true),
+		}
+		s, err := selector.NewRegionAwareSelector(regionEast, rc, sortBy)
+		require.NoError(t, err)
+		s.SysloadLimit = loadLimit
+
+		node, reason, err := s.SelectNodeWithReason(nodes, selector.RoomPlacement{Region: regionWest})
+		require.NoError(t, err)
+		require.Equal(t, regionWest, node.Region)
+		require.Equal(t, selector.SelectionReasonClientRegion, reason)
+
+		// nearest to the client when its region has no node
+		node, reason, err = s.SelectNodeWithReason(nodes, selector.RoomPlacement{Region: regionSeattle})
+		require.NoError(t, err)
+		require.Equal(t, regionWest, node.Region)
+		require.Equal(t, selector.SelectionReasonNearestRegion, reason)
+	})
+
+	t.Run("skips regions at capacity", func(t *testing.T) {
+		cappedRC := append([]config.RegionConfig{}, rc...)
+		cappedRC[1].MaxClients = 100
+
+		full := newTestNodeInRegion(regionEast, true)
+		full.Stats.NumClients = 60
+		fuller := newTestNodeInRegion(regionEast, true)
+		fuller.Stats.NumClients = 40
+		nodes := []*livekit.Node{
+			full,
+			fuller,
+			newTestNodeInRegion(regionWest, true),
+		}
+		s, err := selector.NewRegionAwareSelector(regionEast, cappedRC, sortBy)
+		require.NoError(t, err)
+		s.SysloadLimit = loadLimit
+
+		node, reason, err := s.SelectNodeWithReason(nodes, selector.RoomPlacement{})
+		require.NoError(t, err)
+		require.Equal(t, regionWest, node.Region)
+		require.Equal(t, selector.SelectionReasonNearestRegion, reason)
+
+		fuller.Stats.NumClients = 39
+		node, reason, err = s.SelectNodeWithReason(nodes, selector.RoomPlacement{})
+		require.NoError(t, err)
+		require.Equal(t, regionEast, node.Region)
+		require.Equal(t, selector.SelectionReasonCurrentRegion, reason)
+
+		// clients of nodes the room cannot be placed on count towards the region
+		fuller.Stats.NumClients = 40
+		node, err = selector.SelectNodeForRoom(s, nodes, selector.RoomPlacement{
+			RequiredLabels: map[string]string{"tier": "premium"},
+			NodeLabels: selector.NodeLabels{
+				livekit.NodeID(fuller.Id):   {"tier": "premium"},
+				livekit.NodeID(nodes[2].Id): {"tier": "premium"},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, regionWest, node.Region)
+	})
+}
+
+func TestClosestRegion(t *testing.T) {
+	rc := []config.RegionConfig{
+		{Name: regionWest, Lat: 37.64046607830567, Lon: -120.88026233189062},
+		{Name: regionEast, Lat: 40.68914362140307, Lon: -74.04445748616385},
+	}
+
+	// Boston
+	require.Equal(t, regionEast, selector.ClosestRegion(rc, 42.3601, -71.0589))
+	// Los Angeles
+	require.Equal(t, regionWest, selector.ClosestRegion(rc, 34.0522, -118.2437))
+	require.Empty(t, selector.ClosestRegion(nil, 34.0522, -118.2437))
 }
 
 func newTestNodeInRegion(region string, available bool) *livekit.Node {
@@ -0,0 +1,248 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/d5/tengo/v2"
+
+	"github.com/livekit/protocol/livekit"
+)
+
+const (
+	scriptResultVar = "__res__"
+
+	// budget of evaluating the expressions for all nodes in one selection
+	scriptTimeout = 50 * time.Millisecond
+	// max number of objects allocated by one evaluation of an expression
+	scriptMaxAllocs = 10000
+)
+
+var ErrScriptTimeout = errors.New("node selector script timed out")
+
+// ScriptSelector evaluates user-provided expressions against each available node.
+// Filter must evaluate to a bool, nodes for which it is false are not selected.
+// Score must evaluate to a number, the node with the highest score is selected and ties are broken using SortBy.
+// Expressions have access to the node as `n`, the room being placed as `room` and the region of this node as `region`.
+// Labels of the node are available as `n.labels`.
+// Evaluation is bounded in time and allocations, a selection fails when an expression exceeds them.
+//
+// expression examples:
+// filter nodes in a region with low load : n.region == "us-west" && n.sysload < 0.8
+// prefer nodes with fewer clients : -n.num_clients
+// prefer nodes with the most egress headroom : n.egress_headroom
+type ScriptSelector struct {
+	SortBy string
+	Region string
+
+	// compiled expressions hold the variables of an evaluation, selections are serialized
+	lock   sync.Mutex
+	filter *tengo.Compiled
+	score  *tengo.Compiled
+}
+
+func NewScriptSelector(filter, score, region, sortBy string) (*ScriptSelector, error) {
+	s := &ScriptSelector{
+		SortBy: sortBy,
+		Region: region,
+	}
+
+	var err error
+	if filter != "" {
+		if s.filter, err = compileNodeScript(filter); err != nil {
+			return nil, fmt.Errorf("invalid node selector filter: %w", err)
+		}
+	}
+	if score != "" {
+		if s.score, err = compileNodeScript(score); err != nil {
+			return nil, fmt.Errorf("invalid node selector score: %w", err)
+		}
+	}
+	return s, nil
+}
+
+func (s *ScriptSelector) SelectNode(nodes []*livekit.Node) (*livekit.Node, error) {
+	return s.SelectNodeForRoom(nodes, RoomPlacement{})
+}
+
+func (s *ScriptSelector) SelectNodeForRoom(nodes []*livekit.Node, placement RoomPlacement) (*livekit.Node, error) {
+	nodes = GetAvailableNodes(nodes)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), scriptTimeout)
+	defer cancel()
+
+	var selected []*livekit.Node
+	var maxScore float64
+	for _, node := range nodes {
+		if s.filter != nil {
+			res, err := s.eval(ctx, s.filter, node, placement)
+			if err != nil {
+				return nil, err
+			}
+			include, ok := res.(bool)
+			if !ok {
+				return nil, fmt.Errorf("node selector filter must evaluate to a bool, got %T", res)
+			}
+			if !include {
+				continue
+			}
+		}
+
+		var score float64
+		if s.score != nil {
+			res, err := s.eval(ctx, s.score, node, placement)
+			if err != nil {
+				return nil, err
+			}
+			switch v := res.(type) {
+			case int64:
+				score = float64(v)
+			case float64:
+				score = v
+			default:
+				return nil, fmt.Errorf("node selector score must evaluate to a number, got %T", res)
+			}
+		}
+
+		switch {
+		case len(selected) == 0 || score > maxScore:
+			selected = []*livekit.Node{node}
+			maxScore = score
+		case score == maxScore:
+			selected = append(selected, node)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, ErrNoAvailableNodes
+	}
+	if len(selected) == 1 {
+		return selected[0], nil
+	}
+
+	return SelectSortedNodeWithLabels(selected, s.SortBy, placement.NodeLabels)
+}
+
+func (s *ScriptSelector) eval(ctx context.Context, c *tengo.Compiled, node *livekit.Node, placement RoomPlacement) (interface{}, error) {
+	if err := c.Set("n", &nodeObject{node: node, labels: placement.NodeLabels[livekit.NodeID(node.Id)]}); err != nil {
+		return nil, err
+	}
+	if err := c.Set("room", string(placement.RoomName)); err != nil {
+		return nil, err
+	}
+	if err := c.Set("region", s.Region); err != nil {
+		return nil, err
+	}
+	if err := c.RunContext(ctx); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, ErrScriptTimeout
+		}
+		return nil, err
+	}
+	return c.Get(scriptResultVar).Value(), nil
+}
+
+func compileNodeScript(expr string) (*tengo.Compiled, error) {
+	script := tengo.NewScript([]byte(fmt.Sprintf("%s := (%s)", scriptResultVar, strings.TrimSpace(expr))))
+	for _, name := range []string{"n", "room", "region"} {
+		if err := script.Add(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	script.SetMaxAllocs(scriptMaxAllocs)
+	return script.Compile()
+}
+
+type nodeObject struct {
+	tengo.ObjectImpl
+	node   *livekit.Node
+	labels map[string]string
+}
+
+func (n *nodeObject) TypeName() string {
+	return "nodeObject"
+}
+
+func (n *nodeObject) String() string {
+	return n.node.String()
+}
+
+func (n *nodeObject) IndexGet(index tengo.Object) (res tengo.Object, err error) {
+	field, ok := index.(*tengo.String)
+	if !ok {
+		return nil, tengo.ErrInvalidIndexType
+	}
+
+	switch field.Value {
+	case "id":
+		return &tengo.String{Value: n.node.Id}, nil
+	case "ip":
+		return &tengo.String{Value: n.node.Ip}, nil
+	case "region":
+		return &tengo.String{Value: n.node.Region}, nil
+	case "num_cpus":
+		return &tengo.Int{Value: int64(n.node.NumCpus)}, nil
+	case "labels":
+		labels := make(map[string]tengo.Object, len(n.labels))
+		for k, v := range n.labels {
+			labels[k] = &tengo.String{Value: v}
+		}
+		return &tengo.ImmutableMap{Value: labels}, nil
+	}
+
+	stats := n.node.Stats
+	if stats == nil {
+		return &tengo.Undefined{}, nil
+	}
+	switch field.Value {
+	case "sysload":
+		return &tengo.Float{Value: float64(GetNodeSysload(n.node))}, nil
+	case "cpu_load":
+		return &tengo.Float{Value: float64(stats.CpuLoad)}, nil
+	case "memory_load":
+		return &tengo.Float{Value: float64(stats.MemoryLoad)}, nil
+	case "load_avg_last_1min":
+		return &tengo.Float{Value: float64(stats.LoadAvgLast1Min)}, nil
+	case "num_rooms":
+		return &tengo.Int{Value: int64(stats.NumRooms)}, nil
+	case "num_clients":
+		return &tengo.Int{Value: int64(stats.NumClients)}, nil
+	case "num_tracks_in":
+		return &tengo.Int{Value: int64(stats.NumTracksIn)}, nil
+	case "num_tracks_out":
+		return &tengo.Int{Value: int64(stats.NumTracksOut)}, nil
+	case "bytes_in_per_sec":
+		return &tengo.Float{Value: float64(stats.BytesInPerSec)}, nil
+	case "bytes_out_per_sec":
+		return &tengo.Float{Value: float64(stats.BytesOutPerSec)}, nil
+	case "packets_out_per_sec":
+		return &tengo.Float{Value: float64(stats.PacketsOutPerSec)}, nil
+	case "nack_per_sec":
+		return &tengo.Float{Value: float64(stats.NackPerSec)}, nil
+	case "egress_headroom":
+		if headroom, ok := EgressHeadroom(n.node, n.labels); ok {
+			return &tengo.Float{Value: float64(headroom)}, nil
+		}
+	}
+	return &tengo.Undefined{}, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/routing/selector"
+)
+
+func TestScriptSelector_SelectNode(t *testing.T) {
+	nodes := []*livekit.Node{nodeLoadLow, nodeLoadMedium, nodeLoadHigh}
+
+	t.Run("filters nodes", func(t *testing.T) {
+		sel, err := selector.NewScriptSelector("n.sysload > 0.1 && n.num_clients < 20", "", "", "random")
+		require.NoError(t, err)
+
+		for i := 0; i < 10; i++ {
+			node, err := sel.SelectNode(nodes)
+			require.NoError(t, err)
+			require.Equal(t, nodeLoadMedium, node)
+		}
+	})
+
+	t.Run("selects highest score", func(t *testing.T) {
+		sel, err := selector.NewScriptSelector("", "n.num_tracks_out", "", "random")
+		require.NoError(t, err)
+
+		node, err := sel.SelectNode(nodes)
+		require.NoError(t, err)
+		require.Equal(t, nodeLoadHigh, node)
+	})
+
+	t.Run("breaks ties using sort by", func(t *testing.T) {
+		sel, err := selector.NewScriptSelector("", "1", "", "clients")
+		require.NoError(t, err)
+
+		node, err := sel.SelectNode(nodes)
+		require.NoError(t, err)
+		require.Equal(t, nodeLoadLow, node)
+	})
+
+	t.Run("uses room and region", func(t *testing.T) {
+		east := &livekit.Node{
+			Id:     "east",
+			Region: "us-east",
+			State:  livekit.NodeState_SERVING,
+			Stats:  &livekit.NodeStats{UpdatedAt: time.Now().Unix()},
+		}
+		west := &livekit.Node{
+			Id:     "west",
+			Region: "us-west",
+			State:  livekit.NodeState_SERVING,
+			Stats:  &livekit.NodeStats{UpdatedAt: time.Now().Unix()},
+		}
+
+		sel, err := selector.NewScriptSelector(`n.region == region || room == "global"`, "", "us-west", "random")
+		require.NoError(t, err)
+
+		node, err := selector.SelectNodeForRoom(sel, []*livekit.Node{east, west}, selector.RoomPlacement{RoomName: "local"})
+		require.NoError(t, err)
+		require.Equal(t, west, node)
+
+		sel, err = selector.NewScriptSelector(`n.region == region || room == "global"`, `n.id == "east" ? 1 : 0`, "us-west", "random")
+		require.NoError(t, err)
+
+		node, err = selector.SelectNodeForRoom(sel, []*livekit.Node{east, west}, selector.RoomPlacement{RoomName: "global"})
+		require.NoError(t, err)
+		require.Equal(t, east, node)
+	})
+
+	t.Run("no node passes filter", func(t *testing.T) {
+		sel, err := selector.NewScriptSelector("false", "", "", "random")
+		require.NoError(t, err)
+
+		_, err = sel.SelectNode(nodes)
+		require.ErrorIs(t, err, selector.ErrNoAvailableNodes)
+	})
+
+	t.Run("invalid expressions", func(t *testing.T) {
+		_, err := selector.NewScriptSelector("n.sysload <", "", "", "random")
+		require.Error(t, err)
+
+		sel, err := selector.NewScriptSelector("n.num_rooms", "", "", "random")
+		require.NoError(t, err)
+		_, err = sel.SelectNode(nodes)
+		require.Error(t, err)
+
+		sel, err = selector.NewScriptSelector("", `"high"`, "", "random")
+		require.NoError(t, err)
+		_, err = sel.SelectNode(nodes)
+		require.Error(t, err)
+	})
+}
+
+func TestScriptSelector_Limits(t *testing.T) {
+	nodes := []*livekit.Node{nodeLoadLow, nodeLoadMedium, nodeLoadHigh}
+
+	t.Run("times out", func(t *testing.T) {
+		sel, err := selector.NewScriptSelector("func() { for {} }()", "", "", "random")
+		require.NoError(t, err)
+
+		start := time.Now()
+		_, err = sel.SelectNode(nodes)
+		require.ErrorIs(t, err, selector.ErrScriptTimeout)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("limits allocations", func(t *testing.T) {
+		sel, err := selector.NewScriptSelector("", "func() { a := []; for i := 0; i < 100000; i++ { a = append(a, [i]) }; return len(a) }()", "", "random")
+		require.NoError(t, err)
+
+		_, err = sel.SelectNode(nodes)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, selector.ErrScriptTimeout)
+	})
+}
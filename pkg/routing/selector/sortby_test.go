@@ -56,7 +56,7 @@ func TestSortByErrors(t *testing.T) {
 }
 
 func TestSortBy(t *testing.T) {
-	sortByTests := []string{"sysload", "cpuload", "rooms", "clients", "tracks", "bytespersec"}
+	sortByTests := []string{"sysload", "cpuload", "rooms", "clients", "tracks", "bytespersec", "egress"}
 
 	for _, sortBy := range sortByTests {
 		SortByTest(t, sortBy)
@@ -43,11 +43,19 @@ func (s *SystemLoadSelector) filterNodes(nodes []*livekit.Node) ([]*livekit.Node
 	return nodes, nil
 }
 
+func (s *SystemLoadSelector) HasCapacity(node *livekit.Node) bool {
+	return node.Stats == nil || GetNodeSysload(node) < s.SysloadLimit
+}
+
 func (s *SystemLoadSelector) SelectNode(nodes []*livekit.Node) (*livekit.Node, error) {
+	return s.SelectNodeForRoom(nodes, RoomPlacement{})
+}
+
+func (s *SystemLoadSelector) SelectNodeForRoom(nodes []*livekit.Node, placement RoomPlacement) (*livekit.Node, error) {
 	nodes, err := s.filterNodes(nodes)
 	if err != nil {
 		return nil, err
 	}
 
-	return SelectSortedNode(nodes, s.SortBy)
+	return SelectSortedNodeWithLabels(nodes, s.SortBy, placement.NodeLabels)
 }
@@ -16,6 +16,7 @@ package selector
 
 import (
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/thoas/go-funk"
@@ -65,11 +66,46 @@ func LimitsReached(limitConfig config.LimitConfig, nodeStats *livekit.NodeStats)
 	if limitConfig.BytesPerSec > 0 && limitConfig.BytesPerSec <= nodeStats.BytesInPerSec+nodeStats.BytesOutPerSec {
 		return true
 	}
+	if limitConfig.EgressBytesPerSec > 0 && limitConfig.EgressBytesPerSec <= nodeStats.BytesOutPerSec {
+		return true
+	}
 
 	return false
 }
 
+// EgressBudgetLabel is the node label advertising the egress budget of a node in bytes per second.
+// NodeStats carries the measured egress, the difference is the headroom of the node.
+const EgressBudgetLabel = "livekit.egress_budget"
+
+// EgressHeadroom returns how many bytes per second a node can send before reaching its advertised budget
+func EgressHeadroom(node *livekit.Node, labels map[string]string) (float32, bool) {
+	budget, err := strconv.ParseFloat(labels[EgressBudgetLabel], 32)
+	if err != nil || node.Stats == nil {
+		return 0, false
+	}
+	return float32(budget) - node.Stats.BytesOutPerSec, true
+}
+
+// EgressBudgetExceeded checks if the egress of a node would go over the configured budget with one more client,
+// the client is expected to receive as much as the average client on the node
+func EgressBudgetExceeded(limitConfig config.LimitConfig, nodeStats *livekit.NodeStats) bool {
+	if limitConfig.EgressBytesPerSec <= 0 || nodeStats == nil {
+		return false
+	}
+
+	expected := nodeStats.BytesOutPerSec
+	if nodeStats.NumClients > 0 {
+		expected += nodeStats.BytesOutPerSec / float32(nodeStats.NumClients)
+	}
+	return expected > limitConfig.EgressBytesPerSec
+}
+
 func SelectSortedNode(nodes []*livekit.Node, sortBy string) (*livekit.Node, error) {
+	return SelectSortedNodeWithLabels(nodes, sortBy, nil)
+}
+
+// SelectSortedNodeWithLabels is like SelectSortedNode, the labels of the nodes give the egress budgets they advertise
+func SelectSortedNodeWithLabels(nodes []*livekit.Node, sortBy string, labels NodeLabels) (*livekit.Node, error) {
 	if sortBy == "" {
 		return nil, ErrSortByNotSet
 	}
@@ -104,6 +140,20 @@ func SelectSortedNode(nodes []*livekit.Node, sortBy string) (*livekit.Node, erro
 			return nodes[i].Stats.NumTracksIn+nodes[i].Stats.NumTracksOut < nodes[j].Stats.NumTracksIn+nodes[j].Stats.NumTracksOut
 		})
 		return nodes[0], nil
+	case "egress":
+		// nodes with the most headroom below their budget first, then nodes without a budget sending the least
+		sort.Slice(nodes, func(i, j int) bool {
+			hi, oki := EgressHeadroom(nodes[i], labels[livekit.NodeID(nodes[i].Id)])
+			hj, okj := EgressHeadroom(nodes[j], labels[livekit.NodeID(nodes[j].Id)])
+			if oki && okj {
+				return hi > hj
+			}
+			if oki != okj {
+				return oki
+			}
+			return nodes[i].Stats.BytesOutPerSec < nodes[j].Stats.BytesOutPerSec
+		})
+		return nodes[0], nil
 	case "bytespersec":
 		sort.Slice(nodes, func(i, j int) bool {
 			return nodes[i].Stats.BytesInPerSec+nodes[i].Stats.BytesOutPerSec < nodes[j].Stats.BytesInPerSec+nodes[j].Stats.BytesOutPerSec
@@ -22,6 +22,7 @@ import (
 
 	"github.com/livekit/protocol/livekit"
 
+	"github.com/livekit/livekit-server/pkg/config"
 	"github.com/livekit/livekit-server/pkg/routing/selector"
 )
 
@@ -44,3 +45,60 @@ func TestIsAvailable(t *testing.T) {
 		require.False(t, selector.IsAvailable(n))
 	})
 }
+
+func TestEgressBudget(t *testing.T) {
+	limit := config.LimitConfig{EgressBytesPerSec: 10500}
+
+	t.Run("disabled", func(t *testing.T) {
+		require.False(t, selector.EgressBudgetExceeded(config.LimitConfig{}, nodeLoadHigh.Stats))
+		require.False(t, selector.LimitsReached(config.LimitConfig{}, nodeLoadHigh.Stats))
+	})
+
+	t.Run("room for another client", func(t *testing.T) {
+		// 2000 bytes/s to 2 clients
+		require.False(t, selector.EgressBudgetExceeded(limit, nodeLoadLow.Stats))
+		require.False(t, selector.LimitsReached(limit, nodeLoadLow.Stats))
+	})
+
+	t.Run("another client would exceed budget", func(t *testing.T) {
+		// 10000 bytes/s to 10 clients
+		require.True(t, selector.EgressBudgetExceeded(limit, nodeLoadMedium.Stats))
+		require.False(t, selector.LimitsReached(limit, nodeLoadMedium.Stats))
+	})
+
+	t.Run("over budget", func(t *testing.T) {
+		require.True(t, selector.EgressBudgetExceeded(limit, nodeLoadHigh.Stats))
+		require.True(t, selector.LimitsReached(limit, nodeLoadHigh.Stats))
+	})
+}
+
+func TestEgressHeadroom(t *testing.T) {
+	node := &livekit.Node{Stats: &livekit.NodeStats{BytesOutPerSec: 300}}
+
+	headroom, ok := selector.EgressHeadroom(node, map[string]string{selector.EgressBudgetLabel: "1000"})
+	require.True(t, ok)
+	require.Equal(t, float32(700), headroom)
+
+	_, ok = selector.EgressHeadroom(node, nil)
+	require.False(t, ok)
+}
+
+func TestSelectSortedNodeByEgressHeadroom(t *testing.T) {
+	small := &livekit.Node{Id: "small", Stats: &livekit.NodeStats{BytesOutPerSec: 100}}
+	large := &livekit.Node{Id: "large", Stats: &livekit.NodeStats{BytesOutPerSec: 500}}
+	unknown := &livekit.Node{Id: "unknown", Stats: &livekit.NodeStats{BytesOutPerSec: 0}}
+	labels := selector.NodeLabels{
+		"small": {selector.EgressBudgetLabel: "200"},
+		"large": {selector.EgressBudgetLabel: "1000"},
+	}
+
+	// the node sending more has more headroom below its budget
+	node, err := selector.SelectSortedNodeWithLabels([]*livekit.Node{small, unknown, large}, "egress", labels)
+	require.NoError(t, err)
+	require.Equal(t, large, node)
+
+	// without budgets, the node sending the least
+	node, err = selector.SelectSortedNodeWithLabels([]*livekit.Node{small, large}, "egress", nil)
+	require.NoError(t, err)
+	require.Equal(t, small, node)
+}
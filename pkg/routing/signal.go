@@ -20,22 +20,40 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/atomic"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/livekit/livekit-server/pkg/config"
 	"github.com/livekit/livekit-server/pkg/telemetry/prometheus"
+	"github.com/livekit/livekit-server/pkg/telemetry/tracing"
 	"github.com/livekit/protocol/livekit"
 	"github.com/livekit/protocol/logger"
 	"github.com/livekit/protocol/rpc"
 	"github.com/livekit/protocol/utils/guid"
 	"github.com/livekit/psrpc"
+	"github.com/livekit/psrpc/pkg/metadata"
 	"github.com/livekit/psrpc/pkg/middleware"
 )
 
 var ErrSignalWriteFailed = errors.New("signal write failed")
 var ErrSignalMessageDropped = errors.New("signal message dropped")
 
+// StartSession has no field for the single peer connection mode, it is set in the metadata of the relay request.
+// Nodes which do not know about it ignore it.
+const singlePeerConnectionMetadataKey = "lk-single-peer-connection"
+
+// WithSinglePeerConnection marks an outgoing signal relay request as using a single peer connection
+func WithSinglePeerConnection(ctx context.Context) context.Context {
+	return metadata.AppendMetadataToOutgoingContext(ctx, singlePeerConnectionMetadataKey, "true")
+}
+
+// IsSinglePeerConnection checks if an incoming signal relay request uses a single peer connection
+func IsSinglePeerConnection(ctx context.Context) bool {
+	head := metadata.IncomingHeader(ctx)
+	return head != nil && head.Metadata[singlePeerConnectionMetadataKey] == "true"
+}
+
 //counterfeiter:generate . SignalClient
 type SignalClient interface {
 	ActiveCount() int
@@ -83,6 +101,17 @@ func (r *signalClient) StartParticipantSignal(
 	err error,
 ) {
 	connectionID = livekit.ConnectionID(guid.New("CO_"))
+
+	ctx, span := tracing.Start(ctx, "SignalClient.StartParticipantSignal", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		tracing.AttrRoomName.String(string(roomName)),
+		tracing.AttrParticipantIdentity.String(string(pi.Identity)),
+		tracing.AttrConnectionID.String(string(connectionID)),
+		tracing.AttrNodeID.String(string(nodeID)),
+	))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
 	ss, err := pi.ToStartSession(roomName, connectionID)
 	if err != nil {
 		return
@@ -97,7 +126,10 @@ func (r *signalClient) StartParticipantSignal(
 
 	l.Debugw("starting signal connection")
 
-	stream, err := r.client.RelaySignal(ctx, nodeID)
+	if pi.UseSinglePeerConnection {
+		ctx = WithSinglePeerConnection(ctx)
+	}
+	stream, err := r.client.RelaySignal(tracing.InjectPSRPC(ctx), nodeID)
 	if err != nil {
 		prometheus.MessageCounter.WithLabelValues("signal", "failure").Add(1)
 		return
@@ -28,6 +28,7 @@ var (
 	ErrDataChannelUnavailable   = errors.New("data channel is not available")
 	ErrDataChannelBufferFull    = errors.New("data channel buffer is full")
 	ErrTransportFailure         = errors.New("transport failure")
+	ErrNegotiationFailed        = errors.New("negotiation failed")
 	ErrEmptyIdentity            = errors.New("participant identity cannot be empty")
 	ErrEmptyParticipantID       = errors.New("participant ID cannot be empty")
 	ErrMissingGrants            = errors.New("VideoGrant is missing")
@@ -44,4 +45,15 @@ var (
 	ErrSubscriptionLimitExceeded = errors.New("participant has exceeded its subscription limit")
 
 	ErrNoSubscribeMetricsPermission = errors.New("participant is not given permission to subscribe to metrics")
+
+	// Plain transport related
+	ErrPlainTransportNoTracks  = errors.New("plain transport has no tracks")
+	ErrPlainTransportSSRCInUse = errors.New("SSRC is already in use")
+	ErrPlainTransportSRTPKey   = errors.New("invalid SRTP key material")
+	ErrPlainTransportExists    = errors.New("participant already has a plain transport")
+	ErrPlainEgressNoReceiver   = errors.New("track has no receiver to forward")
+
+	// Media file related
+	ErrMediaFileSourceNoTracks = errors.New("media file source has no tracks")
+	ErrMediaFileSourceExists   = errors.New("participant already publishes media files")
 )
@@ -0,0 +1,154 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mediafile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pion/webrtc/v4"
+	"github.com/pion/webrtc/v4/pkg/media/h264reader"
+
+	"github.com/livekit/livekit-server/pkg/sfu/mime"
+)
+
+// profile-level-id when the stream does not start with a sequence parameter set, constrained baseline 3.1
+const h264DefaultProfileLevelID = "42e01f"
+
+var annexBStartCode = []byte{0, 0, 0, 1}
+
+// h264Reader groups the NAL units of an Annex-B H264 stream into access units.
+// The stream has no timing, access units are spaced at the frame rate.
+type h264Reader struct {
+	f         *os.File
+	r         *h264reader.H264Reader
+	codec     webrtc.RTPCodecCapability
+	frameRate float64
+	frames    int64
+	// read ahead, the first NAL units of the stream or the one starting the next access unit
+	pending []*h264reader.NAL
+}
+
+func newH264Reader(f *os.File, opts Options) (Reader, error) {
+	hr, err := h264reader.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	r := &h264Reader{
+		f:         f,
+		r:         hr,
+		frameRate: opts.FrameRate,
+	}
+	if r.frameRate <= 0 {
+		r.frameRate = DefaultFrameRate
+	}
+
+	// profile and level of the first sequence parameter set, before the first slice
+	profileLevelID := h264DefaultProfileLevelID
+	for {
+		nal, err := r.r.NextNAL()
+		if err != nil {
+			if errors.Is(err, io.EOF) && len(r.pending) != 0 {
+				break
+			}
+			return nil, fmt.Errorf("%w: not an Annex-B H264 stream", ErrInvalidFile)
+		}
+		r.pending = append(r.pending, nal)
+		if nal.UnitType == h264reader.NalUnitTypeSPS && len(nal.Data) >= 4 {
+			profileLevelID = fmt.Sprintf("%02x%02x%02x", nal.Data[1], nal.Data[2], nal.Data[3])
+			break
+		}
+		if isH264VCL(nal) {
+			break
+		}
+	}
+
+	r.codec = webrtc.RTPCodecCapability{
+		MimeType:    mime.MimeTypeH264.String(),
+		ClockRate:   90000,
+		SDPFmtpLine: "level-asymmetry-allowed=1;packetization-mode=1;profile-level-id=" + profileLevelID,
+	}
+	return r, nil
+}
+
+func (r *h264Reader) Codec() webrtc.RTPCodecCapability {
+	return r.codec
+}
+
+func (r *h264Reader) ReadFrame() (*Frame, error) {
+	var data []byte
+	hasVCL := false
+	for {
+		nal, err := r.nextNAL()
+		if err != nil {
+			if errors.Is(err, io.EOF) && hasVCL {
+				break
+			}
+			return nil, err
+		}
+
+		if hasVCL && startsH264AccessUnit(nal) {
+			r.pending = append([]*h264reader.NAL{nal}, r.pending...)
+			break
+		}
+		data = append(data, annexBStartCode...)
+		data = append(data, nal.Data...)
+		if isH264VCL(nal) {
+			hasVCL = true
+		}
+	}
+
+	frame := &Frame{
+		Data:      data,
+		Timestamp: time.Duration(float64(r.frames) * float64(time.Second) / r.frameRate),
+	}
+	r.frames++
+	return frame, nil
+}
+
+func (r *h264Reader) Close() error {
+	return r.f.Close()
+}
+
+func (r *h264Reader) nextNAL() (*h264reader.NAL, error) {
+	if len(r.pending) != 0 {
+		nal := r.pending[0]
+		r.pending = r.pending[1:]
+		return nal, nil
+	}
+	return r.r.NextNAL()
+}
+
+func isH264VCL(nal *h264reader.NAL) bool {
+	return nal.UnitType >= h264reader.NalUnitTypeCodedSliceNonIdr && nal.UnitType <= h264reader.NalUnitTypeCodedSliceIdr
+}
+
+// startsH264AccessUnit tells whether a NAL unit following a slice starts the next access unit (H.264 7.4.1.2.3)
+func startsH264AccessUnit(nal *h264reader.NAL) bool {
+	switch {
+	case isH264VCL(nal):
+		// first_mb_in_slice is 0, its Exp-Golomb code is a single 1 bit
+		return len(nal.Data) > 1 && nal.Data[1]&0x80 != 0
+	case nal.UnitType >= h264reader.NalUnitTypeSEI && nal.UnitType <= h264reader.NalUnitTypeAUD:
+		return true
+	case nal.UnitType >= 14 && nal.UnitType <= 18:
+		return true
+	default:
+		return false
+	}
+}
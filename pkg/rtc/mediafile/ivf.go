@@ -0,0 +1,117 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mediafile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pion/webrtc/v4"
+
+	"github.com/livekit/livekit-server/pkg/sfu/mime"
+)
+
+const (
+	ivfFileHeaderSize  = 32
+	ivfFrameHeaderSize = 12
+	// larger frames are taken as a corrupt file
+	ivfMaxFrameSize = 16 << 20
+)
+
+// ivfReader reads the frames of an IVF file with VP8, VP9 or AV1.
+// Timestamps are converted from the time base of the file, the IVF reader of pion rounds them.
+type ivfReader struct {
+	f     *os.File
+	r     *bufio.Reader
+	codec webrtc.RTPCodecCapability
+	// time base of timestamps
+	numerator   uint64
+	denominator uint64
+}
+
+func newIVFReader(f *os.File, _ Options) (Reader, error) {
+	r := &ivfReader{
+		f: f,
+		r: bufio.NewReader(f),
+	}
+
+	header := make([]byte, ivfFileHeaderSize)
+	if err := readHeader(r.r, header); err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(header, []byte("DKIF")) {
+		return nil, fmt.Errorf("%w: not an IVF file", ErrInvalidFile)
+	}
+	// the header may be larger in future versions
+	if headerSize := int(binary.LittleEndian.Uint16(header[6:8])); headerSize > ivfFileHeaderSize {
+		if _, err := r.r.Discard(headerSize - ivfFileHeaderSize); err != nil {
+			return nil, fmt.Errorf("%w: truncated header", ErrInvalidFile)
+		}
+	}
+
+	switch fourCC := string(header[8:12]); fourCC {
+	case "VP80":
+		r.codec = webrtc.RTPCodecCapability{MimeType: mime.MimeTypeVP8.String(), ClockRate: 90000}
+	case "VP90":
+		r.codec = webrtc.RTPCodecCapability{MimeType: mime.MimeTypeVP9.String(), ClockRate: 90000, SDPFmtpLine: "profile-id=0"}
+	case "AV01":
+		r.codec = webrtc.RTPCodecCapability{MimeType: mime.MimeTypeAV1.String(), ClockRate: 90000}
+	default:
+		return nil, fmt.Errorf("%w: IVF %q", ErrUnsupportedCodec, fourCC)
+	}
+
+	r.denominator = uint64(binary.LittleEndian.Uint32(header[16:20]))
+	r.numerator = uint64(binary.LittleEndian.Uint32(header[20:24]))
+	if r.denominator == 0 || r.numerator == 0 {
+		return nil, fmt.Errorf("%w: invalid time base", ErrInvalidFile)
+	}
+	return r, nil
+}
+
+func (r *ivfReader) Codec() webrtc.RTPCodecCapability {
+	return r.codec
+}
+
+func (r *ivfReader) ReadFrame() (*Frame, error) {
+	header := make([]byte, ivfFrameHeaderSize)
+	if err := readFull(r.r, header); err != nil {
+		return nil, err
+	}
+	size := binary.LittleEndian.Uint32(header[:4])
+	if size > ivfMaxFrameSize {
+		return nil, fmt.Errorf("%w: frame of %d bytes", ErrInvalidFile, size)
+	}
+	data := make([]byte, size)
+	if err := readFull(r.r, data); err != nil {
+		return nil, err
+	}
+
+	// time base is numerator/denominator seconds
+	pts := binary.LittleEndian.Uint64(header[4:12])
+	seconds := pts * r.numerator / r.denominator
+	remainder := pts * r.numerator % r.denominator
+	return &Frame{
+		Data:      data,
+		Timestamp: time.Duration(seconds)*time.Second + time.Duration(remainder*uint64(time.Second)/r.denominator),
+	}, nil
+}
+
+func (r *ivfReader) Close() error {
+	return r.f.Close()
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mediafile reads encoded frames with their timing from container files, to be published as is.
+package mediafile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pion/webrtc/v4"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported media file format")
+	ErrUnsupportedCodec  = errors.New("unsupported media file codec")
+	ErrInvalidFile       = errors.New("invalid media file")
+)
+
+// frame rate of files without timing, i. e. Annex-B H264, when not given
+const DefaultFrameRate = 30
+
+// Frame is an encoded frame, an Opus packet or a video access unit
+type Frame struct {
+	Data []byte
+	// presentation time from the start of the file
+	Timestamp time.Duration
+}
+
+type Reader interface {
+	Codec() webrtc.RTPCodecCapability
+	// ReadFrame returns the next frame, io.EOF at the end of the file
+	ReadFrame() (*Frame, error)
+	Close() error
+}
+
+type Options struct {
+	// frame rate of files without timing, DefaultFrameRate when not set
+	FrameRate float64
+}
+
+// Open opens a media file, the format is given by the extension:
+//   - .ogg, .opus: Ogg/Opus
+//   - .ivf: IVF with VP8, VP9 or AV1
+//   - .h264, .264: Annex-B H264
+func Open(path string, opts Options) (Reader, error) {
+	var newReader func(f *os.File, opts Options) (Reader, error)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ogg", ".opus":
+		newReader = newOggOpusReader
+	case ".ivf":
+		newReader = newIVFReader
+	case ".h264", ".264":
+		newReader = newH264Reader
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, filepath.Base(path))
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := newReader(f, opts)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// readFull reads a part of a file, a truncated last part is dropped as the end of the file
+func readFull(r io.Reader, b []byte) error {
+	if _, err := io.ReadFull(r, b); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return io.EOF
+		}
+		return err
+	}
+	return nil
+}
+
+// readHeader reads the header of a file
+func readHeader(r io.Reader, b []byte) error {
+	if err := readFull(r, b); err != nil {
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("%w: truncated header", ErrInvalidFile)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mediafile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/livekit-server/pkg/sfu/mime"
+)
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func readFrames(t *testing.T, r Reader) []*Frame {
+	var frames []*Frame
+	for {
+		frame, err := r.ReadFrame()
+		if err == io.EOF {
+			return frames
+		}
+		require.NoError(t, err)
+		frames = append(frames, frame)
+	}
+}
+
+func oggPage(headerType byte, serial uint32, lacing []byte, data []byte) []byte {
+	header := make([]byte, oggPageHeaderSize)
+	copy(header, "OggS")
+	header[5] = headerType
+	binary.LittleEndian.PutUint32(header[14:], serial)
+	header[26] = byte(len(lacing))
+	return append(append(header, lacing...), data...)
+}
+
+func TestOggOpus(t *testing.T) {
+	// 20 ms CELT frames
+	packet := func(size int) []byte {
+		return append([]byte{19 << 3}, bytes.Repeat([]byte{0xaa}, size-1)...)
+	}
+	head := append([]byte("OpusHead"), 1, 2, 0, 0, 0x80, 0xbb, 0, 0, 0, 0, 0)
+	p1, p2, p3 := packet(300), packet(10), packet(260)
+
+	var file []byte
+	file = append(file, oggPage(oggHeaderTypeBeginningOfStream, 1, []byte{19}, head)...)
+	file = append(file, oggPage(0, 1, []byte{16}, []byte("OpusTags01234567"))...)
+	// two packets on a page, the first one laced over two segments
+	file = append(file, oggPage(0, 1, []byte{255, 45, 10}, append(append([]byte{}, p1...), p2...))...)
+	// another logical stream is ignored
+	file = append(file, oggPage(oggHeaderTypeBeginningOfStream, 2, []byte{3}, []byte{1, 2, 3})...)
+	// a packet continued on the next page
+	file = append(file, oggPage(0, 1, []byte{255}, p3[:255])...)
+	file = append(file, oggPage(oggHeaderTypeContinued, 1, []byte{5}, p3[255:])...)
+
+	r, err := Open(writeFile(t, "audio.ogg", file), Options{})
+	require.NoError(t, err)
+	defer r.Close()
+	require.Equal(t, mime.MimeTypeOpus.String(), r.Codec().MimeType)
+	require.EqualValues(t, 48000, r.Codec().ClockRate)
+
+	frames := readFrames(t, r)
+	require.Len(t, frames, 3)
+	for i, p := range [][]byte{p1, p2, p3} {
+		require.Equal(t, p, frames[i].Data)
+		require.Equal(t, time.Duration(i)*20*time.Millisecond, frames[i].Timestamp)
+	}
+
+	_, err = Open(writeFile(t, "audio.ogg", oggPage(oggHeaderTypeBeginningOfStream, 1, []byte{4}, []byte("Opus"))), Options{})
+	require.ErrorIs(t, err, ErrInvalidFile)
+}
+
+func TestOpusPacketDuration(t *testing.T) {
+	require.Equal(t, 20*time.Millisecond, opusPacketDuration([]byte{19 << 3}))
+	require.Equal(t, 5*time.Millisecond, opusPacketDuration([]byte{17 << 3}))
+	require.Equal(t, 60*time.Millisecond, opusPacketDuration([]byte{3 << 3}))
+	require.Equal(t, 40*time.Millisecond, opusPacketDuration([]byte{19<<3 | 1}))
+	require.Equal(t, 60*time.Millisecond, opusPacketDuration([]byte{19<<3 | 3, 3}))
+	require.Equal(t, time.Duration(0), opusPacketDuration(nil))
+}
+
+func TestIVF(t *testing.T) {
+	header := make([]byte, ivfFileHeaderSize)
+	copy(header, "DKIF")
+	binary.LittleEndian.PutUint16(header[6:], ivfFileHeaderSize)
+	copy(header[8:], "VP80")
+	binary.LittleEndian.PutUint32(header[16:], 30000)
+	binary.LittleEndian.PutUint32(header[20:], 1001)
+
+	file := header
+	for pts := range 3 {
+		frame := make([]byte, ivfFrameHeaderSize)
+		binary.LittleEndian.PutUint32(frame, 4)
+		binary.LittleEndian.PutUint64(frame[4:], uint64(pts))
+		file = append(append(file, frame...), byte(pts), 1, 2, 3)
+	}
+	// truncated frame at the end
+	file = append(file, 9, 0, 0, 0)
+
+	r, err := Open(writeFile(t, "video.ivf", file), Options{})
+	require.NoError(t, err)
+	defer r.Close()
+	require.Equal(t, mime.MimeTypeVP8.String(), r.Codec().MimeType)
+
+	frames := readFrames(t, r)
+	require.Len(t, frames, 3)
+	for pts, frame := range frames {
+		require.Equal(t, []byte{byte(pts), 1, 2, 3}, frame.Data)
+		require.Equal(t, time.Duration(int64(pts)*1001*int64(time.Second)/30000), frame.Timestamp)
+	}
+
+	copy(header[8:], "H264")
+	_, err = Open(writeFile(t, "video.ivf", header), Options{})
+	require.ErrorIs(t, err, ErrUnsupportedCodec)
+}
+
+func TestH264AnnexB(t *testing.T) {
+	nals := [][]byte{
+		{0x67, 0x42, 0xc0, 0x1f, 0xaa}, // SPS
+		{0x68, 0xce, 0x3c, 0x80},       // PPS
+		{0x65, 0x88, 0x84, 0x00},       // IDR, first slice
+		{0x65, 0x40, 0x84, 0x00},       // IDR, second slice of the same picture
+		{0x41, 0x9a, 0x02, 0x00},       // non-IDR, next picture
+		{0x09, 0x10},                   // AUD
+		{0x41, 0x9a, 0x04, 0x00},       // non-IDR
+	}
+	var file []byte
+	for _, nal := range nals {
+		file = append(append(file, annexBStartCode...), nal...)
+	}
+	annexB := func(nals ...[]byte) []byte {
+		var data []byte
+		for _, nal := range nals {
+			data = append(append(data, annexBStartCode...), nal...)
+		}
+		return data
+	}
+
+	r, err := Open(writeFile(t, "video.h264", file), Options{FrameRate: 25})
+	require.NoError(t, err)
+	defer r.Close()
+	require.Equal(t, mime.MimeTypeH264.String(), r.Codec().MimeType)
+	require.Contains(t, r.Codec().SDPFmtpLine, "profile-level-id=42c01f")
+
+	frames := readFrames(t, r)
+	require.Len(t, frames, 3)
+	require.Equal(t, annexB(nals[0], nals[1], nals[2], nals[3]), frames[0].Data)
+	require.Equal(t, annexB(nals[4]), frames[1].Data)
+	require.Equal(t, annexB(nals[5], nals[6]), frames[2].Data)
+	for i, frame := range frames {
+		require.Equal(t, time.Duration(i)*40*time.Millisecond, frame.Timestamp)
+	}
+}
+
+func TestOpenUnsupported(t *testing.T) {
+	_, err := Open(writeFile(t, "video.mp4", []byte{0}), Options{})
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
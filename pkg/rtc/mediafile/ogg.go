@@ -0,0 +1,198 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mediafile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pion/webrtc/v4"
+
+	"github.com/livekit/livekit-server/pkg/sfu/mime"
+)
+
+const (
+	oggPageHeaderSize = 27
+
+	oggHeaderTypeContinued         = 0x01
+	oggHeaderTypeBeginningOfStream = 0x02
+)
+
+// oggOpusReader reads the Opus packets of the first logical stream of an Ogg file (RFC 7845).
+// Unlike pages, packets are what is sent in RTP, they are reassembled from the lacing values of pages.
+type oggOpusReader struct {
+	f *os.File
+	r *bufio.Reader
+
+	serial    uint32
+	hasSerial bool
+	// lacing values and data of the current page not read yet
+	segments []byte
+	data     []byte
+	// packet continued on the next page
+	partial []byte
+
+	timestamp time.Duration
+}
+
+func newOggOpusReader(f *os.File, _ Options) (Reader, error) {
+	r := &oggOpusReader{
+		f: f,
+		r: bufio.NewReader(f),
+	}
+
+	// identification and comment headers
+	head, err := r.readPacket()
+	if err != nil || len(head) < 19 || !bytes.HasPrefix(head, []byte("OpusHead")) {
+		return nil, fmt.Errorf("%w: not an Ogg/Opus file", ErrInvalidFile)
+	}
+	tags, err := r.readPacket()
+	if err != nil || !bytes.HasPrefix(tags, []byte("OpusTags")) {
+		return nil, fmt.Errorf("%w: missing Opus comment header", ErrInvalidFile)
+	}
+	return r, nil
+}
+
+func (r *oggOpusReader) Codec() webrtc.RTPCodecCapability {
+	// Opus is always declared with two channels in RTP (RFC 7587)
+	return webrtc.RTPCodecCapability{
+		MimeType:    mime.MimeTypeOpus.String(),
+		ClockRate:   48000,
+		Channels:    2,
+		SDPFmtpLine: "minptime=10;useinbandfec=1",
+	}
+}
+
+func (r *oggOpusReader) ReadFrame() (*Frame, error) {
+	for {
+		pkt, err := r.readPacket()
+		if err != nil {
+			return nil, err
+		}
+		if len(pkt) == 0 {
+			continue
+		}
+
+		frame := &Frame{Data: pkt, Timestamp: r.timestamp}
+		r.timestamp += opusPacketDuration(pkt)
+		return frame, nil
+	}
+}
+
+func (r *oggOpusReader) Close() error {
+	return r.f.Close()
+}
+
+func (r *oggOpusReader) readPacket() ([]byte, error) {
+	for {
+		for len(r.segments) != 0 {
+			size := int(r.segments[0])
+			r.segments = r.segments[1:]
+			r.partial = append(r.partial, r.data[:size]...)
+			r.data = r.data[size:]
+			// a lacing value of 255 continues the packet
+			if size < 255 {
+				pkt := r.partial
+				r.partial = nil
+				return pkt, nil
+			}
+		}
+		if err := r.readPage(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (r *oggOpusReader) readPage() error {
+	header := make([]byte, oggPageHeaderSize)
+	for {
+		if err := readFull(r.r, header); err != nil {
+			return err
+		}
+		if !bytes.HasPrefix(header, []byte("OggS")) {
+			return fmt.Errorf("%w: bad Ogg page", ErrInvalidFile)
+		}
+		segments := make([]byte, header[26])
+		if err := readFull(r.r, segments); err != nil {
+			return err
+		}
+		size := 0
+		for _, s := range segments {
+			size += int(s)
+		}
+		data := make([]byte, size)
+		if err := readFull(r.r, data); err != nil {
+			return err
+		}
+
+		serial := binary.LittleEndian.Uint32(header[14:18])
+		if !r.hasSerial {
+			if header[5]&oggHeaderTypeBeginningOfStream == 0 {
+				return fmt.Errorf("%w: missing beginning of stream", ErrInvalidFile)
+			}
+			r.serial = serial
+			r.hasSerial = true
+		}
+		if serial != r.serial {
+			// other logical streams are ignored
+			continue
+		}
+		if header[5]&oggHeaderTypeContinued == 0 {
+			// a page was lost, drop what was left over of it
+			r.partial = nil
+		}
+
+		r.segments = segments
+		r.data = data
+		return nil
+	}
+}
+
+// opusPacketDuration returns the duration of an Opus packet from its TOC byte (RFC 6716 3.1)
+func opusPacketDuration(pkt []byte) time.Duration {
+	if len(pkt) == 0 {
+		return 0
+	}
+
+	config := pkt[0] >> 3
+	var frameDuration time.Duration
+	switch {
+	case config < 12:
+		// SILK
+		frameDuration = []time.Duration{10, 20, 40, 60}[config%4] * time.Millisecond
+	case config < 16:
+		// hybrid
+		frameDuration = []time.Duration{10, 20}[config%2] * time.Millisecond
+	default:
+		// CELT
+		frameDuration = []time.Duration{2500, 5000, 10000, 20000}[config%4] * time.Microsecond
+	}
+
+	frames := 1
+	switch pkt[0] & 0x3 {
+	case 1, 2:
+		frames = 2
+	case 3:
+		if len(pkt) < 2 {
+			return 0
+		}
+		frames = int(pkt[1] & 0x3f)
+	}
+	return frameDuration * time.Duration(frames)
+}
@@ -0,0 +1,338 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"path/filepath"
+	"time"
+
+	"github.com/frostbyte73/core"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/pion/rtp/codecs"
+	"github.com/pion/transport/v3/packetio"
+	"github.com/pion/webrtc/v4"
+	"go.uber.org/atomic"
+
+	"github.com/livekit/mediatransportutil"
+	"github.com/livekit/protocol/logger"
+
+	"github.com/livekit/livekit-server/pkg/rtc/mediafile"
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	"github.com/livekit/livekit-server/pkg/sfu/buffer"
+	"github.com/livekit/livekit-server/pkg/sfu/mime"
+)
+
+const (
+	mediaFileMTU                  = 1200
+	mediaFileSenderReportInterval = time.Second
+	// loop duration of files with a single frame, they would be replayed without pause otherwise
+	mediaFileMinLoopDuration = 100 * time.Millisecond
+)
+
+type MediaFileSourceParams struct {
+	Tracks        []types.MediaFileTrackParams
+	BufferFactory *buffer.Factory
+	Logger        logger.Logger
+	// called when the source closes by itself, i. e. when all files have played to the end
+	OnClosed func()
+}
+
+// MediaFileSource publishes media files read by the server. Frames are packetized and fed into the buffers of
+// the published tracks at the pace of their timestamps, with sender reports, as a real-time producer would.
+// Files have key frames where they were encoded, key frame requests are not answered: subscribers start and recover
+// from loss at the next key frame of the file. Video files need a short key frame interval, about two seconds at
+// most, e. g. `-g 60` at 30 fps with ffmpeg, or subscribers wait for as long.
+type MediaFileSource struct {
+	params MediaFileSourceParams
+	tracks []*mediaFileTrack
+
+	// set by Start or by Close before it, whichever comes first owns the readers
+	started atomic.Bool
+	playing atomic.Int32
+	closed  core.Fuse
+}
+
+type mediaFileTrack struct {
+	params     types.MediaFileTrackParams
+	reader     mediafile.Reader
+	codec      webrtc.RTPCodecParameters
+	ssrc       uint32
+	buffer     *buffer.Buffer
+	rtcpReader *buffer.RTCPReader
+	packetizer rtp.Packetizer
+	logger     logger.Logger
+}
+
+func NewMediaFileSource(params MediaFileSourceParams) (*MediaFileSource, error) {
+	if len(params.Tracks) == 0 {
+		return nil, ErrMediaFileSourceNoTracks
+	}
+
+	s := &MediaFileSource{params: params}
+	for _, trackParams := range params.Tracks {
+		t, err := s.newTrack(trackParams)
+		if err != nil {
+			s.closeReaders()
+			s.closeBuffers()
+			return nil, err
+		}
+		s.tracks = append(s.tracks, t)
+	}
+	return s, nil
+}
+
+func (s *MediaFileSource) newTrack(params types.MediaFileTrackParams) (*mediaFileTrack, error) {
+	reader, err := mediafile.Open(params.Path, mediafile.Options{FrameRate: params.FrameRate})
+	if err != nil {
+		return nil, err
+	}
+	codec := webrtc.RTPCodecParameters{RTPCodecCapability: reader.Codec()}
+	payloader, err := s.newPayloader(&codec)
+	if err != nil {
+		_ = reader.Close()
+		return nil, err
+	}
+
+	ssrc := newPlainEgressSSRC()
+	for s.params.BufferFactory.GetBuffer(ssrc) != nil {
+		ssrc = newPlainEgressSSRC()
+	}
+	return &mediaFileTrack{
+		params:     params,
+		reader:     reader,
+		codec:      codec,
+		ssrc:       ssrc,
+		buffer:     s.params.BufferFactory.GetOrNew(packetio.RTPBufferPacket, ssrc).(*buffer.Buffer),
+		rtcpReader: s.params.BufferFactory.GetOrNew(packetio.RTCPBufferPacket, ssrc).(*buffer.RTCPReader),
+		packetizer: rtp.NewPacketizer(mediaFileMTU, uint8(codec.PayloadType), ssrc, payloader, rtp.NewRandomSequencer(), codec.ClockRate),
+		logger:     s.params.Logger.WithValues("file", filepath.Base(params.Path), "ssrc", ssrc),
+	}, nil
+}
+
+// newPayloader returns the payloader of a codec and sets its payload type, the one it is registered with
+func (s *MediaFileSource) newPayloader(codec *webrtc.RTPCodecParameters) (rtp.Payloader, error) {
+	switch mime.NormalizeMimeType(codec.MimeType) {
+	case mime.MimeTypeOpus:
+		codec.PayloadType = 111
+		return &codecs.OpusPayloader{}, nil
+	case mime.MimeTypeVP8:
+		codec.PayloadType = 96
+		return &codecs.VP8Payloader{EnablePictureID: true}, nil
+	case mime.MimeTypeVP9:
+		codec.PayloadType = 98
+		return &codecs.VP9Payloader{}, nil
+	case mime.MimeTypeH264:
+		codec.PayloadType = 125
+		return &codecs.H264Payloader{}, nil
+	case mime.MimeTypeAV1:
+		codec.PayloadType = 35
+		return &codecs.AV1Payloader{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", mediafile.ErrUnsupportedCodec, codec.MimeType)
+	}
+}
+
+// PlainTracks returns the streams of the files, to be published as tracks
+func (s *MediaFileSource) PlainTracks() []types.PlainTrackParams {
+	tracks := make([]types.PlainTrackParams, 0, len(s.tracks))
+	for _, t := range s.tracks {
+		tracks = append(tracks, types.PlainTrackParams{
+			Request: t.params.Request,
+			SSRC:    t.ssrc,
+			Codec:   t.codec,
+		})
+	}
+	return tracks
+}
+
+func (s *MediaFileSource) Start() {
+	if s.started.Swap(true) {
+		return
+	}
+	s.playing.Store(int32(len(s.tracks)))
+	for _, t := range s.tracks {
+		go s.playWorker(t)
+	}
+}
+
+func (s *MediaFileSource) Close() {
+	if s.closed.IsBroken() {
+		return
+	}
+	s.closed.Break()
+	if !s.started.Swap(true) {
+		s.closeReaders()
+	}
+	s.closeBuffers()
+}
+
+// WriteRTCP takes feedback about the published tracks, there is nothing to adapt in files.
+// Key frame requests are left to the key frame interval of the files, sending an earlier key frame again
+// would break the decoding of subscribers which already have the frames that follow it.
+func (s *MediaFileSource) WriteRTCP(pkts []rtcp.Packet) error {
+	for _, pkt := range pkts {
+		switch pkt.(type) {
+		case *rtcp.PictureLossIndication, *rtcp.FullIntraRequest:
+			s.params.Logger.Debugw("key frame requested from media file", "ssrcs", pkt.DestinationSSRC())
+		}
+	}
+	return nil
+}
+
+func (s *MediaFileSource) closeReaders() {
+	for _, t := range s.tracks {
+		_ = t.reader.Close()
+	}
+}
+
+func (s *MediaFileSource) closeBuffers() {
+	for _, t := range s.tracks {
+		_ = t.buffer.Close()
+		_ = t.rtcpReader.Close()
+	}
+}
+
+// playWorker sends the frames of a file when they are due, the reader is closed when it is done
+func (s *MediaFileSource) playWorker(t *mediaFileTrack) {
+	defer s.onTrackEnded()
+	defer func() { _ = t.reader.Close() }()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	var (
+		start   = time.Now()
+		isVideo = !mime.IsMimeTypeStringAudio(t.codec.MimeType)
+		rtpBase = rand.Uint32()
+		// time of the current loop since start, and of the current frame in it
+		loopOffset time.Duration
+		loopStart  time.Duration
+		last       time.Duration
+		lastDelta  time.Duration
+		firstFrame = true
+
+		lastSenderReport time.Time
+		packets          uint32
+		octets           uint32
+	)
+	rtpTime := func(at time.Duration) uint32 {
+		return rtpBase + mediaFileRTPTime(at, t.codec.ClockRate)
+	}
+
+	for {
+		frame, err := t.reader.ReadFrame()
+		if errors.Is(err, io.EOF) {
+			if !t.params.Loop {
+				t.logger.Infow("media file ended")
+				return
+			}
+			// the next loop starts one frame after the last one
+			loopDuration := last + lastDelta
+			if loopDuration < mediaFileMinLoopDuration {
+				loopDuration = mediaFileMinLoopDuration
+			}
+			loopOffset += loopDuration
+			last, firstFrame = 0, true
+
+			reader, err := mediafile.Open(t.params.Path, mediafile.Options{FrameRate: t.params.FrameRate})
+			if err != nil {
+				t.logger.Warnw("could not reopen media file", err)
+				return
+			}
+			_ = t.reader.Close()
+			t.reader = reader
+			continue
+		}
+		if err != nil {
+			if !s.closed.IsBroken() {
+				t.logger.Warnw("could not read media file", err)
+			}
+			return
+		}
+
+		// timestamps are relative to the first frame of the file
+		if firstFrame {
+			loopStart, firstFrame = frame.Timestamp, false
+		}
+		ts := frame.Timestamp - loopStart
+		if ts > last {
+			lastDelta = ts - last
+			last = ts
+		}
+		at := loopOffset + ts
+
+		if wait := time.Until(start.Add(at)); wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-s.closed.Watch():
+				return
+			case <-timer.C:
+			}
+		} else if s.closed.IsBroken() {
+			return
+		}
+
+		for _, pkt := range t.packetizer.Packetize(frame.Data, 0) {
+			pkt.Timestamp = rtpTime(at)
+			// the end of a frame for video, the start of a talk spurt for audio
+			pkt.Marker = pkt.Marker && isVideo
+			b, err := pkt.Marshal()
+			if err != nil {
+				continue
+			}
+			if _, err = t.buffer.Write(b); err != nil {
+				return
+			}
+			packets++
+			octets += uint32(len(pkt.Payload))
+		}
+
+		if now := time.Now(); now.Sub(lastSenderReport) >= mediaFileSenderReportInterval {
+			lastSenderReport = now
+			b, err := rtcp.Marshal([]rtcp.Packet{&rtcp.SenderReport{
+				SSRC:        t.ssrc,
+				NTPTime:     uint64(mediatransportutil.ToNtpTime(now)),
+				RTPTime:     rtpTime(now.Sub(start)),
+				PacketCount: packets,
+				OctetCount:  octets,
+			}})
+			if err == nil {
+				_, _ = t.rtcpReader.Write(b)
+			}
+		}
+	}
+}
+
+// mediaFileRTPTime converts a time since start to RTP clock units, wrapping around like RTP timestamps.
+// Whole seconds are converted separately so that the product does not overflow on long plays.
+func mediaFileRTPTime(at time.Duration, clockRate uint32) uint32 {
+	seconds, remainder := uint64(at/time.Second), uint64(at%time.Second)
+	return uint32(seconds*uint64(clockRate) + remainder*uint64(clockRate)/uint64(time.Second))
+}
+
+func (s *MediaFileSource) onTrackEnded() {
+	if s.playing.Dec() != 0 || s.closed.IsBroken() {
+		return
+	}
+	s.Close()
+	if onClosed := s.params.OnClosed; onClosed != nil {
+		onClosed()
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtc
+
+import (
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	"github.com/livekit/livekit-server/pkg/sfu/buffer"
+)
+
+// writeOggOpusFile writes an Ogg/Opus file of 20 ms packets, one per page, the payload is the packet index
+func writeOggOpusFile(t *testing.T, packets int) string {
+	page := func(headerType byte, data []byte) []byte {
+		header := make([]byte, 27)
+		copy(header, "OggS")
+		header[5] = headerType
+		binary.LittleEndian.PutUint32(header[14:], 1)
+		header[26] = 1
+		return append(append(header, byte(len(data))), data...)
+	}
+
+	file := page(0x02, append([]byte("OpusHead"), 1, 2, 0, 0, 0x80, 0xbb, 0, 0, 0, 0, 0))
+	file = append(file, page(0, []byte("OpusTags"))...)
+	for i := range packets {
+		file = append(file, page(0, []byte{19 << 3, byte(i)})...)
+	}
+
+	path := filepath.Join(t.TempDir(), "audio.ogg")
+	require.NoError(t, os.WriteFile(path, file, 0o600))
+	return path
+}
+
+func TestMediaFileSource(t *testing.T) {
+	t.Run("forwarded to down tracks", func(t *testing.T) {
+		p := newParticipantForTestWithOpts("files", &participantOpts{
+			permissions: &livekit.ParticipantPermission{CanPublish: true},
+		})
+		defer p.Close(false, types.ParticipantCloseReasonClientRequestLeave, false)
+
+		tracks, err := p.PublishMediaFiles(types.MediaFileParams{
+			Tracks: []types.MediaFileTrackParams{
+				{
+					Request: &livekit.AddTrackRequest{Name: "music", Source: livekit.TrackSource_MICROPHONE},
+					Path:    writeOggOpusFile(t, 10),
+					Loop:    true,
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, tracks, 1)
+		require.Equal(t, "music", tracks[0].Name)
+		track := p.GetPublishedTrack(livekit.TrackID(tracks[0].Sid))
+		require.NotNil(t, track)
+		require.Eventually(t, func() bool { return len(track.Receivers()) != 0 }, time.Second, 10*time.Millisecond)
+
+		_, err = p.PublishMediaFiles(types.MediaFileParams{
+			Tracks: []types.MediaFileTrackParams{{Request: &livekit.AddTrackRequest{Name: "other"}, Path: writeOggOpusFile(t, 1)}},
+		})
+		require.ErrorIs(t, err, ErrMediaFileSourceExists)
+
+		destination, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		require.NoError(t, err)
+		defer destination.Close()
+		egressConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		require.NoError(t, err)
+		egress, err := NewPlainEgress(PlainEgressParams{
+			Track:         track,
+			Conn:          egressConn,
+			RemoteAddr:    destination.LocalAddr().(*net.UDPAddr),
+			Quality:       livekit.VideoQuality_HIGH,
+			BufferFactory: buffer.NewFactoryOfBufferFactory(500, 200).CreateBufferFactory(),
+			MaxTrack:      200,
+			Logger:        logger.GetLogger(),
+		})
+		require.NoError(t, err)
+		defer egress.Close()
+		require.NoError(t, egress.Start())
+
+		// paced at 20 ms, looping over the packets of the file
+		var pkts []*rtp.Packet
+		for len(pkts) < 15 {
+			received := make([]byte, 1500)
+			require.NoError(t, destination.SetReadDeadline(time.Now().Add(2*time.Second)))
+			n, _, err := destination.ReadFromUDP(received)
+			require.NoError(t, err)
+			var pkt rtp.Packet
+			require.NoError(t, pkt.Unmarshal(received[:n]))
+			pkts = append(pkts, &pkt)
+		}
+		for i := 1; i < len(pkts); i++ {
+			require.Equal(t, pkts[i-1].SequenceNumber+1, pkts[i].SequenceNumber)
+			require.Equal(t, pkts[i-1].Timestamp+960, pkts[i].Timestamp)
+			require.Equal(t, (pkts[i-1].Payload[1]+1)%10, pkts[i].Payload[1])
+		}
+	})
+
+	t.Run("closed at the end", func(t *testing.T) {
+		p := newParticipantForTestWithOpts("files", &participantOpts{
+			permissions: &livekit.ParticipantPermission{CanPublish: true},
+		})
+		defer p.Close(false, types.ParticipantCloseReasonClientRequestLeave, false)
+
+		start := time.Now()
+		_, err := p.PublishMediaFiles(types.MediaFileParams{
+			Tracks: []types.MediaFileTrackParams{
+				{
+					Request: &livekit.AddTrackRequest{Name: "announcement", Source: livekit.TrackSource_MICROPHONE},
+					Path:    writeOggOpusFile(t, 10),
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Eventually(t, p.IsClosed, 2*time.Second, 10*time.Millisecond)
+		require.GreaterOrEqual(t, time.Since(start), 180*time.Millisecond)
+	})
+
+	t.Run("unsupported file", func(t *testing.T) {
+		p := newParticipantForTestWithOpts("files", &participantOpts{
+			permissions: &livekit.ParticipantPermission{CanPublish: true},
+		})
+		defer p.Close(false, types.ParticipantCloseReasonClientRequestLeave, false)
+
+		path := filepath.Join(t.TempDir(), "video.mp4")
+		require.NoError(t, os.WriteFile(path, []byte{0}, 0o600))
+		_, err := p.PublishMediaFiles(types.MediaFileParams{
+			Tracks: []types.MediaFileTrackParams{{Request: &livekit.AddTrackRequest{Name: "video"}, Path: path}},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestMediaFileRTPTime(t *testing.T) {
+	require.Equal(t, uint32(72000), mediaFileRTPTime(1500*time.Millisecond, 48000))
+	require.Equal(t, uint32(90), mediaFileRTPTime(time.Millisecond, 90000))
+
+	// long plays wrap around like RTP timestamps
+	at := 100 * time.Hour
+	units := uint64(at/time.Second) * 90000
+	require.Equal(t, uint32(units), mediaFileRTPTime(at, 90000))
+	require.Equal(t, uint32(units+45000), mediaFileRTPTime(at+500*time.Millisecond, 90000))
+}
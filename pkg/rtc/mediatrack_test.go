@@ -20,6 +20,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
 )
 
 func TestTrackInfo(t *testing.T) {
@@ -172,3 +173,20 @@ func TestGetQualityForDimension(t *testing.T) {
 	})
 
 }
+
+func TestRevokeSubscribersNotInRoom(t *testing.T) {
+	mt := NewMediaTrack(MediaTrackParams{Logger: logger.GetLogger()}, &livekit.TrackInfo{Sid: "TR_abc", Type: livekit.TrackType_VIDEO})
+
+	var revoked []string
+	mt.AddOnRevoke("viewer", "viewer", func() { revoked = append(revoked, "viewer") })
+	mt.AddOnRevoke("other", "other", func() { revoked = append(revoked, "other") })
+	mt.AddOnRevoke("removed", "removed", func() { revoked = append(revoked, "removed") })
+	mt.RemoveOnRevoke("removed")
+
+	mt.RevokeDisallowedSubscribers([]livekit.ParticipantIdentity{"viewer"})
+	require.Equal(t, []string{"other"}, revoked)
+
+	// revoked once
+	mt.RevokeDisallowedSubscribers([]livekit.ParticipantIdentity{})
+	require.Equal(t, []string{"other", "viewer"}, revoked)
+}
@@ -145,10 +145,17 @@ type MediaTrackReceiver struct {
 	onMediaLossFeedback func(dt *sfu.DownTrack, report *rtcp.ReceiverReport)
 	onClose             []func(isExpectedToResume bool)
 	onCodecRegression   func(old, new webrtc.RTPCodecParameters)
+	onRevoke            map[string]revocableSubscriber
 
 	*MediaTrackSubscriptions
 }
 
+// revocableSubscriber is a subscriber which is not a participant of the room, e. g. an RTSP viewer
+type revocableSubscriber struct {
+	identity livekit.ParticipantIdentity
+	revoke   func()
+}
+
 func NewMediaTrackReceiver(params MediaTrackReceiverParams, ti *livekit.TrackInfo) *MediaTrackReceiver {
 	t := &MediaTrackReceiver{
 		params: params,
@@ -538,6 +545,23 @@ func (t *MediaTrackReceiver) AddOnClose(f func(isExpectedToResume bool)) {
 	t.lock.Unlock()
 }
 
+// AddOnRevoke registers a subscriber which is not a participant of the room under key, f is called
+// when the subscription permissions of the publisher no longer allow subscriberIdentity
+func (t *MediaTrackReceiver) AddOnRevoke(key string, subscriberIdentity livekit.ParticipantIdentity, f func()) {
+	t.lock.Lock()
+	if t.onRevoke == nil {
+		t.onRevoke = make(map[string]revocableSubscriber)
+	}
+	t.onRevoke[key] = revocableSubscriber{identity: subscriberIdentity, revoke: f}
+	t.lock.Unlock()
+}
+
+func (t *MediaTrackReceiver) RemoveOnRevoke(key string) {
+	t.lock.Lock()
+	delete(t.onRevoke, key)
+	t.lock.Unlock()
+}
+
 // AddSubscriber subscribes sub to current mediaTrack
 func (t *MediaTrackReceiver) AddSubscriber(sub types.LocalParticipant) (types.SubscribedTrack, error) {
 	t.lock.RLock()
@@ -646,6 +670,20 @@ func (t *MediaTrackReceiver) RevokeDisallowedSubscribers(allowedSubscriberIdenti
 		}
 	}
 
+	var revoked []func()
+	t.lock.Lock()
+	for key, sub := range t.onRevoke {
+		if !slices.Contains(allowedSubscriberIdentities, sub.identity) {
+			t.params.Logger.Infow("revoking subscription", "subscriber", sub.identity, "key", key)
+			revoked = append(revoked, sub.revoke)
+			delete(t.onRevoke, key)
+		}
+	}
+	t.lock.Unlock()
+	for _, f := range revoked {
+		f()
+	}
+
 	return revokedSubscriberIdentities
 }
 
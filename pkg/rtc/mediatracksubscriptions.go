@@ -207,9 +207,10 @@ func (t *MediaTrackSubscriptions) AddSubscriber(sub types.LocalParticipant, wr *
 		subTrack.SetPublisherMuted(t.params.MediaTrack.IsMuted())
 	})
 
-	downTrack.OnStatsUpdate(func(_ *sfu.DownTrack, stat *livekit.AnalyticsStat) {
-		key := telemetry.StatsKeyForTrack(livekit.StreamType_DOWNSTREAM, subscriberID, trackID, t.params.MediaTrack.Source(), t.params.MediaTrack.Kind())
-		t.params.Telemetry.TrackStats(key, stat)
+	statsKey := telemetry.StatsKeyForTrack(livekit.StreamType_DOWNSTREAM, subscriberID, trackID, t.params.MediaTrack.Source(), t.params.MediaTrack.Kind())
+	downTrack.OnStatsUpdate(func(dt *sfu.DownTrack, stat *livekit.AnalyticsStat) {
+		t.params.Telemetry.TrackStats(statsKey, stat)
+		t.params.Telemetry.TrackQualityEvents(statsKey, dt.GetQualityEvents())
 	})
 
 	downTrack.OnMaxLayerChanged(func(dt *sfu.DownTrack, layer int32) {
@@ -331,6 +332,8 @@ func (t *MediaTrackSubscriptions) AddSubscriber(sub types.LocalParticipant, wr *
 	downTrack.SetTransceiver(transceiver)
 
 	downTrack.OnCloseHandler(func(isExpectedToResume bool) {
+		// final report, stats are not updated while nothing is forwarded
+		t.params.Telemetry.TrackQualityEvents(statsKey, downTrack.GetQualityEvents())
 		t.downTrackClosed(sub, subTrack, isExpectedToResume)
 	})
 
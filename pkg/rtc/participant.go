@@ -48,6 +48,7 @@ import (
 	"github.com/livekit/livekit-server/pkg/rtc/types"
 	"github.com/livekit/livekit-server/pkg/sfu"
 	"github.com/livekit/livekit-server/pkg/sfu/buffer"
+	"github.com/livekit/livekit-server/pkg/sfu/capture"
 	"github.com/livekit/livekit-server/pkg/sfu/connectionquality"
 	"github.com/livekit/livekit-server/pkg/sfu/mime"
 	"github.com/livekit/livekit-server/pkg/sfu/pacer"
@@ -110,16 +111,18 @@ func (p participantUpdateInfo) String() string {
 // ---------------------------------------------------------------
 
 type ParticipantParams struct {
-	Identity                livekit.ParticipantIdentity
-	Name                    livekit.ParticipantName
-	SID                     livekit.ParticipantID
-	Config                  *WebRTCConfig
-	Sink                    routing.MessageSink
-	AudioConfig             sfu.AudioConfig
-	VideoConfig             config.VideoConfig
-	LimitConfig             config.LimitConfig
-	ProtocolVersion         types.ProtocolVersion
-	SessionStartTime        time.Time
+	Identity         livekit.ParticipantIdentity
+	Name             livekit.ParticipantName
+	SID              livekit.ParticipantID
+	Config           *WebRTCConfig
+	Sink             routing.MessageSink
+	AudioConfig      sfu.AudioConfig
+	VideoConfig      config.VideoConfig
+	LimitConfig      config.LimitConfig
+	ProtocolVersion  types.ProtocolVersion
+	SessionStartTime time.Time
+	// carries the span of the session start, connection and negotiation spans are parented to it
+	TraceContext            context.Context
 	Telemetry               telemetry.TelemetryService
 	Trailer                 []byte
 	PLIThrottleConfig       sfu.PLIThrottleConfig
@@ -160,11 +163,16 @@ type ParticipantParams struct {
 	DisableSenderReportPassThrough bool
 	MetricConfig                   metric.MetricConfig
 	UseOneShotSignallingMode       bool
+	UseSinglePeerConnection        bool
 	EnableMetrics                  bool
 	DataChannelMaxBufferedAmount   uint64
 	DatachannelSlowThreshold       int
 	FireOnTrackBySdp               bool
 	DisableCodecRegression         bool
+	SignalRecorder                 *SignalRecorder
+	// tracks published before the room migrated from another node, a track published again with the same
+	// name, type and source keeps its SID
+	MigratedTracks []*livekit.TrackInfo
 }
 
 type ParticipantImpl struct {
@@ -187,6 +195,7 @@ type ParticipantImpl struct {
 	isPublisher atomic.Bool
 
 	sessionStartRecorded atomic.Bool
+	tracer               *participantTracer
 	lastActiveAt         atomic.Pointer[time.Time]
 	// when first connected
 	connectedAt time.Time
@@ -196,6 +205,11 @@ type ParticipantImpl struct {
 
 	pubRTCPQueue *sutils.TypedOpsQueue[postRtcpOp]
 
+	// set when publishing from a server-side producer, see PublishPlainTransport
+	plainTransport atomic.Pointer[PlainTransport]
+	// set when publishing media files read by the server, see PublishMediaFiles
+	mediaFileSource atomic.Pointer[MediaFileSource]
+
 	// hold reference for MediaTrack
 	twcc *twcc.Responder
 
@@ -204,6 +218,7 @@ type ParticipantImpl struct {
 	pendingTracks           map[string]*pendingTrackInfo
 	pendingPublishingTracks map[livekit.TrackID]*pendingTrackInfo
 	pendingRemoteTracks     []*pendingRemoteTrack
+	migratedTracks          []*livekit.TrackInfo
 
 	// supported codecs
 	enabledPublishCodecs   []*livekit.Codec
@@ -286,6 +301,7 @@ func NewParticipant(params ParticipantParams) (*ParticipantImpl, error) {
 		}),
 		pendingTracks:           make(map[string]*pendingTrackInfo),
 		pendingPublishingTracks: make(map[livekit.TrackID]*pendingTrackInfo),
+		migratedTracks:          slices.Clone(params.MigratedTracks),
 		connectedAt:             time.Now().Truncate(time.Millisecond),
 		rttUpdatedAt:            time.Now(),
 		cachedDownTracks:        make(map[livekit.TrackID]*downTrackState),
@@ -301,6 +317,7 @@ func NewParticipant(params ParticipantParams) (*ParticipantImpl, error) {
 	if !params.DisableSupervisor {
 		p.supervisor = supervisor.NewParticipantSupervisor(supervisor.ParticipantSupervisorParams{Logger: params.Logger})
 	}
+	p.tracer = newParticipantTracer(params.TraceContext, params)
 	p.closeReason.Store(types.ParticipantCloseReasonNone)
 	p.version.Store(params.InitialVersion)
 	p.timedVersion.Update(params.VersionGenerator.Next())
@@ -812,6 +829,10 @@ func (p *ParticipantImpl) OnClaimsChanged(callback func(types.LocalParticipant))
 	p.lock.Unlock()
 }
 
+func (p *ParticipantImpl) RecordSignalRequest(req *livekit.SignalRequest) {
+	p.params.SignalRecorder.RecordRequest(req)
+}
+
 func (p *ParticipantImpl) HandleSignalSourceClose() {
 	p.TransportManager.SetSignalSourceValid(false)
 
@@ -827,8 +848,33 @@ func (p *ParticipantImpl) synthesizeAddTrackRequests(offer webrtc.SessionDescrip
 	}
 
 	for _, m := range parsed.MediaDescriptions {
-		if !strings.EqualFold(m.MediaName.Media, "audio") {
-			// ONE-SHOT-SIGNALLING-MODE-TODO: support video
+		// nothing published on media sections only receiving
+		if _, ok := m.Attribute(sdp.AttrKeyRecvOnly); ok {
+			continue
+		}
+		if _, ok := m.Attribute(sdp.AttrKeyInactive); ok {
+			continue
+		}
+
+		var req *livekit.AddTrackRequest
+		switch {
+		case strings.EqualFold(m.MediaName.Media, "audio"):
+			req = &livekit.AddTrackRequest{
+				Name:       "synthesized-microphone",
+				Source:     livekit.TrackSource_MICROPHONE,
+				Type:       livekit.TrackType_AUDIO,
+				DisableDtx: true,
+				Stereo:     false,
+				Stream:     "camera",
+			}
+		case strings.EqualFold(m.MediaName.Media, "video"):
+			req = &livekit.AddTrackRequest{
+				Name:   "synthesized-camera",
+				Source: livekit.TrackSource_CAMERA,
+				Type:   livekit.TrackType_VIDEO,
+				Stream: "camera",
+			}
+		default:
 			continue
 		}
 
@@ -855,15 +901,7 @@ func (p *ParticipantImpl) synthesizeAddTrackRequests(offer webrtc.SessionDescrip
 			trackID = guid.New(utils.TrackPrefix)
 		}
 
-		req := &livekit.AddTrackRequest{
-			Cid:        trackID,
-			Name:       "synthesized-microphone",
-			Source:     livekit.TrackSource_MICROPHONE,
-			Type:       livekit.TrackType_AUDIO,
-			DisableDtx: true,
-			Stereo:     false,
-			Stream:     "camera",
-		}
+		req.Cid = trackID
 		p.AddTrack(req)
 	}
 	return nil
@@ -884,8 +922,12 @@ func (p *ParticipantImpl) HandleOffer(offer webrtc.SessionDescription) error {
 		shouldPend = true
 	}
 
+	p.tracer.startNegotiation(livekit.SignalTarget_PUBLISHER)
 	offer = p.setCodecPreferencesForPublisher(offer)
 	err := p.TransportManager.HandleOffer(offer, shouldPend)
+	if err != nil {
+		p.tracer.endNegotiation(livekit.SignalTarget_PUBLISHER, err)
+	}
 	if p.params.UseOneShotSignallingMode {
 		p.updateState(livekit.ParticipantInfo_ACTIVE)
 	}
@@ -899,11 +941,13 @@ func (p *ParticipantImpl) onPublisherAnswer(answer webrtc.SessionDescription) er
 
 	answer = p.configurePublisherAnswer(answer)
 	p.pubLogger.Debugw("sending answer", "transport", livekit.SignalTarget_PUBLISHER, "answer", answer)
-	return p.writeMessage(&livekit.SignalResponse{
+	err := p.writeMessage(&livekit.SignalResponse{
 		Message: &livekit.SignalResponse_Answer{
 			Answer: ToProtoSessionDescription(answer),
 		},
 	})
+	p.tracer.endNegotiation(livekit.SignalTarget_PUBLISHER, err)
+	return err
 }
 
 func (p *ParticipantImpl) GetAnswer() (webrtc.SessionDescription, error) {
@@ -935,6 +979,7 @@ func (p *ParticipantImpl) HandleAnswer(answer webrtc.SessionDescription) {
 	p.TransportManager.UpdateSignalingRTT(uint32(signalConnCost))
 
 	p.TransportManager.HandleAnswer(answer)
+	p.tracer.endNegotiation(livekit.SignalTarget_SUBSCRIBER, nil)
 }
 
 func (p *ParticipantImpl) handleMigrateTracks() {
@@ -1042,6 +1087,7 @@ func (p *ParticipantImpl) Close(sendLeave bool, reason types.ParticipantCloseRea
 	p.closeReason.Store(reason)
 	p.clearDisconnectTimer()
 	p.clearMigrationTimer()
+	p.tracer.close(fmt.Errorf("participant closed: %s", reason))
 
 	if sendLeave {
 		p.sendLeaveRequest(
@@ -1080,6 +1126,13 @@ func (p *ParticipantImpl) Close(sendLeave bool, reason types.ParticipantCloseRea
 	go func() {
 		p.SubscriptionManager.Close(isExpectedToResume)
 		p.TransportManager.Close()
+		if pt := p.plainTransport.Load(); pt != nil {
+			pt.Close()
+		}
+		if fs := p.mediaFileSource.Load(); fs != nil {
+			fs.Close()
+		}
+		p.params.SignalRecorder.Close()
 
 		p.metricsCollector.Stop()
 		p.metricsReporter.Stop()
@@ -1151,7 +1204,7 @@ func (p *ParticipantImpl) setupMigrationTimerLocked() {
 }
 
 func (p *ParticipantImpl) MaybeStartMigration(force bool, onStart func()) bool {
-	if p.params.UseOneShotSignallingMode {
+	if p.params.UseOneShotSignallingMode || p.params.UseSinglePeerConnection {
 		return false
 	}
 
@@ -1328,7 +1381,12 @@ func (p *ParticipantImpl) Verify() bool {
 	state := p.State()
 	isActive := state != livekit.ParticipantInfo_JOINING && state != livekit.ParticipantInfo_JOINED
 	if p.params.UseOneShotSignallingMode {
-		isActive = isActive && p.TransportManager.HasPublisherEverConnected()
+		// media files play as soon as they are published, there is no transport to wait for
+		if pt := p.plainTransport.Load(); pt != nil {
+			isActive = isActive && pt.IsConnected()
+		} else if p.mediaFileSource.Load() == nil {
+			isActive = isActive && p.TransportManager.HasPublisherEverConnected()
+		}
 	}
 
 	return isActive
@@ -1366,15 +1424,14 @@ func (p *ParticipantImpl) onTrackSubscribed(subTrack types.SubscribedTrack) {
 				dt.SeedState(sfu.DownTrackState{ForwarderState: p.getAndDeleteForwarderState(subTrack.ID())})
 				dt.SetConnected()
 			}
-			// ONE-SHOT-SIGNALLING-MODE-TODO: video support should add to publisher PC for congestion control
 		} else {
 			if p.TransportManager.HasSubscriberEverConnected() {
 				dt := subTrack.DownTrack()
 				dt.SeedState(sfu.DownTrackState{ForwarderState: p.getAndDeleteForwarderState(subTrack.ID())})
 				dt.SetConnected()
 			}
-			p.TransportManager.AddSubscribedTrack(subTrack)
 		}
+		p.TransportManager.AddSubscribedTrack(subTrack)
 	})
 }
 
@@ -1481,6 +1538,25 @@ func (h SubscriberTransportHandler) OnDataSendError(err error) {
 
 // ----------------------------------------------------------
 
+// SinglePeerConnectionTransportHandler handles the publisher peer connection when it also carries subscribed tracks
+type SinglePeerConnectionTransportHandler struct {
+	PublisherTransportHandler
+}
+
+func (h SinglePeerConnectionTransportHandler) OnOffer(sd webrtc.SessionDescription) error {
+	return h.p.onSubscriberOffer(sd)
+}
+
+func (h SinglePeerConnectionTransportHandler) OnStreamStateChange(update *streamallocator.StreamStateUpdate) error {
+	return h.p.onStreamStateChange(update)
+}
+
+func (h SinglePeerConnectionTransportHandler) OnDataSendError(err error) {
+	h.p.onDataSendError(err)
+}
+
+// ----------------------------------------------------------
+
 type PrimaryTransportHandler struct {
 	transport.Handler
 	p *ParticipantImpl
@@ -1505,13 +1581,21 @@ func (p *ParticipantImpl) setupTransportManager() error {
 	ath := AnyTransportHandler{p: p}
 	var pth transport.Handler = PublisherTransportHandler{ath}
 	var sth transport.Handler = SubscriberTransportHandler{ath}
+	if p.params.UseSinglePeerConnection {
+		pth = SinglePeerConnectionTransportHandler{PublisherTransportHandler{ath}}
+	}
 
-	subscriberAsPrimary := p.ProtocolVersion().SubscriberAsPrimary() && p.CanSubscribe() && !p.params.UseOneShotSignallingMode
+	subscriberAsPrimary := p.ProtocolVersion().SubscriberAsPrimary() && p.CanSubscribe() &&
+		!p.params.UseOneShotSignallingMode && !p.params.UseSinglePeerConnection
 	if subscriberAsPrimary {
 		sth = PrimaryTransportHandler{sth, p}
 	} else {
 		pth = PrimaryTransportHandler{pth, p}
 	}
+	if p.params.UseSinglePeerConnection {
+		// events of the subscriber side also come from the publisher peer connection
+		sth = pth
+	}
 
 	params := TransportManagerParams{
 		Identity: p.params.Identity,
@@ -1540,7 +1624,9 @@ func (p *ParticipantImpl) setupTransportManager() error {
 		SubscriberHandler:            sth,
 		DataChannelStats:             p.dataChannelStats,
 		UseOneShotSignallingMode:     p.params.UseOneShotSignallingMode,
+		UseSinglePeerConnection:      p.params.UseSinglePeerConnection,
 		FireOnTrackBySdp:             p.params.FireOnTrackBySdp,
+		SignalRecorder:               p.params.SignalRecorder,
 	}
 	if p.params.SyncStreams && p.params.PlayoutDelay.GetEnabled() && p.params.ClientInfo.isFirefox() {
 		// we will disable playout delay for Firefox if the user is expecting
@@ -1716,6 +1802,7 @@ func (p *ParticipantImpl) setIsPublisher(isPublisher bool) {
 // when the server has an offer for participant
 func (p *ParticipantImpl) onSubscriberOffer(offer webrtc.SessionDescription) error {
 	p.subLogger.Debugw("sending offer", "transport", livekit.SignalTarget_SUBSCRIBER, "offer", offer)
+	p.tracer.startNegotiation(livekit.SignalTarget_SUBSCRIBER)
 	return p.writeMessage(&livekit.SignalResponse{
 		Message: &livekit.SignalResponse_Offer{
 			Offer: ToProtoSessionDescription(offer),
@@ -1771,7 +1858,7 @@ func (p *ParticipantImpl) onMediaTrack(rtcTrack *webrtc.TrackRemote, rtpReceiver
 	p.params.Logger.Debugw("onMediaTrack", "codec", codec, "payloadType", codec.PayloadType, "fromSdp", fromSdp, "parameters", rtpReceiver.GetParameters())
 
 	var track sfu.TrackRemote = sfu.NewTrackRemoteFromSdp(rtcTrack, codec)
-	publishedTrack, isNewTrack := p.mediaTrackReceived(track, rtpReceiver)
+	publishedTrack, isNewTrack := p.mediaTrackReceived(track, rtpReceiver, p.TransportManager.GetPublisherMid(rtpReceiver))
 	if publishedTrack == nil {
 		p.pubLogger.Debugw(
 			"webrtc Track published but can't find MediaTrack, add to pendingTracks",
@@ -1956,13 +2043,14 @@ func (p *ParticipantImpl) onICECandidate(c *webrtc.ICECandidate, target livekit.
 }
 
 func (p *ParticipantImpl) onPublisherInitialConnected() {
+	p.tracer.addEvent("publisher connected")
 	p.SetMigrateState(types.MigrateStateComplete)
 
 	if p.supervisor != nil {
 		p.supervisor.SetPublisherPeerConnectionConnected(true)
 	}
 
-	if p.params.UseOneShotSignallingMode {
+	if p.params.UseOneShotSignallingMode || p.params.UseSinglePeerConnection {
 		go p.subscriberRTCPWorker()
 
 		p.setDownTracksConnected()
@@ -1972,6 +2060,7 @@ func (p *ParticipantImpl) onPublisherInitialConnected() {
 }
 
 func (p *ParticipantImpl) onSubscriberInitialConnected() {
+	p.tracer.addEvent("subscriber connected")
 	go p.subscriberRTCPWorker()
 
 	p.setDownTracksConnected()
@@ -1989,6 +2078,7 @@ func (p *ParticipantImpl) onPrimaryTransportFullyEstablished() {
 	if !p.sessionStartRecorded.Swap(true) {
 		prometheus.RecordSessionStartTime(int(p.ProtocolVersion()), time.Since(p.params.SessionStartTime))
 	}
+	p.tracer.endConnect(nil)
 	p.updateState(livekit.ParticipantInfo_ACTIVE)
 }
 
@@ -2062,6 +2152,9 @@ func (p *ParticipantImpl) subscriberRTCPWorker() {
 			if sr == nil || chunks == nil {
 				continue
 			}
+			if c := subTrack.DownTrack().GetCapture(); c != nil {
+				c.WriteRTCPPackets(capture.DirectionOut, sr, &rtcp.SourceDescription{Chunks: chunks})
+			}
 
 			pkts = append(pkts, sr)
 			sd = append(sd, chunks...)
@@ -2379,11 +2472,10 @@ func (p *ParticipantImpl) setTrackMuted(trackID livekit.TrackID, muted bool) *li
 	return trackInfo
 }
 
-func (p *ParticipantImpl) mediaTrackReceived(track sfu.TrackRemote, rtpReceiver *webrtc.RTPReceiver) (*MediaTrack, bool) {
+func (p *ParticipantImpl) mediaTrackReceived(track sfu.TrackRemote, rtpReceiver *webrtc.RTPReceiver, mid string) (*MediaTrack, bool) {
 	p.pendingTracksLock.Lock()
 	newTrack := false
 
-	mid := p.TransportManager.GetPublisherMid(rtpReceiver)
 	p.pubLogger.Debugw(
 		"media track received",
 		"kind", track.Kind().String(),
@@ -2479,6 +2571,7 @@ func (p *ParticipantImpl) mediaTrackReceived(track sfu.TrackRemote, rtpReceiver
 			}
 
 			prometheus.RecordPublishTime(mt.Source(), mt.Kind(), pubTime, p.GetClientInfo().GetSdk(), p.Kind())
+			p.tracer.recordPublish(mt.ToProto(), time.Now().Add(-pubTime))
 			p.handleTrackPublished(mt)
 		}()
 	}
@@ -2712,6 +2805,17 @@ func (p *ParticipantImpl) setTrackID(cid string, info *livekit.TrackInfo) {
 		trackID = pti.trackInfos[0].Sid
 	}
 
+	// a track published again after the room migrated keeps its SID
+	if trackID == "" {
+		for i, ti := range p.migratedTracks {
+			if ti.Name == info.Name && ti.Type == info.Type && ti.Source == info.Source {
+				trackID = ti.Sid
+				p.migratedTracks = slices.Delete(p.migratedTracks, i, i+1)
+				break
+			}
+		}
+	}
+
 	// otherwise generate
 	if trackID == "" {
 		trackPrefix := utils.TrackPrefix
@@ -2783,6 +2887,30 @@ func (p *ParticipantImpl) DebugInfo() map[string]interface{} {
 	return info
 }
 
+// SubscriptionDebugInfo describes how subscribed tracks are forwarded to this participant,
+// along with the bandwidth allocation of the subscriber transport that drives layer selection
+func (p *ParticipantImpl) SubscriptionDebugInfo() map[string]interface{} {
+	subscribedTracks := make([]map[string]interface{}, 0)
+	for _, subTrack := range p.GetSubscribedTracks() {
+		dt := subTrack.DownTrack()
+		if dt == nil {
+			continue
+		}
+
+		trackInfo := dt.ForwardingDebugInfo()
+		trackInfo["PublisherIdentity"] = subTrack.PublisherIdentity()
+		subscribedTracks = append(subscribedTracks, trackInfo)
+	}
+
+	return map[string]interface{}{
+		"ID":               p.params.SID,
+		"Identity":         p.params.Identity,
+		"State":            p.State().String(),
+		"StreamAllocator":  p.TransportManager.SubscriberStreamAllocatorDebugInfo(),
+		"SubscribedTracks": subscribedTracks,
+	}
+}
+
 func (p *ParticipantImpl) postRtcp(pkts []rtcp.Packet) {
 	p.lock.RLock()
 	migrationTimer := p.migrationTimer
@@ -2804,7 +2932,13 @@ func (p *ParticipantImpl) postRtcp(pkts []rtcp.Packet) {
 	}
 
 	p.pubRTCPQueue.Enqueue(func(op postRtcpOp) {
-		if err := op.TransportManager.WritePublisherRTCP(op.pkts); err != nil && !IsEOF(err) {
+		write := op.TransportManager.WritePublisherRTCP
+		if pt := op.plainTransport.Load(); pt != nil {
+			write = pt.WriteRTCP
+		} else if fs := op.mediaFileSource.Load(); fs != nil {
+			write = fs.WriteRTCP
+		}
+		if err := write(op.pkts); err != nil && !IsEOF(err) {
 			op.pubLogger.Errorw("could not write RTCP to participant", err)
 		}
 	}, postRtcpOp{p, pkts})
@@ -2898,6 +3032,8 @@ func (p *ParticipantImpl) IssueFullReconnect(reason types.ParticipantCloseReason
 		scr = types.SignallingCloseReasonFullReconnectDataChannelError
 	case types.ParticipantCloseReasonNegotiateFailed:
 		scr = types.SignallingCloseReasonFullReconnectNegotiateFailed
+	case types.ParticipantCloseReasonMigrationRequested:
+		scr = types.SignallingCloseReasonMigration
 	}
 	p.CloseSignalConnection(scr)
 
@@ -2937,6 +3073,7 @@ func (p *ParticipantImpl) onSubscriptionError(trackID livekit.TrackID, fatal boo
 }
 
 func (p *ParticipantImpl) onAnyTransportNegotiationFailed() {
+	p.tracer.close(ErrNegotiationFailed)
 	if p.TransportManager.SinceLastSignal() < negotiationFailedTimeout/2 {
 		p.params.Logger.Infow("negotiation failed, starting full reconnect")
 	}
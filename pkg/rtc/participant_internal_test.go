@@ -115,6 +115,23 @@ func TestTrackPublishing(t *testing.T) {
 		require.Equal(t, uint32(768), published.Track.Height)
 	})
 
+	t.Run("keeps SIDs of tracks published before migration", func(t *testing.T) {
+		p := newParticipantForTest("test")
+		p.migratedTracks = []*livekit.TrackInfo{
+			{Sid: "TR_webcam", Name: "webcam", Type: livekit.TrackType_VIDEO, Source: livekit.TrackSource_CAMERA},
+		}
+		sink := p.params.Sink.(*routingfakes.FakeMessageSink)
+		p.AddTrack(&livekit.AddTrackRequest{Cid: "cid", Name: "webcam", Type: livekit.TrackType_VIDEO, Source: livekit.TrackSource_CAMERA})
+		p.AddTrack(&livekit.AddTrackRequest{Cid: "cid2", Name: "screen", Type: livekit.TrackType_VIDEO, Source: livekit.TrackSource_SCREEN_SHARE})
+
+		require.Equal(t, 2, sink.WriteMessageCallCount())
+		published := sink.WriteMessageArgsForCall(0).(*livekit.SignalResponse).Message.(*livekit.SignalResponse_TrackPublished).TrackPublished
+		require.Equal(t, "TR_webcam", published.Track.Sid)
+		published = sink.WriteMessageArgsForCall(1).(*livekit.SignalResponse).Message.(*livekit.SignalResponse_TrackPublished).TrackPublished
+		require.NotEqual(t, "TR_webcam", published.Track.Sid)
+		require.Empty(t, p.migratedTracks)
+	})
+
 	t.Run("should not allow adding of duplicate tracks", func(t *testing.T) {
 		p := newParticipantForTest("test")
 		sink := p.params.Sink.(*routingfakes.FakeMessageSink)
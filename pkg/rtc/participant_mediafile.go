@@ -0,0 +1,75 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtc
+
+import (
+	"strconv"
+
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	sutils "github.com/livekit/livekit-server/pkg/utils"
+)
+
+// PublishMediaFiles publishes media files read by the server as tracks of the participant, e. g. for hold music,
+// announcements or tests. Tracks go through the same receivers as a real publisher's.
+// The participant is closed when all files have played to the end, looping files play until it leaves.
+func (p *ParticipantImpl) PublishMediaFiles(params types.MediaFileParams) ([]*livekit.TrackInfo, error) {
+	if p.plainTransport.Load() != nil {
+		return nil, ErrPlainTransportExists
+	}
+	if p.mediaFileSource.Load() != nil {
+		return nil, ErrMediaFileSourceExists
+	}
+
+	fs, err := NewMediaFileSource(MediaFileSourceParams{
+		Tracks:        params.Tracks,
+		BufferFactory: p.params.Config.BufferFactory,
+		Logger:        p.params.Logger.WithComponent(sutils.ComponentTransport),
+		OnClosed:      p.onMediaFileSourceClosed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !p.mediaFileSource.CompareAndSwap(nil, fs) {
+		fs.Close()
+		return nil, ErrMediaFileSourceExists
+	}
+
+	var tracks []*livekit.TrackInfo
+	for idx, trackParams := range fs.PlainTracks() {
+		ti, err := p.addPlainTrack(trackParams, strconv.Itoa(idx))
+		if err != nil {
+			fs.Close()
+			return nil, err
+		}
+		tracks = append(tracks, ti)
+	}
+
+	p.setIsPublisher(true)
+	p.dirty.Store(true)
+
+	p.tracer.addEvent("media file source started")
+	p.SetMigrateState(types.MigrateStateComplete)
+	p.pubRTCPQueue.Start()
+	p.onPrimaryTransportFullyEstablished()
+	fs.Start()
+	return tracks, nil
+}
+
+func (p *ParticipantImpl) onMediaFileSourceClosed() {
+	p.pubLogger.Infow("media files ended")
+	_ = p.Close(false, types.ParticipantCloseReasonClientRequestLeave, false)
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtc
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/utils"
+
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	sutils "github.com/livekit/livekit-server/pkg/utils"
+)
+
+// PublishPlainTransport publishes the streams received on a plain transport as tracks of the participant.
+// The participant becomes active with the first packet received and is closed when the transport goes idle.
+func (p *ParticipantImpl) PublishPlainTransport(params types.PlainTransportParams) (*types.PlainTransportInfo, error) {
+	if p.plainTransport.Load() != nil {
+		return nil, ErrPlainTransportExists
+	}
+	if p.mediaFileSource.Load() != nil {
+		return nil, ErrMediaFileSourceExists
+	}
+
+	pt, err := NewPlainTransport(PlainTransportParams{
+		PlainTransportParams: params,
+		BufferFactory:        p.params.Config.BufferFactory,
+		Logger:               p.params.Logger.WithComponent(sutils.ComponentTransport),
+		OnConnected:          p.onPlainTransportConnected,
+		OnClosed:             p.onPlainTransportClosed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !p.plainTransport.CompareAndSwap(nil, pt) {
+		pt.Close()
+		return nil, ErrPlainTransportExists
+	}
+
+	info := &types.PlainTransportInfo{SRTP: pt.LocalSRTP()}
+	for idx, trackParams := range params.Tracks {
+		ti, err := p.addPlainTrack(trackParams, strconv.Itoa(idx))
+		if err != nil {
+			pt.Close()
+			return nil, err
+		}
+		info.Tracks = append(info.Tracks, ti)
+	}
+
+	p.setIsPublisher(true)
+	p.dirty.Store(true)
+	pt.Start()
+	return info, nil
+}
+
+func (p *ParticipantImpl) addPlainTrack(params types.PlainTrackParams, mid string) (*livekit.TrackInfo, error) {
+	track := newPlainTrackRemote(params)
+	req := utils.CloneProto(params.Request)
+	req.Cid = track.ID()
+	req.Type = ToProtoTrackKind(track.Kind())
+	if !p.CanPublishSource(req.Source) {
+		return nil, ErrPermissionDenied
+	}
+
+	p.pendingTracksLock.Lock()
+	ti := p.addPendingTrackLocked(req)
+	p.pendingTracksLock.Unlock()
+	if ti == nil {
+		return nil, fmt.Errorf("could not add track for SSRC %d", params.SSRC)
+	}
+
+	receiver, err := newPlainRTPReceiver(track)
+	if err != nil {
+		return nil, err
+	}
+	mt, _ := p.mediaTrackReceived(track, receiver, mid)
+	if mt == nil {
+		return nil, fmt.Errorf("could not publish track for SSRC %d", params.SSRC)
+	}
+
+	p.pubLogger.Infow("plain track published",
+		"trackID", mt.ID(),
+		"SSRC", params.SSRC,
+		"mime", track.Codec().MimeType,
+	)
+	return mt.ToProto(), nil
+}
+
+func (p *ParticipantImpl) onPlainTransportConnected() {
+	p.tracer.addEvent("plain transport connected")
+	p.SetMigrateState(types.MigrateStateComplete)
+	p.pubRTCPQueue.Start()
+	p.onPrimaryTransportFullyEstablished()
+}
+
+func (p *ParticipantImpl) onPlainTransportClosed() {
+	// as with one-shot signalling, there is no way to notify the producer
+	_ = p.Close(false, types.ParticipantCloseReasonPeerConnectionDisconnected, false)
+}
@@ -330,6 +330,7 @@ func (p *ParticipantImpl) writeMessage(msg *livekit.SignalResponse) error {
 		return nil
 	}
 
+	p.params.SignalRecorder.RecordResponse(msg)
 	err := sink.WriteMessage(msg)
 	if utils.ErrorIsOneOf(err, psrpc.Canceled, routing.ErrChannelClosed) {
 		p.params.Logger.Debugw(
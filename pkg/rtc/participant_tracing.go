@@ -0,0 +1,118 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/telemetry/tracing"
+)
+
+// participantTracer follows a participant from session start until the primary transport is
+// fully established, with a child span for each offer/answer exchange.
+type participantTracer struct {
+	lock         sync.Mutex
+	ctx          context.Context
+	connectSpan  trace.Span
+	negotiations map[livekit.SignalTarget]trace.Span
+}
+
+func newParticipantTracer(parent context.Context, params ParticipantParams) *participantTracer {
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, span := tracing.Start(parent, "ParticipantImpl.connect", trace.WithAttributes(
+		tracing.AttrParticipantIdentity.String(string(params.Identity)),
+		tracing.AttrParticipantID.String(string(params.SID)),
+		attribute.Bool("livekit.reconnect", params.Reconnect),
+		attribute.Bool("livekit.migration", params.Migration),
+	))
+	return &participantTracer{
+		ctx:          ctx,
+		connectSpan:  span,
+		negotiations: make(map[livekit.SignalTarget]trace.Span),
+	}
+}
+
+func (t *participantTracer) addEvent(name string, attrs ...attribute.KeyValue) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.connectSpan != nil {
+		t.connectSpan.AddEvent(name, trace.WithAttributes(attrs...))
+	}
+}
+
+func (t *participantTracer) endConnect(err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.connectSpan != nil {
+		tracing.EndSpan(t.connectSpan, err)
+		t.connectSpan = nil
+	}
+}
+
+func (t *participantTracer) startNegotiation(target livekit.SignalTarget) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.negotiations[target]; ok {
+		// renegotiation while the previous exchange is in flight, keep the original span
+		return
+	}
+	_, span := tracing.Start(t.ctx, "ParticipantImpl.negotiate", trace.WithAttributes(
+		tracing.AttrTransport.String(target.String()),
+	))
+	t.negotiations[target] = span
+}
+
+func (t *participantTracer) endNegotiation(target livekit.SignalTarget, err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if span, ok := t.negotiations[target]; ok {
+		tracing.EndSpan(span, err)
+		delete(t.negotiations, target)
+	}
+}
+
+func (t *participantTracer) recordPublish(ti *livekit.TrackInfo, startedAt time.Time) {
+	_, span := tracing.Start(t.ctx, "ParticipantImpl.publishTrack", trace.WithTimestamp(startedAt), trace.WithAttributes(
+		tracing.AttrTrackID.String(ti.Sid),
+		attribute.String("livekit.track.source", ti.Source.String()),
+		attribute.String("livekit.track.mime", ti.MimeType),
+	))
+	span.End()
+}
+
+// close ends all outstanding spans, used when the participant goes away before connecting
+func (t *participantTracer) close(err error) {
+	t.lock.Lock()
+	for target, span := range t.negotiations {
+		tracing.EndSpan(span, err)
+		delete(t.negotiations, target)
+	}
+	t.lock.Unlock()
+
+	t.endConnect(err)
+}
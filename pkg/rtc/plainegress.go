@@ -0,0 +1,362 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtc
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"time"
+
+	"github.com/frostbyte73/core"
+	"github.com/pion/interceptor"
+	"github.com/pion/rtcp"
+	"github.com/pion/webrtc/v4"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+	"github.com/livekit/protocol/utils/guid"
+
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	"github.com/livekit/livekit-server/pkg/sfu"
+	"github.com/livekit/livekit-server/pkg/sfu/buffer"
+	"github.com/livekit/livekit-server/pkg/sfu/mime"
+	"github.com/livekit/livekit-server/pkg/sfu/pacer"
+)
+
+const (
+	plainEgressPrefix = "PE_"
+	// same as the sender reports of subscribers
+	plainEgressReportInterval = 3 * time.Second
+)
+
+// PlainEgressTransport carries the RTP and RTCP of a plain egress when it is not sent on a plain transport,
+// e. g. over RTSP. RTCP received by the transport is delivered through the buffer factory of the egress.
+type PlainEgressTransport interface {
+	webrtc.TrackLocalWriter
+	WriteRTCP(pkts []rtcp.Packet) error
+	Close()
+}
+
+// PlainEgressParams are the parameters of forwarding a published track to a plain RTP destination
+type PlainEgressParams struct {
+	Track types.MediaTrack
+	// a plain transport on Conn is used when Transport is not set
+	Transport PlainEgressTransport
+	Conn      *net.UDPConn
+	// where RTP is sent to, RTCP is accepted from this address only
+	RemoteAddr *net.UDPAddr
+	// keys of RTCP sent by the destination, SRTP is not used when not set
+	SRTP *types.PlainTransportSRTP
+	// simulcast layer forwarded, for video tracks
+	Quality livekit.VideoQuality
+	// each egress uses its own buffer factory, RTCP is routed by SSRC
+	BufferFactory *buffer.Factory
+	// number of packets kept for retransmission
+	MaxTrack int
+	Logger   logger.Logger
+}
+
+// PlainEgress forwards a published track as plain RTP, optionally SRTP protected, to a UDP destination such as
+// broadcast gear or ffmpeg. It is a down track written to a plain transport instead of a peer connection,
+// the forwarded layer is fixed and there is no bandwidth estimation.
+type PlainEgress struct {
+	params         PlainEgressParams
+	id             string
+	ssrc           uint32
+	codec          webrtc.RTPCodecParameters
+	transport      PlainEgressTransport
+	plainTransport *PlainTransport
+	downTrack      *sfu.DownTrack
+	logger         logger.Logger
+
+	onClosed func()
+
+	allocateCh chan struct{}
+	closed     core.Fuse
+}
+
+func NewPlainEgress(params PlainEgressParams) (*PlainEgress, error) {
+	codec, err := PlainEgressCodec(params.Track)
+	if err != nil {
+		return nil, err
+	}
+	receiver := params.Track.Receivers()[0]
+
+	e := &PlainEgress{
+		params:     params,
+		id:         guid.New(plainEgressPrefix),
+		ssrc:       newPlainEgressSSRC(),
+		codec:      codec,
+		transport:  params.Transport,
+		allocateCh: make(chan struct{}, 1),
+	}
+	e.logger = params.Logger.WithValues("egressID", e.id, "trackID", params.Track.ID())
+
+	upstreamCodecs := []webrtc.RTPCodecParameters{receiver.Codec()}
+	if mime.IsMimeTypeStringRED(receiver.Codec().MimeType) {
+		// the down track translates RED to its primary encoding when that is what is negotiated
+		upstreamCodecs = append(upstreamCodecs, codec)
+	}
+
+	if e.transport == nil {
+		e.plainTransport, err = NewPlainTransport(PlainTransportParams{
+			PlainTransportParams: types.PlainTransportParams{
+				Conn:       params.Conn,
+				RemoteAddr: params.RemoteAddr,
+				SRTP:       params.SRTP,
+			},
+			SendSSRCs:     []uint32{e.ssrc},
+			BufferFactory: params.BufferFactory,
+			Logger:        e.logger,
+		})
+		if err != nil {
+			return nil, err
+		}
+		e.transport = e.plainTransport
+	}
+
+	e.downTrack, err = sfu.NewDownTrack(sfu.DowntrackParams{
+		Codecs:        upstreamCodecs,
+		Source:        params.Track.Source(),
+		Receiver:      receiver,
+		BufferFactory: params.BufferFactory,
+		SubID:         livekit.ParticipantID(e.id),
+		StreamID:      receiver.StreamID(),
+		MaxTrack:      params.MaxTrack,
+		Pacer:         pacer.NewPassThrough(e.logger, nil),
+		Logger:        e.logger,
+		RTCPWriter:    e.transport.WriteRTCP,
+	})
+	if err != nil {
+		e.transport.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *PlainEgress) ID() string {
+	return e.id
+}
+
+func (e *PlainEgress) TrackID() livekit.TrackID {
+	return e.params.Track.ID()
+}
+
+func (e *PlainEgress) SSRC() uint32 {
+	return e.ssrc
+}
+
+func (e *PlainEgress) Codec() webrtc.RTPCodecParameters {
+	return e.codec
+}
+
+// LocalSRTP returns the keys protecting RTP sent by the server, nil when SRTP is not used
+func (e *PlainEgress) LocalSRTP() *types.PlainTransportSRTP {
+	if e.plainTransport == nil {
+		return nil
+	}
+	return e.plainTransport.LocalSRTP()
+}
+
+// OnClosed sets a callback invoked when the egress closes by itself, i. e. when the track is unpublished.
+// It must be set before Start.
+func (e *PlainEgress) OnClosed(f func()) {
+	e.onClosed = f
+}
+
+func (e *PlainEgress) Start() error {
+	dt := e.downTrack
+	dt.OnCloseHandler(func(_ bool) { e.closeByItself() })
+	e.params.Track.AddOnClose(func(_ bool) { e.closeByItself() })
+
+	if _, err := dt.Bind(&plainTrackLocalContext{
+		id:          e.id,
+		codec:       e.codec,
+		ssrc:        e.ssrc,
+		writeStream: e.transport,
+	}); err != nil {
+		return err
+	}
+	if dt.Kind() == webrtc.RTPCodecTypeVideo {
+		ti := e.params.Track.ToProto()
+		dt.SetMaxSpatialLayer(buffer.VideoQualityToSpatialLayer(e.params.Quality, ti))
+		if lmt, ok := e.params.Track.(types.LocalMediaTrack); ok {
+			lmt.NotifySubscriberNodeMaxQuality(livekit.NodeID(e.id), []types.SubscribedCodecQuality{
+				{CodecMime: mime.NormalizeMimeType(e.codec.MimeType), Quality: e.params.Quality},
+			})
+		}
+		dt.SetStreamAllocatorListener(e)
+	}
+	if err := dt.Receiver().AddDownTrack(dt); err != nil {
+		return err
+	}
+
+	if e.plainTransport != nil {
+		e.plainTransport.Start()
+	}
+	// bound and connected, requests a key frame to start forwarding
+	dt.SetConnected()
+	go e.allocateWorker()
+	go e.reportWorker()
+
+	values := []interface{}{
+		"ssrc", e.ssrc,
+		"mime", e.codec.MimeType,
+		"quality", e.params.Quality,
+	}
+	if e.plainTransport != nil {
+		values = append(values, "remoteAddr", e.params.RemoteAddr)
+	}
+	e.logger.Infow("plain egress started", values...)
+	return nil
+}
+
+func (e *PlainEgress) Close() {
+	if e.closed.IsBroken() {
+		return
+	}
+	e.close()
+}
+
+func (e *PlainEgress) close() {
+	e.closed.Break()
+
+	e.downTrack.Close()
+	e.transport.Close()
+	if lmt, ok := e.params.Track.(types.LocalMediaTrack); ok && e.downTrack.Kind() == webrtc.RTPCodecTypeVideo {
+		lmt.NotifySubscriberNodeMaxQuality(livekit.NodeID(e.id), []types.SubscribedCodecQuality{
+			{CodecMime: mime.NormalizeMimeType(e.codec.MimeType), Quality: livekit.VideoQuality_OFF},
+		})
+	}
+	e.logger.Infow("plain egress closed")
+}
+
+func (e *PlainEgress) closeByItself() {
+	if e.closed.IsBroken() {
+		return
+	}
+	e.logger.Infow("plain egress track closed")
+	e.close()
+	if onClosed := e.onClosed; onClosed != nil {
+		onClosed()
+	}
+}
+
+// allocateWorker applies layer changes outside of down track callbacks, as the stream allocator does
+func (e *PlainEgress) allocateWorker() {
+	for {
+		select {
+		case <-e.closed.Watch():
+			return
+		case <-e.allocateCh:
+			e.downTrack.AllocateOptimal(true, false)
+		}
+	}
+}
+
+func (e *PlainEgress) reportWorker() {
+	ticker := time.NewTicker(plainEgressReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.closed.Watch():
+			return
+		case <-ticker.C:
+			sr := e.downTrack.CreateSenderReport()
+			chunks := e.downTrack.CreateSourceDescriptionChunks()
+			if sr == nil || chunks == nil {
+				continue
+			}
+			if err := e.transport.WriteRTCP([]rtcp.Packet{sr, &rtcp.SourceDescription{Chunks: chunks}}); err != nil {
+				e.logger.Debugw("could not send sender report", "error", err)
+			}
+		}
+	}
+}
+
+func (e *PlainEgress) postAllocate() {
+	select {
+	case e.allocateCh <- struct{}{}:
+	default:
+	}
+}
+
+// sfu.DownTrackStreamAllocatorListener, the forwarded layer only follows what the publisher sends
+
+func (e *PlainEgress) OnREMB(_ *sfu.DownTrack, _ *rtcp.ReceiverEstimatedMaximumBitrate) {}
+func (e *PlainEgress) OnTransportCCFeedback(_ *sfu.DownTrack, _ *rtcp.TransportLayerCC) {}
+func (e *PlainEgress) OnAvailableLayersChanged(_ *sfu.DownTrack)                        { e.postAllocate() }
+func (e *PlainEgress) OnBitrateAvailabilityChanged(_ *sfu.DownTrack)                    { e.postAllocate() }
+func (e *PlainEgress) OnMaxPublishedSpatialChanged(_ *sfu.DownTrack)                    { e.postAllocate() }
+func (e *PlainEgress) OnMaxPublishedTemporalChanged(_ *sfu.DownTrack)                   { e.postAllocate() }
+func (e *PlainEgress) OnSubscriptionChanged(_ *sfu.DownTrack)                           { e.postAllocate() }
+func (e *PlainEgress) OnSubscribedLayerChanged(_ *sfu.DownTrack, _ buffer.VideoLayer) {
+	e.postAllocate()
+}
+func (e *PlainEgress) OnResume(_ *sfu.DownTrack)                {}
+func (e *PlainEgress) IsBWEEnabled(_ *sfu.DownTrack) bool       { return false }
+func (e *PlainEgress) IsSubscribeMutable(_ *sfu.DownTrack) bool { return false }
+
+var _ sfu.DownTrackStreamAllocatorListener = (*PlainEgress)(nil)
+
+// PlainEgressCodec returns the codec a plain egress of the track sends
+func PlainEgressCodec(track types.MediaTrack) (webrtc.RTPCodecParameters, error) {
+	receivers := track.Receivers()
+	if len(receivers) == 0 {
+		return webrtc.RTPCodecParameters{}, ErrPlainEgressNoReceiver
+	}
+
+	codec := receivers[0].Codec()
+	if mime.IsMimeTypeStringRED(codec.MimeType) {
+		// destinations are not expected to handle RED, forward the primary encoding
+		codec = webrtc.RTPCodecParameters{RTPCodecCapability: OpusCodecCapability, PayloadType: 111}
+	}
+	return codec, nil
+}
+
+func newPlainEgressSSRC() uint32 {
+	b := make([]byte, 4)
+	for {
+		_, _ = rand.Read(b)
+		if ssrc := binary.BigEndian.Uint32(b); ssrc != 0 {
+			return ssrc
+		}
+	}
+}
+
+// ------------------------------------------------------
+
+// plainTrackLocalContext binds a down track to a plain transport, in place of the RTP sender of a peer connection
+type plainTrackLocalContext struct {
+	id          string
+	codec       webrtc.RTPCodecParameters
+	ssrc        uint32
+	writeStream webrtc.TrackLocalWriter
+}
+
+func (c *plainTrackLocalContext) CodecParameters() []webrtc.RTPCodecParameters {
+	return []webrtc.RTPCodecParameters{c.codec}
+}
+
+func (c *plainTrackLocalContext) HeaderExtensions() []webrtc.RTPHeaderExtensionParameter { return nil }
+func (c *plainTrackLocalContext) SSRC() webrtc.SSRC                                      { return webrtc.SSRC(c.ssrc) }
+func (c *plainTrackLocalContext) SSRCRetransmission() webrtc.SSRC                        { return 0 }
+func (c *plainTrackLocalContext) SSRCForwardErrorCorrection() webrtc.SSRC                { return 0 }
+func (c *plainTrackLocalContext) WriteStream() webrtc.TrackLocalWriter                   { return c.writeStream }
+func (c *plainTrackLocalContext) ID() string                                             { return c.id }
+func (c *plainTrackLocalContext) RTCPReader() interceptor.RTCPReader                     { return nil }
+
+var _ webrtc.TrackLocalContext = (*plainTrackLocalContext)(nil)
@@ -0,0 +1,163 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtc
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/pion/srtp/v3"
+	"github.com/pion/webrtc/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	"github.com/livekit/livekit-server/pkg/sfu/buffer"
+)
+
+func TestPlainEgress(t *testing.T) {
+	p := newParticipantForTestWithOpts("plain", &participantOpts{
+		permissions: &livekit.ParticipantPermission{CanPublish: true},
+	})
+	defer p.Close(false, types.ParticipantCloseReasonClientRequestLeave, false)
+
+	publisherConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	info, err := p.PublishPlainTransport(types.PlainTransportParams{
+		Conn: publisherConn,
+		Tracks: []types.PlainTrackParams{
+			{
+				Request: &livekit.AddTrackRequest{Name: "mic", Source: livekit.TrackSource_MICROPHONE},
+				SSRC:    1111,
+				Codec: webrtc.RTPCodecParameters{
+					RTPCodecCapability: webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus, ClockRate: 48000, Channels: 2},
+					PayloadType:        111,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	track := p.GetPublishedTrack(livekit.TrackID(info.Tracks[0].Sid))
+	require.NotNil(t, track)
+
+	producer, err := net.DialUDP("udp", nil, publisherConn.LocalAddr().(*net.UDPAddr))
+	require.NoError(t, err)
+	defer producer.Close()
+	produce := func(sn uint16) {
+		pkt, err := (&rtp.Packet{
+			Header:  rtp.Header{Version: 2, PayloadType: 111, SequenceNumber: sn, Timestamp: uint32(sn) * 960, SSRC: 1111},
+			Payload: []byte{0xf8, 0xff, 0xfe},
+		}).Marshal()
+		require.NoError(t, err)
+		_, err = producer.Write(pkt)
+		require.NoError(t, err)
+	}
+	// the receiver becomes ready with the first packet
+	produce(1)
+	require.Eventually(t, func() bool { return len(track.Receivers()) != 0 }, time.Second, 10*time.Millisecond)
+
+	destination, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer destination.Close()
+	egressConn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+
+	keys, err := generatePlainTransportSRTP(srtp.ProtectionProfileAes128CmHmacSha1_80)
+	require.NoError(t, err)
+	egress, err := NewPlainEgress(PlainEgressParams{
+		Track:         track,
+		Conn:          egressConn,
+		RemoteAddr:    destination.LocalAddr().(*net.UDPAddr),
+		SRTP:          keys,
+		Quality:       livekit.VideoQuality_HIGH,
+		BufferFactory: buffer.NewFactoryOfBufferFactory(500, 200).CreateBufferFactory(),
+		MaxTrack:      200,
+		Logger:        logger.GetLogger(),
+	})
+	require.NoError(t, err)
+	closed := make(chan struct{})
+	egress.OnClosed(func() { close(closed) })
+	require.NoError(t, egress.Start())
+	require.True(t, webrtc.MimeTypeOpus == egress.Codec().MimeType)
+	require.NotNil(t, egress.LocalSRTP())
+
+	decrypt, err := newPlainTransportSRTPContext(egress.LocalSRTP())
+	require.NoError(t, err)
+	encrypt, err := newPlainTransportSRTPContext(keys)
+	require.NoError(t, err)
+
+	// forwarded with the SSRC of the egress, SRTP protected
+	received := make([]byte, 1500)
+	var forwarded *rtp.Packet
+	sn := uint16(1)
+	require.Eventually(t, func() bool {
+		sn++
+		produce(sn)
+		require.NoError(t, destination.SetReadDeadline(time.Now().Add(50*time.Millisecond)))
+		n, _, err := destination.ReadFromUDP(received)
+		if err != nil || received[1] >= 192 && received[1] <= 223 {
+			return false
+		}
+		decrypted, err := decrypt.DecryptRTP(nil, received[:n], nil)
+		require.NoError(t, err)
+		forwarded = &rtp.Packet{}
+		require.NoError(t, forwarded.Unmarshal(decrypted))
+		return true
+	}, 2*time.Second, 10*time.Millisecond)
+	require.Equal(t, egress.SSRC(), forwarded.SSRC)
+	require.EqualValues(t, 111, forwarded.PayloadType)
+	require.Equal(t, []byte{0xf8, 0xff, 0xfe}, forwarded.Payload)
+
+	// RTCP from the destination reaches the down track, a NACK is answered with a retransmission
+	nack, err := rtcp.Marshal([]rtcp.Packet{&rtcp.TransportLayerNack{
+		SenderSSRC: 5678,
+		MediaSSRC:  egress.SSRC(),
+		Nacks:      rtcp.NackPairsFromSequenceNumbers([]uint16{forwarded.SequenceNumber}),
+	}})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		encrypted, err := encrypt.EncryptRTCP(nil, nack, nil)
+		require.NoError(t, err)
+		_, err = destination.WriteToUDP(encrypted, egressConn.LocalAddr().(*net.UDPAddr))
+		require.NoError(t, err)
+
+		require.NoError(t, destination.SetReadDeadline(time.Now().Add(50*time.Millisecond)))
+		n, _, err := destination.ReadFromUDP(received)
+		if err != nil || received[1] >= 192 && received[1] <= 223 {
+			return false
+		}
+		// a fresh context, the retransmission would be rejected as a replay otherwise
+		retransmission, err := newPlainTransportSRTPContext(egress.LocalSRTP())
+		require.NoError(t, err)
+		decrypted, err := retransmission.DecryptRTP(nil, received[:n], nil)
+		require.NoError(t, err)
+		var pkt rtp.Packet
+		require.NoError(t, pkt.Unmarshal(decrypted))
+		return pkt.SequenceNumber == forwarded.SequenceNumber
+	}, 2*time.Second, 50*time.Millisecond)
+
+	// unpublishing the track stops forwarding
+	track.Close(false)
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "egress not closed")
+	}
+}
@@ -0,0 +1,449 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtc
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/frostbyte73/core"
+	"github.com/pion/interceptor"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/pion/srtp/v3"
+	"github.com/pion/transport/v3/packetio"
+	"github.com/pion/webrtc/v4"
+	"go.uber.org/atomic"
+
+	"github.com/livekit/mediatransportutil/pkg/bucket"
+	"github.com/livekit/protocol/logger"
+
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	"github.com/livekit/livekit-server/pkg/sfu"
+	"github.com/livekit/livekit-server/pkg/sfu/buffer"
+	"github.com/livekit/livekit-server/pkg/sfu/mime"
+)
+
+const (
+	// plain transports are closed when nothing is received for this long
+	plainTransportIdleTimeout = 30 * time.Second
+	plainTransportCidPrefix   = "PLAIN_"
+)
+
+// PlainTransportParams are the parameters of a plain transport publishing into a participant
+type PlainTransportParams struct {
+	types.PlainTransportParams
+	// streams sent on the transport, RTCP about them is delivered through the buffer factory
+	SendSSRCs     []uint32
+	BufferFactory *buffer.Factory
+	Logger        logger.Logger
+	// called when the first packet is received
+	OnConnected func()
+	// called when the transport closes by itself, i. e. on idle timeout or socket failure
+	OnClosed func()
+}
+
+// PlainTransport receives RTP and RTCP, optionally SRTP protected, on a UDP socket from a server-side producer,
+// e. g. ffmpeg or GStreamer, and feeds it into the buffers of the published tracks. It also sends RTP to such
+// an endpoint as the write stream of down tracks.
+// Unlike PCTransport, there is no ICE nor DTLS, streams are declared upfront by SSRC.
+type PlainTransport struct {
+	params PlainTransportParams
+
+	buffers     map[uint32]*buffer.Buffer
+	rtcpReaders map[uint32]*buffer.RTCPReader
+
+	// used by the read worker only
+	decrypt *srtp.Context
+
+	localSRTP   *types.PlainTransportSRTP
+	encryptLock sync.Mutex
+	encrypt     *srtp.Context
+
+	remoteAddr   atomic.Pointer[net.UDPAddr]
+	connected    atomic.Bool
+	lastPacketAt atomic.Int64
+
+	closed core.Fuse
+}
+
+func NewPlainTransport(params PlainTransportParams) (*PlainTransport, error) {
+	if len(params.Tracks) == 0 && len(params.SendSSRCs) == 0 {
+		return nil, ErrPlainTransportNoTracks
+	}
+
+	t := &PlainTransport{
+		params:      params,
+		buffers:     make(map[uint32]*buffer.Buffer, len(params.Tracks)),
+		rtcpReaders: make(map[uint32]*buffer.RTCPReader, len(params.Tracks)+len(params.SendSSRCs)),
+	}
+	if params.RemoteAddr != nil {
+		t.remoteAddr.Store(params.RemoteAddr)
+	}
+
+	if params.SRTP != nil {
+		var err error
+		if t.decrypt, err = newPlainTransportSRTPContext(params.SRTP); err != nil {
+			return nil, err
+		}
+		if t.localSRTP, err = generatePlainTransportSRTP(params.SRTP.Profile); err != nil {
+			return nil, err
+		}
+		if t.encrypt, err = newPlainTransportSRTPContext(t.localSRTP); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, track := range params.Tracks {
+		if _, ok := t.buffers[track.SSRC]; ok || params.BufferFactory.GetBuffer(track.SSRC) != nil {
+			t.closeBuffers()
+			return nil, fmt.Errorf("%w: %d", ErrPlainTransportSSRCInUse, track.SSRC)
+		}
+		t.buffers[track.SSRC] = params.BufferFactory.GetOrNew(packetio.RTPBufferPacket, track.SSRC).(*buffer.Buffer)
+		t.rtcpReaders[track.SSRC] = params.BufferFactory.GetOrNew(packetio.RTCPBufferPacket, track.SSRC).(*buffer.RTCPReader)
+	}
+	for _, ssrc := range params.SendSSRCs {
+		if _, ok := t.rtcpReaders[ssrc]; ok {
+			t.closeBuffers()
+			return nil, fmt.Errorf("%w: %d", ErrPlainTransportSSRCInUse, ssrc)
+		}
+		t.rtcpReaders[ssrc] = params.BufferFactory.GetOrNew(packetio.RTCPBufferPacket, ssrc).(*buffer.RTCPReader)
+	}
+	return t, nil
+}
+
+// LocalSRTP returns the keys protecting RTCP sent by the server, nil when SRTP is not used
+func (t *PlainTransport) LocalSRTP() *types.PlainTransportSRTP {
+	return t.localSRTP
+}
+
+func (t *PlainTransport) LocalAddr() *net.UDPAddr {
+	return t.params.Conn.LocalAddr().(*net.UDPAddr)
+}
+
+func (t *PlainTransport) IsConnected() bool {
+	return t.connected.Load()
+}
+
+func (t *PlainTransport) Start() {
+	t.lastPacketAt.Store(time.Now().UnixNano())
+	go t.readWorker()
+	// a destination may not send anything back, only time out receiving transports
+	if len(t.params.Tracks) != 0 {
+		go t.idleWorker()
+	}
+}
+
+func (t *PlainTransport) Close() {
+	if t.closed.IsBroken() {
+		return
+	}
+	t.closed.Break()
+
+	_ = t.params.Conn.Close()
+	t.closeBuffers()
+}
+
+// WriteRTCP sends RTCP to the producer, packets are dropped until its address is known
+func (t *PlainTransport) WriteRTCP(pkts []rtcp.Packet) error {
+	remoteAddr := t.remoteAddr.Load()
+	if remoteAddr == nil || t.closed.IsBroken() {
+		return nil
+	}
+
+	payload, err := rtcp.Marshal(pkts)
+	if err != nil {
+		return err
+	}
+	if t.encrypt != nil {
+		t.encryptLock.Lock()
+		payload, err = t.encrypt.EncryptRTCP(nil, payload, nil)
+		t.encryptLock.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	_, err = t.params.Conn.WriteToUDP(payload, remoteAddr)
+	return err
+}
+
+// WriteRTP sends an RTP packet to the remote endpoint, it implements webrtc.TrackLocalWriter for down tracks
+func (t *PlainTransport) WriteRTP(header *rtp.Header, payload []byte) (int, error) {
+	pkt, err := (&rtp.Packet{Header: *header, Payload: payload}).Marshal()
+	if err != nil {
+		return 0, err
+	}
+	return t.Write(pkt)
+}
+
+// Write sends a marshalled RTP packet to the remote endpoint
+func (t *PlainTransport) Write(pkt []byte) (int, error) {
+	remoteAddr := t.remoteAddr.Load()
+	if t.closed.IsBroken() {
+		return 0, io.ErrClosedPipe
+	}
+	if remoteAddr == nil {
+		return 0, nil
+	}
+
+	if t.encrypt != nil {
+		var err error
+		t.encryptLock.Lock()
+		pkt, err = t.encrypt.EncryptRTP(nil, pkt, nil)
+		t.encryptLock.Unlock()
+		if err != nil {
+			return 0, err
+		}
+	}
+	return t.params.Conn.WriteToUDP(pkt, remoteAddr)
+}
+
+func (t *PlainTransport) closeBuffers() {
+	for _, buff := range t.buffers {
+		_ = buff.Close()
+	}
+	for _, reader := range t.rtcpReaders {
+		_ = reader.Close()
+	}
+}
+
+func (t *PlainTransport) readWorker() {
+	pkt := make([]byte, bucket.MaxPktSize)
+	var decrypted []byte
+	for {
+		n, addr, err := t.params.Conn.ReadFromUDP(pkt)
+		if err != nil {
+			if !t.closed.IsBroken() {
+				t.params.Logger.Warnw("could not read from plain transport", err)
+				t.closeByItself()
+			}
+			return
+		}
+
+		remoteAddr := t.remoteAddr.Load()
+		if remoteAddr != nil && (!remoteAddr.IP.Equal(addr.IP) || remoteAddr.Port != addr.Port) {
+			continue
+		}
+
+		// RTP and RTCP are multiplexed as with rtcp-mux (RFC 5761)
+		isRTCP := n >= 8 && pkt[1] >= 192 && pkt[1] <= 223
+		payload := pkt[:n]
+		if t.decrypt != nil {
+			if isRTCP {
+				decrypted, err = t.decrypt.DecryptRTCP(decrypted, payload, nil)
+			} else {
+				decrypted, err = t.decrypt.DecryptRTP(decrypted, payload, nil)
+			}
+			if err != nil {
+				t.params.Logger.Debugw("could not decrypt packet", "error", err, "isRTCP", isRTCP)
+				continue
+			}
+			payload = decrypted
+		}
+
+		// anyone can send to the port, only lock onto a sender which authenticated or sends a declared stream
+		if remoteAddr == nil {
+			if t.decrypt == nil && !t.isDeclared(payload, isRTCP) {
+				continue
+			}
+			t.remoteAddr.Store(addr)
+		}
+
+		if isRTCP {
+			t.handleRTCP(payload)
+		} else {
+			t.handleRTP(payload)
+		}
+	}
+}
+
+// isDeclared returns whether a packet is about a stream declared on the transport
+func (t *PlainTransport) isDeclared(payload []byte, isRTCP bool) bool {
+	if !isRTCP {
+		return len(payload) >= 12 && t.buffers[binary.BigEndian.Uint32(payload[8:12])] != nil
+	}
+
+	pkts, err := rtcp.Unmarshal(payload)
+	if err != nil {
+		return false
+	}
+	for _, pkt := range pkts {
+		for _, ssrc := range pkt.DestinationSSRC() {
+			if t.rtcpReaders[ssrc] != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (t *PlainTransport) handleRTP(payload []byte) {
+	if len(payload) < 12 {
+		return
+	}
+	buff := t.buffers[binary.BigEndian.Uint32(payload[8:12])]
+	if buff == nil {
+		return
+	}
+
+	t.lastPacketAt.Store(time.Now().UnixNano())
+	if !t.connected.Swap(true) {
+		t.params.Logger.Infow("plain transport connected", "remoteAddr", t.remoteAddr.Load().String())
+		if onConnected := t.params.OnConnected; onConnected != nil {
+			onConnected()
+		}
+	}
+	_, _ = buff.Write(payload)
+}
+
+func (t *PlainTransport) handleRTCP(payload []byte) {
+	pkts, err := rtcp.Unmarshal(payload)
+	if err != nil {
+		t.params.Logger.Debugw("could not unmarshal RTCP", "error", err)
+		return
+	}
+
+	t.lastPacketAt.Store(time.Now().UnixNano())
+	// same as the SRTP session of a peer connection, deliver to each stream the compound packet is about
+	seen := make(map[uint32]struct{})
+	for _, pkt := range pkts {
+		for _, ssrc := range pkt.DestinationSSRC() {
+			if _, ok := seen[ssrc]; ok {
+				continue
+			}
+			seen[ssrc] = struct{}{}
+			if reader := t.rtcpReaders[ssrc]; reader != nil {
+				_, _ = reader.Write(payload)
+			}
+		}
+	}
+}
+
+func (t *PlainTransport) idleWorker() {
+	ticker := time.NewTicker(plainTransportIdleTimeout / 10)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.closed.Watch():
+			return
+		case <-ticker.C:
+			if time.Since(time.Unix(0, t.lastPacketAt.Load())) > plainTransportIdleTimeout {
+				t.params.Logger.Infow("plain transport idle, closing", "connected", t.connected.Load())
+				t.closeByItself()
+				return
+			}
+		}
+	}
+}
+
+func (t *PlainTransport) closeByItself() {
+	if t.closed.IsBroken() {
+		return
+	}
+	t.Close()
+	if onClosed := t.params.OnClosed; onClosed != nil {
+		onClosed()
+	}
+}
+
+func newPlainTransportSRTPContext(keys *types.PlainTransportSRTP) (*srtp.Context, error) {
+	keyLen, err := keys.Profile.KeyLen()
+	if err != nil {
+		return nil, err
+	}
+	saltLen, err := keys.Profile.SaltLen()
+	if err != nil {
+		return nil, err
+	}
+	if len(keys.KeyMaterial) != keyLen+saltLen {
+		return nil, fmt.Errorf("%w: expected %d bytes, got %d", ErrPlainTransportSRTPKey, keyLen+saltLen, len(keys.KeyMaterial))
+	}
+	return srtp.CreateContext(keys.KeyMaterial[:keyLen], keys.KeyMaterial[keyLen:], keys.Profile)
+}
+
+func generatePlainTransportSRTP(profile srtp.ProtectionProfile) (*types.PlainTransportSRTP, error) {
+	keyLen, err := profile.KeyLen()
+	if err != nil {
+		return nil, err
+	}
+	saltLen, err := profile.SaltLen()
+	if err != nil {
+		return nil, err
+	}
+	keyMaterial := make([]byte, keyLen+saltLen)
+	if _, err := rand.Read(keyMaterial); err != nil {
+		return nil, err
+	}
+	return &types.PlainTransportSRTP{Profile: profile, KeyMaterial: keyMaterial}, nil
+}
+
+// ------------------------------------------------------
+
+func plainTrackCid(ssrc uint32) string {
+	return plainTransportCidPrefix + strconv.FormatUint(uint64(ssrc), 10)
+}
+
+// plainTrackRemote is the sfu.TrackRemote of a stream declared on a plain transport
+type plainTrackRemote struct {
+	cid   string
+	ssrc  uint32
+	codec webrtc.RTPCodecParameters
+}
+
+func newPlainTrackRemote(params types.PlainTrackParams) *plainTrackRemote {
+	return &plainTrackRemote{
+		cid:   plainTrackCid(params.SSRC),
+		ssrc:  params.SSRC,
+		codec: params.Codec,
+	}
+}
+
+func (t *plainTrackRemote) ID() string                       { return t.cid }
+func (t *plainTrackRemote) RID() string                      { return "" }
+func (t *plainTrackRemote) Msid() string                     { return t.cid }
+func (t *plainTrackRemote) SSRC() webrtc.SSRC                { return webrtc.SSRC(t.ssrc) }
+func (t *plainTrackRemote) StreamID() string                 { return t.cid }
+func (t *plainTrackRemote) Codec() webrtc.RTPCodecParameters { return t.codec }
+func (t *plainTrackRemote) RTCTrack() *webrtc.TrackRemote    { return nil }
+
+func (t *plainTrackRemote) Kind() webrtc.RTPCodecType {
+	if mime.IsMimeTypeStringAudio(t.codec.MimeType) {
+		return webrtc.RTPCodecTypeAudio
+	}
+	return webrtc.RTPCodecTypeVideo
+}
+
+var _ sfu.TrackRemote = (*plainTrackRemote)(nil)
+
+// newPlainRTPReceiver creates a receiver outside of a peer connection, it only serves the RTP parameters,
+// i. e. the declared codec, to the media track.
+func newPlainRTPReceiver(track *plainTrackRemote) (*webrtc.RTPReceiver, error) {
+	me := &webrtc.MediaEngine{}
+	if err := me.RegisterCodec(track.codec, track.Kind()); err != nil {
+		return nil, err
+	}
+	api := webrtc.NewAPI(webrtc.WithMediaEngine(me), webrtc.WithInterceptorRegistry(&interceptor.Registry{}))
+	dtlsTransport, err := api.NewDTLSTransport(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewRTPReceiver(track.Kind(), dtlsTransport)
+}
@@ -0,0 +1,259 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtc
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/pion/srtp/v3"
+	"github.com/pion/webrtc/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	"github.com/livekit/livekit-server/pkg/sfu/buffer"
+	"github.com/livekit/livekit-server/pkg/sfu/mime"
+)
+
+func TestPlainTransport(t *testing.T) {
+	opus := webrtc.RTPCodecParameters{
+		RTPCodecCapability: webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus, ClockRate: 48000, Channels: 2},
+		PayloadType:        111,
+	}
+	newTransport := func(t *testing.T, factory *buffer.Factory, keys *types.PlainTransportSRTP, connected *atomic.Bool) *PlainTransport {
+		conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		require.NoError(t, err)
+		pt, err := NewPlainTransport(PlainTransportParams{
+			PlainTransportParams: types.PlainTransportParams{
+				Conn: conn,
+				SRTP: keys,
+				Tracks: []types.PlainTrackParams{
+					{Request: &livekit.AddTrackRequest{Name: "mic"}, SSRC: 1234, Codec: opus},
+				},
+			},
+			BufferFactory: factory,
+			Logger:        logger.GetLogger(),
+			OnConnected:   func() { connected.Store(true) },
+		})
+		require.NoError(t, err)
+		t.Cleanup(pt.Close)
+		return pt
+	}
+
+	for name, profile := range map[string]srtp.ProtectionProfile{
+		"RTP":          0,
+		"SRTP":         srtp.ProtectionProfileAes128CmHmacSha1_80,
+		"SRTP AES-GCM": srtp.ProtectionProfileAeadAes128Gcm,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var keys *types.PlainTransportSRTP
+			var err error
+			if profile != 0 {
+				keys, err = generatePlainTransportSRTP(profile)
+				require.NoError(t, err)
+			}
+
+			factory := buffer.NewFactoryOfBufferFactory(500, 200).CreateBufferFactory()
+			var connected atomic.Bool
+			pt := newTransport(t, factory, keys, &connected)
+			require.Equal(t, keys != nil, pt.LocalSRTP() != nil)
+
+			buff := factory.GetBuffer(1234)
+			require.NotNil(t, buff)
+			receiver, err := newPlainRTPReceiver(newPlainTrackRemote(types.PlainTrackParams{SSRC: 1234, Codec: opus}))
+			require.NoError(t, err)
+			buff.Bind(receiver.GetParameters(), opus.RTPCodecCapability, 0)
+
+			var senderReports atomic.Int32
+			factory.GetRTCPReader(1234).OnPacket(func(_ []byte) { senderReports.Inc() })
+			pt.Start()
+
+			producer, err := net.DialUDP("udp", nil, pt.LocalAddr())
+			require.NoError(t, err)
+			defer producer.Close()
+
+			var encrypt, decrypt *srtp.Context
+			if keys != nil {
+				encrypt, err = newPlainTransportSRTPContext(keys)
+				require.NoError(t, err)
+				decrypt, err = newPlainTransportSRTPContext(pt.LocalSRTP())
+				require.NoError(t, err)
+			}
+			send := func(payload []byte, isRTCP bool) {
+				if encrypt != nil {
+					if isRTCP {
+						payload, err = encrypt.EncryptRTCP(nil, payload, nil)
+					} else {
+						payload, err = encrypt.EncryptRTP(nil, payload, nil)
+					}
+					require.NoError(t, err)
+				}
+				_, err = producer.Write(payload)
+				require.NoError(t, err)
+			}
+
+			// unknown SSRC is dropped
+			unknown, err := (&rtp.Packet{Header: rtp.Header{Version: 2, PayloadType: 111, SSRC: 5678}, Payload: []byte{1}}).Marshal()
+			require.NoError(t, err)
+			send(unknown, false)
+
+			pkt, err := (&rtp.Packet{
+				Header:  rtp.Header{Version: 2, PayloadType: 111, SequenceNumber: 10, Timestamp: 960, SSRC: 1234},
+				Payload: []byte{1, 2, 3},
+			}).Marshal()
+			require.NoError(t, err)
+
+			// a sender of an unknown stream or of packets which do not authenticate is not locked onto
+			stray, err := net.DialUDP("udp", nil, pt.LocalAddr())
+			require.NoError(t, err)
+			defer stray.Close()
+			_, err = stray.Write(unknown)
+			require.NoError(t, err)
+			if keys != nil {
+				_, err = stray.Write(pkt)
+				require.NoError(t, err)
+			}
+			time.Sleep(20 * time.Millisecond)
+			require.False(t, connected.Load())
+
+			send(pkt, false)
+
+			sr, err := rtcp.Marshal([]rtcp.Packet{&rtcp.SenderReport{SSRC: 1234, RTPTime: 960}})
+			require.NoError(t, err)
+			send(sr, true)
+
+			ext, err := buff.ReadExtended(make([]byte, 1500))
+			require.NoError(t, err)
+			require.Equal(t, uint32(1234), ext.Packet.SSRC)
+			require.Equal(t, uint16(10), ext.Packet.SequenceNumber)
+			require.Equal(t, []byte{1, 2, 3}, ext.Packet.Payload)
+			require.True(t, connected.Load())
+			require.Eventually(t, func() bool { return senderReports.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+			// RTCP goes back to where the producer sends from
+			require.NoError(t, pt.WriteRTCP([]rtcp.Packet{&rtcp.PictureLossIndication{MediaSSRC: 1234}}))
+			received := make([]byte, 1500)
+			require.NoError(t, producer.SetReadDeadline(time.Now().Add(time.Second)))
+			n, err := producer.Read(received)
+			require.NoError(t, err)
+			received = received[:n]
+			if decrypt != nil {
+				received, err = decrypt.DecryptRTCP(nil, received, nil)
+				require.NoError(t, err)
+			}
+			pkts, err := rtcp.Unmarshal(received)
+			require.NoError(t, err)
+			require.Equal(t, []uint32{1234}, pkts[0].DestinationSSRC())
+		})
+	}
+
+	t.Run("SSRC in use", func(t *testing.T) {
+		factory := buffer.NewFactoryOfBufferFactory(500, 200).CreateBufferFactory()
+		var connected atomic.Bool
+		newTransport(t, factory, nil, &connected)
+
+		conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = NewPlainTransport(PlainTransportParams{
+			PlainTransportParams: types.PlainTransportParams{
+				Conn:   conn,
+				Tracks: []types.PlainTrackParams{{Request: &livekit.AddTrackRequest{}, SSRC: 1234, Codec: opus}},
+			},
+			BufferFactory: factory,
+			Logger:        logger.GetLogger(),
+		})
+		require.ErrorIs(t, err, ErrPlainTransportSSRCInUse)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		factory := buffer.NewFactoryOfBufferFactory(500, 200).CreateBufferFactory()
+		_, err := NewPlainTransport(PlainTransportParams{
+			PlainTransportParams: types.PlainTransportParams{
+				SRTP:   &types.PlainTransportSRTP{Profile: srtp.ProtectionProfileAes128CmHmacSha1_80, KeyMaterial: []byte{1}},
+				Tracks: []types.PlainTrackParams{{Request: &livekit.AddTrackRequest{}, SSRC: 1234, Codec: opus}},
+			},
+			BufferFactory: factory,
+			Logger:        logger.GetLogger(),
+		})
+		require.ErrorIs(t, err, ErrPlainTransportSRTPKey)
+		require.Nil(t, factory.GetBuffer(1234))
+	})
+}
+
+func TestPublishPlainTransport(t *testing.T) {
+	p := newParticipantForTestWithOpts("plain", &participantOpts{
+		permissions: &livekit.ParticipantPermission{CanPublish: true},
+	})
+	defer p.Close(false, types.ParticipantCloseReasonClientRequestLeave, false)
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	params := types.PlainTransportParams{
+		Conn: conn,
+		Tracks: []types.PlainTrackParams{
+			{
+				Request: &livekit.AddTrackRequest{Name: "mic", Source: livekit.TrackSource_MICROPHONE},
+				SSRC:    1111,
+				Codec: webrtc.RTPCodecParameters{
+					RTPCodecCapability: webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus, ClockRate: 48000, Channels: 2},
+					PayloadType:        111,
+				},
+			},
+			{
+				Request: &livekit.AddTrackRequest{Name: "cam", Source: livekit.TrackSource_CAMERA, Width: 1280, Height: 720},
+				SSRC:    2222,
+				Codec: webrtc.RTPCodecParameters{
+					RTPCodecCapability: webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeVP8, ClockRate: 90000},
+					PayloadType:        96,
+				},
+			},
+		},
+	}
+	info, err := p.PublishPlainTransport(params)
+	require.NoError(t, err)
+	require.Len(t, info.Tracks, 2)
+	require.Nil(t, info.SRTP)
+
+	audio := p.GetPublishedTrack(livekit.TrackID(info.Tracks[0].Sid))
+	require.NotNil(t, audio)
+	require.Equal(t, livekit.TrackType_AUDIO, audio.Kind())
+	require.Equal(t, "mic", audio.Name())
+	video := p.GetPublishedTrack(livekit.TrackID(info.Tracks[1].Sid))
+	require.NotNil(t, video)
+	require.Equal(t, livekit.TrackType_VIDEO, video.Kind())
+	require.Equal(t, livekit.TrackSource_CAMERA, video.Source())
+	require.True(t, mime.IsMimeTypeStringEqual(webrtc.MimeTypeVP8, video.ToProto().MimeType))
+	require.True(t, p.IsPublisher())
+
+	_, err = p.PublishPlainTransport(params)
+	require.ErrorIs(t, err, ErrPlainTransportExists)
+
+	producer, err := net.DialUDP("udp", nil, conn.LocalAddr().(*net.UDPAddr))
+	require.NoError(t, err)
+	defer producer.Close()
+	pkt, err := (&rtp.Packet{Header: rtp.Header{Version: 2, PayloadType: 111, SSRC: 1111}, Payload: []byte{1}}).Marshal()
+	require.NoError(t, err)
+	_, err = producer.Write(pkt)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return p.plainTransport.Load().IsConnected() }, time.Second, 10*time.Millisecond)
+}
@@ -131,7 +131,8 @@ type Room struct {
 	batchedUpdates   map[livekit.ParticipantIdentity]*participantUpdate
 	batchedUpdatesMu sync.Mutex
 
-	closed chan struct{}
+	closed      chan struct{}
+	closeReason types.ParticipantCloseReason
 
 	trailer []byte
 
@@ -232,6 +233,13 @@ func (j *agentJob) waitForParticipantLeaving() error {
 	return nil
 }
 
+// RoomMigrationParams is the state of a room taken over from the node it was hosted on
+type RoomMigrationParams struct {
+	// dispatches of the room on the previous node, agents already in the room reconnect by themselves
+	AgentDispatches []*livekit.AgentDispatch
+}
+
+// NewRoom creates a room, migration is set when the room moved from another node
 func NewRoom(
 	room *livekit.Room,
 	internal *livekit.RoomInternal,
@@ -243,6 +251,7 @@ func NewRoom(
 	agentClient agent.Client,
 	agentStore AgentStore,
 	egressLauncher EgressLauncher,
+	migration *RoomMigrationParams,
 ) *Room {
 	r := &Room{
 		protoRoom: utils.CloneProto(room),
@@ -288,9 +297,13 @@ func NewRoom(
 	}
 	r.protoProxy = utils.NewProtoProxy[*livekit.Room](roomUpdateInterval, r.updateProto)
 
-	r.createAgentDispatchesFromRoomAgent()
+	if migration != nil {
+		r.restoreAgentDispatches(migration.AgentDispatches)
+	} else {
+		r.createAgentDispatchesFromRoomAgent()
 
-	r.launchRoomAgents(maps.Values(r.agentDispatches))
+		r.launchRoomAgents(maps.Values(r.agentDispatches))
+	}
 
 	go r.audioUpdateWorker()
 	go r.connectionQualityWorker()
@@ -911,11 +924,17 @@ func (r *Room) Close(reason types.ParticipantCloseReason) {
 		// fall through
 	}
 	close(r.closed)
+	r.closeReason = reason
 	r.lock.Unlock()
 
-	r.Logger.Infow("closing room")
+	r.Logger.Infow("closing room", "reason", reason.String())
 	for _, p := range r.GetParticipants() {
-		_ = p.Close(true, reason, false)
+		if reason == types.ParticipantCloseReasonMigrationRequested {
+			// room continues on another node, clients join it there and restore their publications
+			p.IssueFullReconnect(reason)
+		} else {
+			_ = p.Close(true, reason, false)
+		}
 	}
 
 	r.protoProxy.Stop()
@@ -925,6 +944,14 @@ func (r *Room) Close(reason types.ParticipantCloseReason) {
 	}
 }
 
+// CloseReason returns the reason the room was closed with, valid once the room is closed
+func (r *Room) CloseReason() types.ParticipantCloseReason {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.closeReason
+}
+
 func (r *Room) OnClose(f func()) {
 	r.onClose = f
 }
@@ -1744,6 +1771,20 @@ func (r *Room) createAgentDispatchesFromRoomAgent() {
 	}
 }
 
+func (r *Room) restoreAgentDispatches(dispatches []*livekit.AgentDispatch) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, dispatch := range dispatches {
+		r.agentDispatches[dispatch.Id] = newAgentDispatch(dispatch)
+		for _, job := range dispatch.GetState().GetJobs() {
+			if job.State != nil && job.State.ParticipantIdentity != "" {
+				r.agentParticpants[livekit.ParticipantIdentity(job.State.ParticipantIdentity)] = newAgentJob(job)
+			}
+		}
+	}
+}
+
 func (r *Room) IsDataMessageUserPacketDuplicate(up *livekit.UserPacket) bool {
 	return r.userPacketDeduper.IsDuplicate(up)
 }
@@ -412,6 +412,20 @@ func TestRoomClosure(t *testing.T) {
 		rm.CloseIfEmpty()
 		require.True(t, isClosed)
 	})
+
+	t.Run("participants reconnect when room migrates", func(t *testing.T) {
+		rm := newRoomWithParticipants(t, testRoomOpts{num: 2})
+		rm.Close(types.ParticipantCloseReasonMigrationRequested)
+		require.True(t, rm.IsClosed())
+		require.Equal(t, types.ParticipantCloseReasonMigrationRequested, rm.CloseReason())
+
+		for _, p := range rm.GetParticipants() {
+			fp := p.(*typesfakes.FakeLocalParticipant)
+			require.Equal(t, 1, fp.IssueFullReconnectCallCount())
+			require.Equal(t, types.ParticipantCloseReasonMigrationRequested, fp.IssueFullReconnectArgsForCall(0))
+			require.Zero(t, fp.CloseCallCount())
+		}
+	})
 }
 
 func TestNewTrack(t *testing.T) {
@@ -817,7 +831,7 @@ func newRoomWithParticipants(t *testing.T, opts testRoomOpts) *Room {
 			Region:   "testregion",
 		},
 		telemetry.NewTelemetryService(webhook.NewDefaultNotifier("", "", nil), &telemetryfakes.FakeAnalyticsService{}),
-		nil, nil, nil,
+		nil, nil, nil, nil,
 	)
 	for i := 0; i < opts.num+opts.numHidden; i++ {
 		identity := livekit.ParticipantIdentity(fmt.Sprintf("p%d", i))
@@ -23,6 +23,7 @@ import (
 
 func HandleParticipantSignal(room types.Room, participant types.LocalParticipant, req *livekit.SignalRequest, pLogger logger.Logger) error {
 	participant.UpdateLastSeenSignal()
+	participant.RecordSignalRequest(req)
 
 	switch msg := req.GetMessage().(type) {
 	case *livekit.SignalRequest_Offer:
@@ -0,0 +1,227 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtc
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+)
+
+type SignalRecordType string
+
+const (
+	SignalRecordTypeSession   SignalRecordType = "session"
+	SignalRecordTypeRequest   SignalRecordType = "request"
+	SignalRecordTypeResponse  SignalRecordType = "response"
+	SignalRecordTypeTransport SignalRecordType = "transport"
+)
+
+// transport events recorded besides the signals handled by PCTransport
+const (
+	SignalRecordEventNegotiationState    = "NEGOTIATION_STATE"
+	SignalRecordEventICEConnectionState  = "ICE_CONNECTION_STATE"
+	SignalRecordEventPeerConnectionState = "PEER_CONNECTION_STATE"
+)
+
+// max size of a line when reading a recording, offers of sessions with many tracks can get large
+const maxSignalRecordSize = 4 * 1024 * 1024
+
+var ErrSignalRecordType = errors.New("unexpected signal record type")
+
+// SignalRecordSession is the first record of a recording, it holds what is needed to create the participant again
+type SignalRecordSession struct {
+	Room                     livekit.RoomName            `json:"room"`
+	Identity                 livekit.ParticipantIdentity `json:"identity"`
+	SID                      livekit.ParticipantID       `json:"sid"`
+	ProtocolVersion          types.ProtocolVersion       `json:"protocol_version"`
+	Client                   *livekit.ClientInfo         `json:"client,omitempty"`
+	Grants                   *auth.ClaimGrants           `json:"grants,omitempty"`
+	Reconnect                bool                        `json:"reconnect,omitempty"`
+	UseOneShotSignallingMode bool                        `json:"use_one_shot_signalling_mode,omitempty"`
+	UseSinglePeerConnection  bool                        `json:"use_single_peer_connection,omitempty"`
+}
+
+// SignalRecord is a line of a recording, messages are protojson encoded
+type SignalRecord struct {
+	Time    time.Time            `json:"time"`
+	Type    SignalRecordType     `json:"type"`
+	Session *SignalRecordSession `json:"session,omitempty"`
+	Message json.RawMessage      `json:"message,omitempty"`
+	Target  string               `json:"target,omitempty"`
+	Event   string               `json:"event,omitempty"`
+	Value   string               `json:"value,omitempty"`
+}
+
+func (r *SignalRecord) SignalRequest() (*livekit.SignalRequest, error) {
+	if r.Type != SignalRecordTypeRequest {
+		return nil, ErrSignalRecordType
+	}
+	req := &livekit.SignalRequest{}
+	if err := protojson.Unmarshal(r.Message, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (r *SignalRecord) SignalResponse() (*livekit.SignalResponse, error) {
+	if r.Type != SignalRecordTypeResponse {
+		return nil, ErrSignalRecordType
+	}
+	res := &livekit.SignalResponse{}
+	if err := protojson.Unmarshal(r.Message, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SignalRecorder writes the signal requests and responses of a participant session together with the events of
+// its transports, one JSON record per line, to reproduce negotiation issues offline (see test/signalreplay.go).
+// Recordings contain the SDP and ICE candidates of the client. Methods can be called on a nil recorder.
+type SignalRecorder struct {
+	logger logger.Logger
+
+	lock   sync.Mutex
+	w      io.WriteCloser
+	enc    *json.Encoder
+	closed bool
+}
+
+func NewSignalRecorder(w io.WriteCloser, session SignalRecordSession, logger logger.Logger) *SignalRecorder {
+	r := &SignalRecorder{
+		logger: logger,
+		w:      w,
+		enc:    json.NewEncoder(w),
+	}
+	r.write(&SignalRecord{
+		Type:    SignalRecordTypeSession,
+		Session: &session,
+	})
+	return r
+}
+
+// CreateSignalRecording starts a recording in a new file of dir
+func CreateSignalRecording(dir string, session SignalRecordSession, logger logger.Logger) (*SignalRecorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("%s_%s_%s.jsonl", url.PathEscape(string(session.Room)), url.PathEscape(string(session.Identity)), session.SID)
+	f, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infow("recording signalling", "path", f.Name())
+	return NewSignalRecorder(f, session, logger), nil
+}
+
+func (r *SignalRecorder) RecordRequest(req *livekit.SignalRequest) {
+	r.recordMessage(SignalRecordTypeRequest, req)
+}
+
+func (r *SignalRecorder) RecordResponse(res *livekit.SignalResponse) {
+	r.recordMessage(SignalRecordTypeResponse, res)
+}
+
+func (r *SignalRecorder) RecordTransportEvent(target livekit.SignalTarget, event string, value string) {
+	if r == nil {
+		return
+	}
+	r.write(&SignalRecord{
+		Type:   SignalRecordTypeTransport,
+		Target: target.String(),
+		Event:  event,
+		Value:  value,
+	})
+}
+
+func (r *SignalRecorder) Close() {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return
+	}
+	r.closed = true
+	if err := r.w.Close(); err != nil {
+		r.logger.Warnw("could not close signal recording", err)
+	}
+}
+
+func (r *SignalRecorder) recordMessage(recordType SignalRecordType, msg proto.Message) {
+	if r == nil {
+		return
+	}
+	payload, err := protojson.Marshal(msg)
+	if err != nil {
+		r.logger.Warnw("could not marshal signal message for recording", err, "recordType", recordType)
+		return
+	}
+	r.write(&SignalRecord{
+		Type:    recordType,
+		Message: payload,
+	})
+}
+
+func (r *SignalRecorder) write(record *SignalRecord) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return
+	}
+
+	record.Time = time.Now()
+	if err := r.enc.Encode(record); err != nil {
+		// give up on the recording rather than leaving gaps in it
+		r.logger.Warnw("could not write signal recording", err)
+		r.closed = true
+		_ = r.w.Close()
+	}
+}
+
+// ReadSignalRecording reads the records written by a SignalRecorder
+func ReadSignalRecording(rd io.Reader) ([]*SignalRecord, error) {
+	var records []*SignalRecord
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(nil, maxSignalRecordSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := &SignalRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
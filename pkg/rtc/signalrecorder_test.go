@@ -0,0 +1,83 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rtc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+)
+
+func TestSignalRecorder(t *testing.T) {
+	dir := t.TempDir()
+	r, err := CreateSignalRecording(dir, SignalRecordSession{
+		Room:            "a/b",
+		Identity:        "p1",
+		SID:             "PA_1",
+		ProtocolVersion: 15,
+		Client:          &livekit.ClientInfo{Sdk: livekit.ClientInfo_JS},
+	}, logger.GetLogger())
+	require.NoError(t, err)
+
+	req := &livekit.SignalRequest{
+		Message: &livekit.SignalRequest_Offer{Offer: &livekit.SessionDescription{Type: "offer", Sdp: "v=0"}},
+	}
+	res := &livekit.SignalResponse{
+		Message: &livekit.SignalResponse_Answer{Answer: &livekit.SessionDescription{Type: "answer", Sdp: "v=0"}},
+	}
+	r.RecordRequest(req)
+	r.RecordTransportEvent(livekit.SignalTarget_PUBLISHER, SignalRecordEventNegotiationState, "NONE")
+	r.RecordResponse(res)
+	r.Close()
+	// dropped after close
+	r.RecordRequest(req)
+
+	f, err := os.Open(filepath.Join(dir, "a%2Fb_p1_PA_1.jsonl"))
+	require.NoError(t, err)
+	defer f.Close()
+	records, err := ReadSignalRecording(f)
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	require.Equal(t, SignalRecordTypeSession, records[0].Type)
+	require.Equal(t, livekit.ParticipantID("PA_1"), records[0].Session.SID)
+	require.Equal(t, livekit.ClientInfo_JS, records[0].Session.Client.Sdk)
+
+	recordedReq, err := records[1].SignalRequest()
+	require.NoError(t, err)
+	require.True(t, proto.Equal(req, recordedReq))
+	_, err = records[1].SignalResponse()
+	require.ErrorIs(t, err, ErrSignalRecordType)
+
+	require.Equal(t, "PUBLISHER", records[2].Target)
+	require.Equal(t, SignalRecordEventNegotiationState, records[2].Event)
+	require.Equal(t, "NONE", records[2].Value)
+
+	recordedRes, err := records[3].SignalResponse()
+	require.NoError(t, err)
+	require.True(t, proto.Equal(res, recordedRes))
+
+	// not recording
+	var nr *SignalRecorder
+	nr.RecordRequest(req)
+	nr.RecordTransportEvent(livekit.SignalTarget_SUBSCRIBER, SignalRecordEventICEConnectionState, "checking")
+	nr.Close()
+}
@@ -263,9 +263,11 @@ type TransportParams struct {
 	IsSendSide                   bool
 	AllowPlayoutDelay            bool
 	UseOneShotSignallingMode     bool
+	UseSinglePeerConnection      bool
 	FireOnTrackBySdp             bool
 	DataChannelMaxBufferedAmount uint64
 	DatachannelSlowThreshold     int
+	SignalRecorder               *SignalRecorder
 
 	// for development test
 	DatachannelMaxReceiverBufferSize int
@@ -402,7 +404,8 @@ func newPeerConnection(params TransportParams, onBandwidthEstimator func(estimat
 				}
 			}
 		}
-	} else {
+	}
+	if !params.IsSendSide || params.UseOneShotSignallingMode || params.UseSinglePeerConnection {
 		// sfu only use interceptor to send XR but don't read response from it (use buffer instead),
 		// so use a empty callback here
 		ir.Add(lkinterceptor.NewRTTFromXRFactory(func(rtt uint32) {}))
@@ -709,6 +712,7 @@ func (t *PCTransport) handleConnectionFailed(forceShortConn bool) {
 
 func (t *PCTransport) onICEConnectionStateChange(state webrtc.ICEConnectionState) {
 	t.params.Logger.Debugw("ice connection state change", "state", state.String())
+	t.params.SignalRecorder.RecordTransportEvent(t.params.Transport, SignalRecordEventICEConnectionState, state.String())
 	switch state {
 	case webrtc.ICEConnectionStateConnected:
 		t.setICEConnectedAt(time.Now())
@@ -720,6 +724,7 @@ func (t *PCTransport) onICEConnectionStateChange(state webrtc.ICEConnectionState
 
 func (t *PCTransport) onPeerConnectionStateChange(state webrtc.PeerConnectionState) {
 	t.params.Logger.Debugw("peer connection state change", "state", state.String())
+	t.params.SignalRecorder.RecordTransportEvent(t.params.Transport, SignalRecordEventPeerConnectionState, state.String())
 	switch state {
 	case webrtc.PeerConnectionStateConnected:
 		t.clearConnTimer()
@@ -1295,6 +1300,14 @@ func (t *PCTransport) SetChannelCapacityOfStreamAllocator(channelCapacity int64)
 	t.streamAllocator.SetChannelCapacity(channelCapacity)
 }
 
+func (t *PCTransport) StreamAllocatorDebugInfo() map[string]interface{} {
+	if t.streamAllocator == nil {
+		return nil
+	}
+
+	return t.streamAllocator.DebugInfo()
+}
+
 func (t *PCTransport) preparePC(previousAnswer webrtc.SessionDescription) error {
 	// sticky data channel to first m-lines, if someday we don't send sdp without media streams to
 	// client's subscribe pc after joining, should change this step
@@ -1478,6 +1491,8 @@ func (t *PCTransport) parseTrackMid(offer webrtc.SessionDescription, senders map
 func (t *PCTransport) postEvent(e event) {
 	e.PCTransport = t
 	t.eventsQueue.Enqueue(func(e event) {
+		e.params.SignalRecorder.RecordTransportEvent(e.params.Transport, e.signal.String(), "")
+
 		var err error
 		switch e.signal {
 		case signalICEGatheringComplete:
@@ -1502,6 +1517,13 @@ func (t *PCTransport) postEvent(e event) {
 }
 
 func (t *PCTransport) handleICEGatheringComplete(_ event) error {
+	if t.params.UseSinglePeerConnection {
+		if err := t.handleICEGatheringCompleteOfferer(); err != nil {
+			return err
+		}
+		return t.handleICEGatheringCompleteAnswerer()
+	}
+
 	if t.params.IsOfferer {
 		return t.handleICEGatheringCompleteOfferer()
 	} else {
@@ -1625,6 +1647,7 @@ func (t *PCTransport) handleRemoteICECandidate(e event) error {
 
 func (t *PCTransport) setNegotiationState(state transport.NegotiationState) {
 	t.negotiationState = state
+	t.params.SignalRecorder.RecordTransportEvent(t.params.Transport, SignalRecordEventNegotiationState, state.String())
 	if onNegotiationStateChanged := t.getOnNegotiationStateChanged(); onNegotiationStateChanged != nil {
 		onNegotiationStateChanged(t.negotiationState)
 	}
@@ -1722,6 +1745,13 @@ func (t *PCTransport) createAndSendOffer(options *webrtc.OfferOptions) error {
 		return nil
 	}
 
+	// on a single peer connection, remote side makes the first offer, offer after answering it
+	if t.params.UseSinglePeerConnection && t.pc.RemoteDescription() == nil {
+		t.params.Logger.Debugw("deferring offer to after first remote offer")
+		t.setNegotiationState(transport.NegotiationStateRetry)
+		return nil
+	}
+
 	ensureICERestart := func(options *webrtc.OfferOptions) *webrtc.OfferOptions {
 		if options == nil {
 			options = &webrtc.OfferOptions{}
@@ -1918,6 +1948,13 @@ func (t *PCTransport) handleRemoteOfferReceived(sd *webrtc.SessionDescription) e
 		return nil
 	}
 
+	if t.params.UseSinglePeerConnection && t.pc.SignalingState() == webrtc.SignalingStateHaveLocalOffer {
+		// offers crossed, local offer wins as rollback is not supported,
+		// remote side is expected to roll back its offer and offer again after answering
+		t.params.Logger.Debugw("ignoring remote offer while local offer is pending")
+		return nil
+	}
+
 	t.lock.Lock()
 	if !t.firstOfferReceived {
 		t.firstOfferReceived = true
@@ -1966,7 +2003,17 @@ func (t *PCTransport) handleRemoteOfferReceived(sd *webrtc.SessionDescription) e
 		t.currentOfferIceCredential = iceCredential
 	}
 
-	return t.createAndSendAnswer()
+	if err := t.createAndSendAnswer(); err != nil {
+		return err
+	}
+
+	if t.params.UseSinglePeerConnection && t.negotiationState == transport.NegotiationStateRetry {
+		t.setNegotiationState(transport.NegotiationStateNone)
+
+		t.params.Logger.Debugw("re-negotiate after sending answer")
+		return t.createAndSendOffer(nil)
+	}
+	return nil
 }
 
 func (t *PCTransport) handleRemoteAnswerReceived(sd *webrtc.SessionDescription) error {
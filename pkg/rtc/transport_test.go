@@ -384,6 +384,94 @@ func TestNegotiationFailed(t *testing.T) {
 	transportA.Close()
 }
 
+func TestSinglePeerConnectionOfferCollision(t *testing.T) {
+	handler := &transportfakes.FakeHandler{}
+	offers := make(chan webrtc.SessionDescription, 10)
+	handler.OnOfferCalls(func(sd webrtc.SessionDescription) error {
+		offers <- sd
+		return nil
+	})
+	answers := make(chan webrtc.SessionDescription, 10)
+	handler.OnAnswerCalls(func(sd webrtc.SessionDescription) error {
+		answers <- sd
+		return nil
+	})
+	server, err := NewPCTransport(TransportParams{
+		ParticipantID:           "id",
+		ParticipantIdentity:     "identity",
+		Config:                  &WebRTCConfig{},
+		Handler:                 handler,
+		UseSinglePeerConnection: true,
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	var negotiationState atomic.Value
+	server.OnNegotiationStateChanged(func(state transport.NegotiationState) {
+		negotiationState.Store(state)
+	})
+
+	client, err := webrtc.NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.CreateDataChannel(ReliableDataChannel, nil)
+	require.NoError(t, err)
+
+	clientOffer := func() {
+		offer, err := client.CreateOffer(nil)
+		require.NoError(t, err)
+		require.NoError(t, client.SetLocalDescription(offer))
+		require.NoError(t, server.HandleRemoteDescription(offer))
+	}
+	receive := func(ch chan webrtc.SessionDescription) webrtc.SessionDescription {
+		select {
+		case sd := <-ch:
+			return sd
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "no session description")
+			return webrtc.SessionDescription{}
+		}
+	}
+
+	// server does not offer before the client has
+	server.Negotiate(true)
+	require.Eventually(t, func() bool {
+		state, ok := negotiationState.Load().(transport.NegotiationState)
+		return ok && state == transport.NegotiationStateRetry
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Empty(t, offers)
+
+	// deferred offer is sent after answering the first client offer
+	clientOffer()
+	require.NoError(t, client.SetRemoteDescription(receive(answers)))
+	serverOffer := receive(offers)
+	require.Equal(t, webrtc.SignalingStateHaveLocalOffer, server.pc.SignalingState())
+
+	// offers cross, server ignores the client offer
+	_, err = client.AddTransceiverFromKind(webrtc.RTPCodecTypeAudio, webrtc.RTPTransceiverInit{Direction: webrtc.RTPTransceiverDirectionSendonly})
+	require.NoError(t, err)
+	crossingOffer, err := client.CreateOffer(nil)
+	require.NoError(t, err)
+	require.NoError(t, server.HandleRemoteDescription(crossingOffer))
+
+	// client answers the server offer, as if it had rolled back its own
+	require.NoError(t, client.SetRemoteDescription(serverOffer))
+	answer, err := client.CreateAnswer(nil)
+	require.NoError(t, err)
+	require.NoError(t, client.SetLocalDescription(answer))
+	require.NoError(t, server.HandleRemoteDescription(answer))
+
+	// and offers again
+	clientOffer()
+	require.NoError(t, client.SetRemoteDescription(receive(answers)))
+	require.Empty(t, answers)
+
+	require.Eventually(t, func() bool {
+		state, ok := negotiationState.Load().(transport.NegotiationState)
+		return ok && state == transport.NegotiationStateNone && server.pc.SignalingState() == webrtc.SignalingStateStable
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 func TestFilteringCandidates(t *testing.T) {
 	params := TransportParams{
 		ParticipantID:       "id",
@@ -107,7 +107,9 @@ type TransportManagerParams struct {
 	SubscriberHandler            transport.Handler
 	DataChannelStats             *telemetry.BytesTrackStats
 	UseOneShotSignallingMode     bool
+	UseSinglePeerConnection      bool
 	FireOnTrackBySdp             bool
+	SignalRecorder               *SignalRecorder
 }
 
 type TransportManager struct {
@@ -150,6 +152,7 @@ func NewTransportManager(params TransportManagerParams) (*TransportManager, erro
 	t.mediaLossProxy.OnMediaLossUpdate(t.onMediaLossUpdate)
 
 	lgr := LoggerWithPCTarget(params.Logger, livekit.SignalTarget_PUBLISHER)
+	// in one-shot signalling and single peer connection modes, subscribed tracks are also sent on the publisher peer connection
 	publisher, err := NewPCTransport(TransportParams{
 		ParticipantID:                params.SID,
 		ParticipantIdentity:          params.Identity,
@@ -164,16 +167,26 @@ func NewTransportManager(params TransportManagerParams) (*TransportManager, erro
 		ClientInfo:                   params.ClientInfo,
 		Transport:                    livekit.SignalTarget_PUBLISHER,
 		Handler:                      TransportManagerPublisherTransportHandler{TransportManagerTransportHandler{params.PublisherHandler, t, lgr}},
+		IsSendSide:                   params.UseOneShotSignallingMode || params.UseSinglePeerConnection,
 		UseOneShotSignallingMode:     params.UseOneShotSignallingMode,
+		UseSinglePeerConnection:      params.UseSinglePeerConnection,
 		DataChannelMaxBufferedAmount: params.DataChannelMaxBufferedAmount,
 		DatachannelSlowThreshold:     params.DatachannelSlowThreshold,
 		FireOnTrackBySdp:             params.FireOnTrackBySdp,
+		SignalRecorder:               params.SignalRecorder,
 	})
 	if err != nil {
 		return nil, err
 	}
 	t.publisher = publisher
 
+	if params.UseSinglePeerConnection {
+		// server offers downstream changes on the publisher peer connection, data channels are created by the client
+		t.subscriber = publisher
+		t.signalSourceValid.Store(true)
+		return t, nil
+	}
+
 	lgr = LoggerWithPCTarget(params.Logger, livekit.SignalTarget_SUBSCRIBER)
 	subscriber, err := NewPCTransport(TransportParams{
 		ParticipantID:            params.SID,
@@ -191,6 +204,7 @@ func NewTransportManager(params TransportManagerParams) (*TransportManager, erro
 		DatachannelSlowThreshold: params.DatachannelSlowThreshold,
 		Transport:                livekit.SignalTarget_SUBSCRIBER,
 		Handler:                  TransportManagerTransportHandler{params.SubscriberHandler, t, lgr},
+		SignalRecorder:           params.SignalRecorder,
 	})
 	if err != nil {
 		return nil, err
@@ -286,15 +300,27 @@ func (t *TransportManager) WriteSubscriberRTCP(pkts []rtcp.Packet) error {
 }
 
 func (t *TransportManager) GetSubscriberPacer() pacer.Pacer {
-	return t.subscriber.GetPacer()
+	if t.params.UseOneShotSignallingMode {
+		return t.publisher.GetPacer()
+	} else {
+		return t.subscriber.GetPacer()
+	}
 }
 
 func (t *TransportManager) AddSubscribedTrack(subTrack types.SubscribedTrack) {
-	t.subscriber.AddTrackToStreamAllocator(subTrack)
+	if t.params.UseOneShotSignallingMode {
+		t.publisher.AddTrackToStreamAllocator(subTrack)
+	} else {
+		t.subscriber.AddTrackToStreamAllocator(subTrack)
+	}
 }
 
 func (t *TransportManager) RemoveSubscribedTrack(subTrack types.SubscribedTrack) {
-	t.subscriber.RemoveTrackFromStreamAllocator(subTrack)
+	if t.params.UseOneShotSignallingMode {
+		t.publisher.RemoveTrackFromStreamAllocator(subTrack)
+	} else {
+		t.subscriber.RemoveTrackFromStreamAllocator(subTrack)
+	}
 }
 
 func (t *TransportManager) SendDataPacket(kind livekit.DataPacket_Kind, encoded []byte) error {
@@ -568,8 +594,13 @@ func (t *TransportManager) SubscriberAsPrimary() bool {
 }
 
 func (t *TransportManager) GetICEConnectionInfo() []*types.ICEConnectionInfo {
+	pcs := []*PCTransport{t.publisher, t.subscriber}
+	if t.params.UseSinglePeerConnection {
+		pcs = pcs[:1]
+	}
+
 	infos := make([]*types.ICEConnectionInfo, 0, 2)
-	for _, pc := range []*PCTransport{t.publisher, t.subscriber} {
+	for _, pc := range pcs {
 		info := pc.GetICEConnectionInfo()
 		if info.HasCandidates() {
 			infos = append(infos, info)
@@ -813,11 +844,27 @@ func (t *TransportManager) SetSignalSourceValid(valid bool) {
 }
 
 func (t *TransportManager) SetSubscriberAllowPause(allowPause bool) {
-	t.subscriber.SetAllowPauseOfStreamAllocator(allowPause)
+	if t.params.UseOneShotSignallingMode {
+		t.publisher.SetAllowPauseOfStreamAllocator(allowPause)
+	} else {
+		t.subscriber.SetAllowPauseOfStreamAllocator(allowPause)
+	}
 }
 
 func (t *TransportManager) SetSubscriberChannelCapacity(channelCapacity int64) {
-	t.subscriber.SetChannelCapacityOfStreamAllocator(channelCapacity)
+	if t.params.UseOneShotSignallingMode {
+		t.publisher.SetChannelCapacityOfStreamAllocator(channelCapacity)
+	} else {
+		t.subscriber.SetChannelCapacityOfStreamAllocator(channelCapacity)
+	}
+}
+
+func (t *TransportManager) SubscriberStreamAllocatorDebugInfo() map[string]interface{} {
+	if t.params.UseOneShotSignallingMode {
+		return t.publisher.StreamAllocatorDebugInfo()
+	} else {
+		return t.subscriber.StreamAllocatorDebugInfo()
+	}
 }
 
 func (t *TransportManager) hasRecentSignalLocked() bool {
@@ -344,6 +344,8 @@ type LocalParticipant interface {
 	SetResponseSink(sink routing.MessageSink)
 	CloseSignalConnection(reason SignallingCloseReason)
 	UpdateLastSeenSignal()
+	// records the request when the signalling of the participant is recorded
+	RecordSignalRequest(req *livekit.SignalRequest)
 	SetSignalSourceValid(valid bool)
 	HandleSignalSourceClose()
 
@@ -379,6 +381,11 @@ type LocalParticipant interface {
 
 	WriteSubscriberRTCP(pkts []rtcp.Packet) error
 
+	// publishes RTP from a server-side producer, received on a plain transport instead of the publisher peer connection
+	PublishPlainTransport(params PlainTransportParams) (*PlainTransportInfo, error)
+	// publishes media files read by the server, paced as a real-time producer
+	PublishMediaFiles(params MediaFileParams) ([]*livekit.TrackInfo, error)
+
 	// subscriptions
 	SubscribeToTrack(trackID livekit.TrackID)
 	UnsubscribeFromTrack(trackID livekit.TrackID)
@@ -520,6 +527,9 @@ type MediaTrack interface {
 	RemoveSubscriber(participantID livekit.ParticipantID, isExpectedToResume bool)
 	IsSubscriber(subID livekit.ParticipantID) bool
 	RevokeDisallowedSubscribers(allowedSubscriberIdentities []livekit.ParticipantIdentity) []livekit.ParticipantIdentity
+	// subscribers which are not participants of the room, e. g. RTSP viewers, are revoked through callbacks
+	AddOnRevoke(key string, subscriberIdentity livekit.ParticipantIdentity, f func())
+	RemoveOnRevoke(key string)
 	GetAllSubscribers() []livekit.ParticipantID
 	GetNumSubscribers() int
 	OnTrackSubscribed()
@@ -0,0 +1,34 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/livekit/protocol/livekit"
+)
+
+// MediaFileTrackParams declares a track published from a media file read by the server
+type MediaFileTrackParams struct {
+	Request *livekit.AddTrackRequest
+	// Ogg/Opus, IVF (VP8, VP9, AV1) or Annex-B H264 file
+	Path string
+	// frame rate of Annex-B H264, which has no timing
+	FrameRate float64
+	// plays the file again from the start when it ends
+	Loop bool
+}
+
+type MediaFileParams struct {
+	Tracks []MediaFileTrackParams
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"net"
+
+	"github.com/pion/srtp/v3"
+	"github.com/pion/webrtc/v4"
+
+	"github.com/livekit/protocol/livekit"
+)
+
+// PlainTransportSRTP holds the keys of one direction of a plain transport using SRTP
+type PlainTransportSRTP struct {
+	Profile srtp.ProtectionProfile
+	// master key followed by master salt
+	KeyMaterial []byte
+}
+
+// PlainTrackParams declares an RTP stream published over a plain transport
+type PlainTrackParams struct {
+	Request *livekit.AddTrackRequest
+	SSRC    uint32
+	Codec   webrtc.RTPCodecParameters
+}
+
+// PlainTransportParams describes a plain transport, i. e. RTP and RTCP multiplexed on a UDP socket, without ICE and DTLS
+type PlainTransportParams struct {
+	Conn *net.UDPConn
+	// where RTCP is sent, learned from the first packet which authenticates or is of a declared stream when not set
+	RemoteAddr *net.UDPAddr
+	// keys of the remote endpoint, SRTP is not used when not set
+	SRTP   *PlainTransportSRTP
+	Tracks []PlainTrackParams
+}
+
+type PlainTransportInfo struct {
+	Tracks []*livekit.TrackInfo
+	// keys of the server, when SRTP is used
+	SRTP *PlainTransportSRTP
+}
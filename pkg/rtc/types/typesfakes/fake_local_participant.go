@@ -756,6 +756,37 @@ type FakeLocalParticipant struct {
 	protocolVersionReturnsOnCall map[int]struct {
 		result1 types.ProtocolVersion
 	}
+	PublishMediaFilesStub        func(types.MediaFileParams) ([]*livekit.TrackInfo, error)
+	publishMediaFilesMutex       sync.RWMutex
+	publishMediaFilesArgsForCall []struct {
+		arg1 types.MediaFileParams
+	}
+	publishMediaFilesReturns struct {
+		result1 []*livekit.TrackInfo
+		result2 error
+	}
+	publishMediaFilesReturnsOnCall map[int]struct {
+		result1 []*livekit.TrackInfo
+		result2 error
+	}
+	PublishPlainTransportStub        func(types.PlainTransportParams) (*types.PlainTransportInfo, error)
+	publishPlainTransportMutex       sync.RWMutex
+	publishPlainTransportArgsForCall []struct {
+		arg1 types.PlainTransportParams
+	}
+	publishPlainTransportReturns struct {
+		result1 *types.PlainTransportInfo
+		result2 error
+	}
+	publishPlainTransportReturnsOnCall map[int]struct {
+		result1 *types.PlainTransportInfo
+		result2 error
+	}
+	RecordSignalRequestStub        func(*livekit.SignalRequest)
+	recordSignalRequestMutex       sync.RWMutex
+	recordSignalRequestArgsForCall []struct {
+		arg1 *livekit.SignalRequest
+	}
 	RemovePublishedTrackStub        func(types.MediaTrack, bool, bool)
 	removePublishedTrackMutex       sync.RWMutex
 	removePublishedTrackArgsForCall []struct {
@@ -5140,6 +5171,166 @@ func (fake *FakeLocalParticipant) ProtocolVersionReturnsOnCall(i int, result1 ty
 	}{result1}
 }
 
+func (fake *FakeLocalParticipant) PublishMediaFiles(arg1 types.MediaFileParams) ([]*livekit.TrackInfo, error) {
+	fake.publishMediaFilesMutex.Lock()
+	ret, specificReturn := fake.publishMediaFilesReturnsOnCall[len(fake.publishMediaFilesArgsForCall)]
+	fake.publishMediaFilesArgsForCall = append(fake.publishMediaFilesArgsForCall, struct {
+		arg1 types.MediaFileParams
+	}{arg1})
+	stub := fake.PublishMediaFilesStub
+	fakeReturns := fake.publishMediaFilesReturns
+	fake.recordInvocation("PublishMediaFiles", []interface{}{arg1})
+	fake.publishMediaFilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLocalParticipant) PublishMediaFilesCallCount() int {
+	fake.publishMediaFilesMutex.RLock()
+	defer fake.publishMediaFilesMutex.RUnlock()
+	return len(fake.publishMediaFilesArgsForCall)
+}
+
+func (fake *FakeLocalParticipant) PublishMediaFilesCalls(stub func(types.MediaFileParams) ([]*livekit.TrackInfo, error)) {
+	fake.publishMediaFilesMutex.Lock()
+	defer fake.publishMediaFilesMutex.Unlock()
+	fake.PublishMediaFilesStub = stub
+}
+
+func (fake *FakeLocalParticipant) PublishMediaFilesArgsForCall(i int) types.MediaFileParams {
+	fake.publishMediaFilesMutex.RLock()
+	defer fake.publishMediaFilesMutex.RUnlock()
+	argsForCall := fake.publishMediaFilesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLocalParticipant) PublishMediaFilesReturns(result1 []*livekit.TrackInfo, result2 error) {
+	fake.publishMediaFilesMutex.Lock()
+	defer fake.publishMediaFilesMutex.Unlock()
+	fake.PublishMediaFilesStub = nil
+	fake.publishMediaFilesReturns = struct {
+		result1 []*livekit.TrackInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocalParticipant) PublishMediaFilesReturnsOnCall(i int, result1 []*livekit.TrackInfo, result2 error) {
+	fake.publishMediaFilesMutex.Lock()
+	defer fake.publishMediaFilesMutex.Unlock()
+	fake.PublishMediaFilesStub = nil
+	if fake.publishMediaFilesReturnsOnCall == nil {
+		fake.publishMediaFilesReturnsOnCall = make(map[int]struct {
+			result1 []*livekit.TrackInfo
+			result2 error
+		})
+	}
+	fake.publishMediaFilesReturnsOnCall[i] = struct {
+		result1 []*livekit.TrackInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocalParticipant) PublishPlainTransport(arg1 types.PlainTransportParams) (*types.PlainTransportInfo, error) {
+	fake.publishPlainTransportMutex.Lock()
+	ret, specificReturn := fake.publishPlainTransportReturnsOnCall[len(fake.publishPlainTransportArgsForCall)]
+	fake.publishPlainTransportArgsForCall = append(fake.publishPlainTransportArgsForCall, struct {
+		arg1 types.PlainTransportParams
+	}{arg1})
+	stub := fake.PublishPlainTransportStub
+	fakeReturns := fake.publishPlainTransportReturns
+	fake.recordInvocation("PublishPlainTransport", []interface{}{arg1})
+	fake.publishPlainTransportMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLocalParticipant) PublishPlainTransportCallCount() int {
+	fake.publishPlainTransportMutex.RLock()
+	defer fake.publishPlainTransportMutex.RUnlock()
+	return len(fake.publishPlainTransportArgsForCall)
+}
+
+func (fake *FakeLocalParticipant) PublishPlainTransportCalls(stub func(types.PlainTransportParams) (*types.PlainTransportInfo, error)) {
+	fake.publishPlainTransportMutex.Lock()
+	defer fake.publishPlainTransportMutex.Unlock()
+	fake.PublishPlainTransportStub = stub
+}
+
+func (fake *FakeLocalParticipant) PublishPlainTransportArgsForCall(i int) types.PlainTransportParams {
+	fake.publishPlainTransportMutex.RLock()
+	defer fake.publishPlainTransportMutex.RUnlock()
+	argsForCall := fake.publishPlainTransportArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLocalParticipant) PublishPlainTransportReturns(result1 *types.PlainTransportInfo, result2 error) {
+	fake.publishPlainTransportMutex.Lock()
+	defer fake.publishPlainTransportMutex.Unlock()
+	fake.PublishPlainTransportStub = nil
+	fake.publishPlainTransportReturns = struct {
+		result1 *types.PlainTransportInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocalParticipant) PublishPlainTransportReturnsOnCall(i int, result1 *types.PlainTransportInfo, result2 error) {
+	fake.publishPlainTransportMutex.Lock()
+	defer fake.publishPlainTransportMutex.Unlock()
+	fake.PublishPlainTransportStub = nil
+	if fake.publishPlainTransportReturnsOnCall == nil {
+		fake.publishPlainTransportReturnsOnCall = make(map[int]struct {
+			result1 *types.PlainTransportInfo
+			result2 error
+		})
+	}
+	fake.publishPlainTransportReturnsOnCall[i] = struct {
+		result1 *types.PlainTransportInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLocalParticipant) RecordSignalRequest(arg1 *livekit.SignalRequest) {
+	fake.recordSignalRequestMutex.Lock()
+	fake.recordSignalRequestArgsForCall = append(fake.recordSignalRequestArgsForCall, struct {
+		arg1 *livekit.SignalRequest
+	}{arg1})
+	stub := fake.RecordSignalRequestStub
+	fake.recordInvocation("RecordSignalRequest", []interface{}{arg1})
+	fake.recordSignalRequestMutex.Unlock()
+	if stub != nil {
+		fake.RecordSignalRequestStub(arg1)
+	}
+}
+
+func (fake *FakeLocalParticipant) RecordSignalRequestCallCount() int {
+	fake.recordSignalRequestMutex.RLock()
+	defer fake.recordSignalRequestMutex.RUnlock()
+	return len(fake.recordSignalRequestArgsForCall)
+}
+
+func (fake *FakeLocalParticipant) RecordSignalRequestCalls(stub func(*livekit.SignalRequest)) {
+	fake.recordSignalRequestMutex.Lock()
+	defer fake.recordSignalRequestMutex.Unlock()
+	fake.RecordSignalRequestStub = stub
+}
+
+func (fake *FakeLocalParticipant) RecordSignalRequestArgsForCall(i int) *livekit.SignalRequest {
+	fake.recordSignalRequestMutex.RLock()
+	defer fake.recordSignalRequestMutex.RUnlock()
+	argsForCall := fake.recordSignalRequestArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeLocalParticipant) RemovePublishedTrack(arg1 types.MediaTrack, arg2 bool, arg3 bool) {
 	fake.removePublishedTrackMutex.Lock()
 	fake.removePublishedTrackArgsForCall = append(fake.removePublishedTrackArgsForCall, struct {
@@ -7678,6 +7869,12 @@ func (fake *FakeLocalParticipant) Invocations() map[string][][]interface{} {
 	defer fake.onTrackUpdatedMutex.RUnlock()
 	fake.protocolVersionMutex.RLock()
 	defer fake.protocolVersionMutex.RUnlock()
+	fake.publishMediaFilesMutex.RLock()
+	defer fake.publishMediaFilesMutex.RUnlock()
+	fake.publishPlainTransportMutex.RLock()
+	defer fake.publishPlainTransportMutex.RUnlock()
+	fake.recordSignalRequestMutex.RLock()
+	defer fake.recordSignalRequestMutex.RUnlock()
 	fake.removePublishedTrackMutex.RLock()
 	defer fake.removePublishedTrackMutex.RUnlock()
 	fake.removeTrackLocalMutex.RLock()
@@ -16,6 +16,13 @@ type FakeMediaTrack struct {
 	addOnCloseArgsForCall []struct {
 		arg1 func(isExpectedToResume bool)
 	}
+	AddOnRevokeStub        func(string, livekit.ParticipantIdentity, func())
+	addOnRevokeMutex       sync.RWMutex
+	addOnRevokeArgsForCall []struct {
+		arg1 string
+		arg2 livekit.ParticipantIdentity
+		arg3 func()
+	}
 	AddSubscriberStub        func(types.LocalParticipant) (types.SubscribedTrack, error)
 	addSubscriberMutex       sync.RWMutex
 	addSubscriberArgsForCall []struct {
@@ -221,6 +228,11 @@ type FakeMediaTrack struct {
 	receiversReturnsOnCall map[int]struct {
 		result1 []sfu.TrackReceiver
 	}
+	RemoveOnRevokeStub        func(string)
+	removeOnRevokeMutex       sync.RWMutex
+	removeOnRevokeArgsForCall []struct {
+		arg1 string
+	}
 	RemoveSubscriberStub        func(livekit.ParticipantID, bool)
 	removeSubscriberMutex       sync.RWMutex
 	removeSubscriberArgsForCall []struct {
@@ -324,6 +336,40 @@ func (fake *FakeMediaTrack) AddOnCloseArgsForCall(i int) func(isExpectedToResume
 	return argsForCall.arg1
 }
 
+func (fake *FakeMediaTrack) AddOnRevoke(arg1 string, arg2 livekit.ParticipantIdentity, arg3 func()) {
+	fake.addOnRevokeMutex.Lock()
+	fake.addOnRevokeArgsForCall = append(fake.addOnRevokeArgsForCall, struct {
+		arg1 string
+		arg2 livekit.ParticipantIdentity
+		arg3 func()
+	}{arg1, arg2, arg3})
+	stub := fake.AddOnRevokeStub
+	fake.recordInvocation("AddOnRevoke", []interface{}{arg1, arg2, arg3})
+	fake.addOnRevokeMutex.Unlock()
+	if stub != nil {
+		fake.AddOnRevokeStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeMediaTrack) AddOnRevokeCallCount() int {
+	fake.addOnRevokeMutex.RLock()
+	defer fake.addOnRevokeMutex.RUnlock()
+	return len(fake.addOnRevokeArgsForCall)
+}
+
+func (fake *FakeMediaTrack) AddOnRevokeCalls(stub func(string, livekit.ParticipantIdentity, func())) {
+	fake.addOnRevokeMutex.Lock()
+	defer fake.addOnRevokeMutex.Unlock()
+	fake.AddOnRevokeStub = stub
+}
+
+func (fake *FakeMediaTrack) AddOnRevokeArgsForCall(i int) (string, livekit.ParticipantIdentity, func()) {
+	fake.addOnRevokeMutex.RLock()
+	defer fake.addOnRevokeMutex.RUnlock()
+	argsForCall := fake.addOnRevokeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeMediaTrack) AddSubscriber(arg1 types.LocalParticipant) (types.SubscribedTrack, error) {
 	fake.addSubscriberMutex.Lock()
 	ret, specificReturn := fake.addSubscriberReturnsOnCall[len(fake.addSubscriberArgsForCall)]
@@ -1407,6 +1453,38 @@ func (fake *FakeMediaTrack) ReceiversReturnsOnCall(i int, result1 []sfu.TrackRec
 	}{result1}
 }
 
+func (fake *FakeMediaTrack) RemoveOnRevoke(arg1 string) {
+	fake.removeOnRevokeMutex.Lock()
+	fake.removeOnRevokeArgsForCall = append(fake.removeOnRevokeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RemoveOnRevokeStub
+	fake.recordInvocation("RemoveOnRevoke", []interface{}{arg1})
+	fake.removeOnRevokeMutex.Unlock()
+	if stub != nil {
+		fake.RemoveOnRevokeStub(arg1)
+	}
+}
+
+func (fake *FakeMediaTrack) RemoveOnRevokeCallCount() int {
+	fake.removeOnRevokeMutex.RLock()
+	defer fake.removeOnRevokeMutex.RUnlock()
+	return len(fake.removeOnRevokeArgsForCall)
+}
+
+func (fake *FakeMediaTrack) RemoveOnRevokeCalls(stub func(string)) {
+	fake.removeOnRevokeMutex.Lock()
+	defer fake.removeOnRevokeMutex.Unlock()
+	fake.RemoveOnRevokeStub = stub
+}
+
+func (fake *FakeMediaTrack) RemoveOnRevokeArgsForCall(i int) string {
+	fake.removeOnRevokeMutex.RLock()
+	defer fake.removeOnRevokeMutex.RUnlock()
+	argsForCall := fake.removeOnRevokeArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeMediaTrack) RemoveSubscriber(arg1 livekit.ParticipantID, arg2 bool) {
 	fake.removeSubscriberMutex.Lock()
 	fake.removeSubscriberArgsForCall = append(fake.removeSubscriberArgsForCall, struct {
@@ -1798,6 +1876,8 @@ func (fake *FakeMediaTrack) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.addOnCloseMutex.RLock()
 	defer fake.addOnCloseMutex.RUnlock()
+	fake.addOnRevokeMutex.RLock()
+	defer fake.addOnRevokeMutex.RUnlock()
 	fake.addSubscriberMutex.RLock()
 	defer fake.addSubscriberMutex.RUnlock()
 	fake.clearAllReceiversMutex.RLock()
@@ -1840,6 +1920,8 @@ func (fake *FakeMediaTrack) Invocations() map[string][][]interface{} {
 	defer fake.publisherVersionMutex.RUnlock()
 	fake.receiversMutex.RLock()
 	defer fake.receiversMutex.RUnlock()
+	fake.removeOnRevokeMutex.RLock()
+	defer fake.removeOnRevokeMutex.RUnlock()
 	fake.removeSubscriberMutex.RLock()
 	defer fake.removeSubscriberMutex.RUnlock()
 	fake.revokeDisallowedSubscribersMutex.RLock()
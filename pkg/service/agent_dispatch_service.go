@@ -51,7 +51,7 @@ func (ag *AgentDispatchService) CreateDispatch(ctx context.Context, req *livekit
 	}
 
 	if ag.roomAllocator.AutoCreateEnabled(ctx) {
-		err := ag.roomAllocator.SelectRoomNode(ctx, livekit.RoomName(req.Room), "")
+		_, err := ag.roomAllocator.SelectRoomNode(ctx, livekit.RoomName(req.Room), "", "", "")
 		if err != nil {
 			return nil, err
 		}
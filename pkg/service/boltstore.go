@@ -0,0 +1,740 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/livekit/protocol/ingress"
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+	"github.com/livekit/protocol/utils"
+	"github.com/livekit/psrpc"
+)
+
+var (
+	boltEgressBucket           = []byte("egress")
+	boltIngressBucket          = []byte("ingress")
+	boltIngressStateBucket     = []byte("ingress_state")
+	boltIngressStreamKeyBucket = []byte("ingress_stream_key")
+	boltSIPTrunkBucket         = []byte(SIPTrunkKey)
+	boltSIPInboundTrunkBucket  = []byte(SIPInboundTrunkKey)
+	boltSIPOutboundTrunkBucket = []byte(SIPOutboundTrunkKey)
+	boltSIPDispatchRuleBucket  = []byte(SIPDispatchRuleKey)
+	// contain a bucket per room
+	boltAgentDispatchBucket = []byte("agent_dispatch")
+	boltAgentJobBucket      = []byte("agent_job")
+
+	boltBuckets = [][]byte{
+		boltEgressBucket,
+		boltIngressBucket,
+		boltIngressStateBucket,
+		boltIngressStreamKeyBucket,
+		boltSIPTrunkBucket,
+		boltSIPInboundTrunkBucket,
+		boltSIPOutboundTrunkBucket,
+		boltSIPDispatchRuleBucket,
+		boltAgentDispatchBucket,
+		boltAgentJobBucket,
+	}
+)
+
+const boltOpenTimeout = 5 * time.Second
+
+// BoltStore persists ingress, egress, SIP and agent dispatch state to an embedded database file,
+// for single node deployments without Redis.
+// Rooms, participants and room locks only exist while the node is running, they are kept in memory.
+// Agent dispatches of rooms are removed when the store is opened, their rooms did not survive.
+type BoltStore struct {
+	*LocalStore
+
+	db   *bolt.DB
+	done chan struct{}
+}
+
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: boltOpenTimeout})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range boltBuckets {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return boltPruneRoomBuckets(tx)
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{
+		LocalStore: NewLocalStore(),
+		db:         db,
+	}, nil
+}
+
+// boltPruneRoomBuckets deletes the buckets of rooms which were open when the store was last closed
+func boltPruneRoomBuckets(tx *bolt.Tx) error {
+	for _, name := range [][]byte{boltAgentDispatchBucket, boltAgentJobBucket} {
+		b := tx.Bucket(name)
+
+		var rooms [][]byte
+		err := b.ForEachBucket(func(k []byte) error {
+			rooms = append(rooms, bytes.Clone(k))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, room := range rooms {
+			if err = b.DeleteBucket(room); err != nil {
+				return err
+			}
+		}
+		if len(rooms) != 0 {
+			logger.Infow("removed state of rooms from previous run", "bucket", string(name), "numRooms", len(rooms))
+		}
+	}
+	return nil
+}
+
+func (s *BoltStore) Start() error {
+	if s.done != nil {
+		return nil
+	}
+
+	s.done = make(chan struct{}, 1)
+	go s.egressWorker()
+	return nil
+}
+
+// Stop stops the egress worker, the database stays open for other services until Close
+func (s *BoltStore) Stop() {
+	if s.done != nil {
+		select {
+		case <-s.done:
+		default:
+			close(s.done)
+		}
+	}
+}
+
+func (s *BoltStore) Close() {
+	if err := s.db.Close(); err != nil {
+		logger.Errorw("could not close store", err)
+	}
+}
+
+func (s *BoltStore) DeleteRoom(ctx context.Context, roomName livekit.RoomName) error {
+	if err := s.LocalStore.DeleteRoom(ctx, roomName); err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{boltAgentDispatchBucket, boltAgentJobBucket} {
+			err := tx.Bucket(name).DeleteBucket([]byte(roomName))
+			if err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) StoreEgress(_ context.Context, info *livekit.EgressInfo) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return boltStoreOne(tx.Bucket(boltEgressBucket), info.EgressId, info)
+	})
+}
+
+func (s *BoltStore) LoadEgress(_ context.Context, egressID string) (info *livekit.EgressInfo, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		info, err = boltLoadOne[livekit.EgressInfo](tx.Bucket(boltEgressBucket), egressID, ErrEgressNotFound)
+		return err
+	})
+	return
+}
+
+func (s *BoltStore) ListEgress(_ context.Context, roomName livekit.RoomName, active bool) ([]*livekit.EgressInfo, error) {
+	var infos []*livekit.EgressInfo
+	err := s.db.View(func(tx *bolt.Tx) error {
+		all, err := boltLoadMany[livekit.EgressInfo](tx.Bucket(boltEgressBucket))
+		if err != nil {
+			return err
+		}
+
+		for _, info := range all {
+			if roomName != "" && info.RoomName != string(roomName) {
+				continue
+			}
+			// if active, filter status starting, active, and ending
+			if !active || int32(info.Status) < int32(livekit.EgressStatus_EGRESS_COMPLETE) {
+				infos = append(infos, info)
+			}
+		}
+		return nil
+	})
+	return infos, err
+}
+
+func (s *BoltStore) UpdateEgress(ctx context.Context, info *livekit.EgressInfo) error {
+	return s.StoreEgress(ctx, info)
+}
+
+// Deletes egress info 24h after the egress has ended
+func (s *BoltStore) egressWorker() {
+	ticker := time.NewTicker(time.Minute * 30)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			err := s.CleanEndedEgress()
+			if err != nil {
+				logger.Errorw("could not clean egress info", err)
+			}
+		}
+	}
+}
+
+func (s *BoltStore) CleanEndedEgress() error {
+	expiry := time.Now().Add(-24 * time.Hour).UnixNano()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltEgressBucket)
+		infos, err := boltLoadMany[livekit.EgressInfo](b)
+		if err != nil {
+			return err
+		}
+
+		for _, info := range infos {
+			if info.EndedAt != 0 && info.EndedAt < expiry {
+				if err = b.Delete([]byte(info.EgressId)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) StoreIngress(ctx context.Context, info *livekit.IngressInfo) error {
+	err := s.storeIngress(ctx, info)
+	if err != nil {
+		return err
+	}
+
+	return s.storeIngressState(ctx, info.IngressId, nil)
+}
+
+func (s *BoltStore) storeIngress(_ context.Context, info *livekit.IngressInfo) error {
+	if info.IngressId == "" {
+		return errors.New("Missing IngressId")
+	}
+	if info.StreamKey == "" && info.InputType != livekit.IngressInput_URL_INPUT {
+		return errors.New("Missing StreamKey")
+	}
+
+	// ignore state
+	infoCopy := utils.CloneProto(info)
+	infoCopy.State = nil
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltIngressBucket)
+		streamKeys := tx.Bucket(boltIngressStreamKeyBucket)
+
+		oldInfo, err := boltLoadOne[livekit.IngressInfo](b, info.IngressId, ErrIngressNotFound)
+		switch err {
+		case ErrIngressNotFound:
+			// Ingress doesn't exist yet
+		case nil:
+			if oldInfo.StreamKey != "" && oldInfo.StreamKey != info.StreamKey {
+				if err = streamKeys.Delete([]byte(oldInfo.StreamKey)); err != nil {
+					return err
+				}
+			}
+		default:
+			return err
+		}
+
+		if err = boltStoreOne(b, info.IngressId, infoCopy); err != nil {
+			return err
+		}
+		if info.StreamKey != "" {
+			return streamKeys.Put([]byte(info.StreamKey), []byte(info.IngressId))
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) storeIngressState(_ context.Context, ingressId string, state *livekit.IngressState) error {
+	if ingressId == "" {
+		return errors.New("Missing IngressId")
+	}
+
+	if state == nil {
+		state = &livekit.IngressState{}
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltIngressStateBucket)
+
+		oldState, err := boltLoadOne[livekit.IngressState](b, ingressId, ErrIngressNotFound)
+		switch err {
+		case ErrIngressNotFound:
+			// Ingress state doesn't exist yet
+		case nil:
+			if state.StartedAt < oldState.StartedAt {
+				// Do not overwrite the info and state of a more recent session
+				return ingress.ErrIngressOutOfDate
+			}
+			if state.StartedAt == oldState.StartedAt && state.UpdatedAt < oldState.UpdatedAt {
+				// Do not overwrite with an old state in case RPCs were delivered out of order.
+				return nil
+			}
+		default:
+			return err
+		}
+
+		return boltStoreOne(b, ingressId, state)
+	})
+}
+
+func (s *BoltStore) LoadIngress(_ context.Context, ingressId string) (info *livekit.IngressInfo, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		info, err = boltLoadIngress(tx, ingressId)
+		return err
+	})
+	return
+}
+
+func (s *BoltStore) LoadIngressFromStreamKey(_ context.Context, streamKey string) (info *livekit.IngressInfo, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		ingressID := tx.Bucket(boltIngressStreamKeyBucket).Get([]byte(streamKey))
+		if ingressID == nil {
+			return ErrIngressNotFound
+		}
+		info, err = boltLoadIngress(tx, string(ingressID))
+		return err
+	})
+	return
+}
+
+func (s *BoltStore) ListIngress(_ context.Context, roomName livekit.RoomName) ([]*livekit.IngressInfo, error) {
+	var infos []*livekit.IngressInfo
+	err := s.db.View(func(tx *bolt.Tx) error {
+		all, err := boltLoadMany[livekit.IngressInfo](tx.Bucket(boltIngressBucket))
+		if err != nil {
+			return err
+		}
+
+		for _, info := range all {
+			if roomName != "" && info.RoomName != string(roomName) {
+				continue
+			}
+			state, err := boltLoadOne[livekit.IngressState](tx.Bucket(boltIngressStateBucket), info.IngressId, ErrIngressNotFound)
+			switch err {
+			case nil:
+				info.State = state
+			case ErrIngressNotFound:
+				// No state for this ingress
+			default:
+				return err
+			}
+
+			infos = append(infos, info)
+		}
+		return nil
+	})
+	return infos, err
+}
+
+func (s *BoltStore) UpdateIngress(ctx context.Context, info *livekit.IngressInfo) error {
+	return s.storeIngress(ctx, info)
+}
+
+func (s *BoltStore) UpdateIngressState(ctx context.Context, ingressId string, state *livekit.IngressState) error {
+	return s.storeIngressState(ctx, ingressId, state)
+}
+
+func (s *BoltStore) DeleteIngress(_ context.Context, info *livekit.IngressInfo) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if info.StreamKey != "" {
+			if err := tx.Bucket(boltIngressStreamKeyBucket).Delete([]byte(info.StreamKey)); err != nil {
+				return err
+			}
+		}
+		if err := tx.Bucket(boltIngressBucket).Delete([]byte(info.IngressId)); err != nil {
+			return err
+		}
+		return tx.Bucket(boltIngressStateBucket).Delete([]byte(info.IngressId))
+	})
+}
+
+func boltLoadIngress(tx *bolt.Tx, ingressId string) (*livekit.IngressInfo, error) {
+	info, err := boltLoadOne[livekit.IngressInfo](tx.Bucket(boltIngressBucket), ingressId, ErrIngressNotFound)
+	if err != nil {
+		return nil, err
+	}
+	state, err := boltLoadOne[livekit.IngressState](tx.Bucket(boltIngressStateBucket), ingressId, ErrIngressNotFound)
+	switch err {
+	case nil:
+		info.State = state
+	case ErrIngressNotFound:
+		// No state for this ingress
+	default:
+		return nil, err
+	}
+	return info, nil
+}
+
+func (s *BoltStore) StoreSIPTrunk(_ context.Context, info *livekit.SIPTrunkInfo) error {
+	return s.storeOne(boltSIPTrunkBucket, info.SipTrunkId, info)
+}
+
+func (s *BoltStore) StoreSIPInboundTrunk(_ context.Context, info *livekit.SIPInboundTrunkInfo) error {
+	return s.storeOne(boltSIPInboundTrunkBucket, info.SipTrunkId, info)
+}
+
+func (s *BoltStore) StoreSIPOutboundTrunk(_ context.Context, info *livekit.SIPOutboundTrunkInfo) error {
+	return s.storeOne(boltSIPOutboundTrunkBucket, info.SipTrunkId, info)
+}
+
+func (s *BoltStore) LoadSIPTrunk(_ context.Context, id string) (info *livekit.SIPTrunkInfo, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		tr, err := boltLoadOne[livekit.SIPTrunkInfo](tx.Bucket(boltSIPTrunkBucket), id, ErrSIPTrunkNotFound)
+		if err != ErrSIPTrunkNotFound {
+			info = tr
+			return err
+		}
+		in, err := boltLoadOne[livekit.SIPInboundTrunkInfo](tx.Bucket(boltSIPInboundTrunkBucket), id, ErrSIPTrunkNotFound)
+		if err == nil {
+			info = in.AsTrunkInfo()
+		}
+		if err != ErrSIPTrunkNotFound {
+			return err
+		}
+		out, err := boltLoadOne[livekit.SIPOutboundTrunkInfo](tx.Bucket(boltSIPOutboundTrunkBucket), id, ErrSIPTrunkNotFound)
+		if err == nil {
+			info = out.AsTrunkInfo()
+		}
+		return err
+	})
+	return
+}
+
+func (s *BoltStore) LoadSIPInboundTrunk(_ context.Context, id string) (info *livekit.SIPInboundTrunkInfo, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		in, err := boltLoadOne[livekit.SIPInboundTrunkInfo](tx.Bucket(boltSIPInboundTrunkBucket), id, ErrSIPTrunkNotFound)
+		if err != ErrSIPTrunkNotFound {
+			info = in
+			return err
+		}
+		tr, err := boltLoadOne[livekit.SIPTrunkInfo](tx.Bucket(boltSIPTrunkBucket), id, ErrSIPTrunkNotFound)
+		if err == nil {
+			info = tr.AsInbound()
+		}
+		return err
+	})
+	return
+}
+
+func (s *BoltStore) LoadSIPOutboundTrunk(_ context.Context, id string) (info *livekit.SIPOutboundTrunkInfo, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		out, err := boltLoadOne[livekit.SIPOutboundTrunkInfo](tx.Bucket(boltSIPOutboundTrunkBucket), id, ErrSIPTrunkNotFound)
+		if err != ErrSIPTrunkNotFound {
+			info = out
+			return err
+		}
+		tr, err := boltLoadOne[livekit.SIPTrunkInfo](tx.Bucket(boltSIPTrunkBucket), id, ErrSIPTrunkNotFound)
+		if err == nil {
+			info = tr.AsOutbound()
+		}
+		return err
+	})
+	return
+}
+
+func (s *BoltStore) ListSIPTrunk(_ context.Context) (infos []*livekit.SIPTrunkInfo, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		infos, err = boltLoadMany[livekit.SIPTrunkInfo](tx.Bucket(boltSIPTrunkBucket))
+		if err != nil {
+			return err
+		}
+		in, err := boltLoadMany[livekit.SIPInboundTrunkInfo](tx.Bucket(boltSIPInboundTrunkBucket))
+		if err != nil {
+			return err
+		}
+		for _, t := range in {
+			infos = append(infos, t.AsTrunkInfo())
+		}
+		out, err := boltLoadMany[livekit.SIPOutboundTrunkInfo](tx.Bucket(boltSIPOutboundTrunkBucket))
+		if err != nil {
+			return err
+		}
+		for _, t := range out {
+			infos = append(infos, t.AsTrunkInfo())
+		}
+		return nil
+	})
+	return
+}
+
+func (s *BoltStore) ListSIPInboundTrunk(_ context.Context) (infos []*livekit.SIPInboundTrunkInfo, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		infos, err = boltLoadMany[livekit.SIPInboundTrunkInfo](tx.Bucket(boltSIPInboundTrunkBucket))
+		if err != nil {
+			return err
+		}
+		old, err := boltLoadMany[livekit.SIPTrunkInfo](tx.Bucket(boltSIPTrunkBucket))
+		if err != nil {
+			return err
+		}
+		for _, t := range old {
+			infos = append(infos, t.AsInbound())
+		}
+		return nil
+	})
+	return
+}
+
+func (s *BoltStore) ListSIPOutboundTrunk(_ context.Context) (infos []*livekit.SIPOutboundTrunkInfo, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		infos, err = boltLoadMany[livekit.SIPOutboundTrunkInfo](tx.Bucket(boltSIPOutboundTrunkBucket))
+		if err != nil {
+			return err
+		}
+		old, err := boltLoadMany[livekit.SIPTrunkInfo](tx.Bucket(boltSIPTrunkBucket))
+		if err != nil {
+			return err
+		}
+		for _, t := range old {
+			infos = append(infos, t.AsOutbound())
+		}
+		return nil
+	})
+	return
+}
+
+func (s *BoltStore) DeleteSIPTrunk(_ context.Context, id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{boltSIPTrunkBucket, boltSIPInboundTrunkBucket, boltSIPOutboundTrunkBucket} {
+			if err := tx.Bucket(name).Delete([]byte(id)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *BoltStore) StoreSIPDispatchRule(_ context.Context, info *livekit.SIPDispatchRuleInfo) error {
+	return s.storeOne(boltSIPDispatchRuleBucket, info.SipDispatchRuleId, info)
+}
+
+func (s *BoltStore) LoadSIPDispatchRule(_ context.Context, sipDispatchRuleId string) (info *livekit.SIPDispatchRuleInfo, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		info, err = boltLoadOne[livekit.SIPDispatchRuleInfo](tx.Bucket(boltSIPDispatchRuleBucket), sipDispatchRuleId, ErrSIPDispatchRuleNotFound)
+		return err
+	})
+	return
+}
+
+func (s *BoltStore) DeleteSIPDispatchRule(_ context.Context, info *livekit.SIPDispatchRuleInfo) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltSIPDispatchRuleBucket).Delete([]byte(info.SipDispatchRuleId))
+	})
+}
+
+func (s *BoltStore) ListSIPDispatchRule(_ context.Context) (infos []*livekit.SIPDispatchRuleInfo, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		infos, err = boltLoadMany[livekit.SIPDispatchRuleInfo](tx.Bucket(boltSIPDispatchRuleBucket))
+		return err
+	})
+	return
+}
+
+func (s *BoltStore) StoreAgentDispatch(_ context.Context, dispatch *livekit.AgentDispatch) error {
+	di := utils.CloneProto(dispatch)
+
+	// Do not store jobs with the dispatch
+	if di.State != nil {
+		di.State.Jobs = nil
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket(boltAgentDispatchBucket).CreateBucketIfNotExists([]byte(dispatch.Room))
+		if err != nil {
+			return err
+		}
+		return boltStoreOne(b, di.Id, di)
+	})
+}
+
+// This will not delete the jobs created by the dispatch
+func (s *BoltStore) DeleteAgentDispatch(_ context.Context, dispatch *livekit.AgentDispatch) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltAgentDispatchBucket).Bucket([]byte(dispatch.Room))
+		if b == nil {
+			return nil
+		}
+		return b.Delete([]byte(dispatch.Id))
+	})
+}
+
+func (s *BoltStore) ListAgentDispatches(_ context.Context, roomName livekit.RoomName) (dispatches []*livekit.AgentDispatch, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		dispatches, err = boltLoadMany[livekit.AgentDispatch](tx.Bucket(boltAgentDispatchBucket).Bucket([]byte(roomName)))
+		if err != nil {
+			return err
+		}
+
+		dMap := make(map[string]*livekit.AgentDispatch)
+		for _, di := range dispatches {
+			dMap[di.Id] = di
+		}
+
+		jobs, err := boltLoadMany[livekit.Job](tx.Bucket(boltAgentJobBucket).Bucket([]byte(roomName)))
+		if err != nil {
+			return err
+		}
+
+		// Associate job to dispatch
+		for _, jb := range jobs {
+			di := dMap[jb.DispatchId]
+			if di == nil {
+				continue
+			}
+			if di.State == nil {
+				di.State = &livekit.AgentDispatchState{}
+			}
+			di.State.Jobs = append(di.State.Jobs, jb)
+		}
+		return nil
+	})
+	return
+}
+
+func (s *BoltStore) StoreAgentJob(_ context.Context, job *livekit.Job) error {
+	if job.Room == nil {
+		return psrpc.NewErrorf(psrpc.InvalidArgument, "job doesn't have a valid Room field")
+	}
+
+	jb := utils.CloneProto(job)
+
+	// Do not store room with the job
+	jb.Room = nil
+
+	// Only store the participant identity
+	if jb.Participant != nil {
+		jb.Participant = &livekit.ParticipantInfo{
+			Identity: jb.Participant.Identity,
+		}
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket(boltAgentJobBucket).CreateBucketIfNotExists([]byte(job.Room.Name))
+		if err != nil {
+			return err
+		}
+		return boltStoreOne(b, jb.Id, jb)
+	})
+}
+
+func (s *BoltStore) DeleteAgentJob(_ context.Context, job *livekit.Job) error {
+	if job.Room == nil {
+		return psrpc.NewErrorf(psrpc.InvalidArgument, "job doesn't have a valid Room field")
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltAgentJobBucket).Bucket([]byte(job.Room.Name))
+		if b == nil {
+			return nil
+		}
+		return b.Delete([]byte(job.Id))
+	})
+}
+
+func (s *BoltStore) storeOne(bucket []byte, id string, p proto.Message) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return boltStoreOne(tx.Bucket(bucket), id, p)
+	})
+}
+
+func boltStoreOne(b *bolt.Bucket, id string, p proto.Message) error {
+	if id == "" {
+		return errors.New("id is not set")
+	}
+	data, err := proto.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(id), data)
+}
+
+// boltLoadOne decodes the value of id in bucket b, which may be nil if the bucket does not exist
+func boltLoadOne[T any, P interface {
+	*T
+	proto.Message
+}](b *bolt.Bucket, id string, notFoundErr error) (P, error) {
+	if b == nil {
+		return nil, notFoundErr
+	}
+	data := b.Get([]byte(id))
+	if data == nil {
+		return nil, notFoundErr
+	}
+	var p P = new(T)
+	if err := proto.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// boltLoadMany decodes all values in bucket b, which may be nil if the bucket does not exist
+func boltLoadMany[T any, P interface {
+	*T
+	proto.Message
+}](b *bolt.Bucket) ([]P, error) {
+	if b == nil {
+		return nil, nil
+	}
+
+	var list []P
+	err := b.ForEach(func(_, data []byte) error {
+		if data == nil {
+			// nested bucket
+			return nil
+		}
+		var p P = new(T)
+		if err := proto.Unmarshal(data, p); err != nil {
+			return err
+		}
+		list = append(list, p)
+		return nil
+	})
+	return list, err
+}
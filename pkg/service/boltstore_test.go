@@ -0,0 +1,204 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/ingress"
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/service"
+)
+
+func boltStore(t testing.TB, path string) *service.BoltStore {
+	s, err := service.NewBoltStore(path)
+	require.NoError(t, err)
+	return s
+}
+
+func TestBoltStorePersistence(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "livekit.db")
+
+	s := boltStore(t, path)
+	require.NoError(t, s.StoreIngress(ctx, &livekit.IngressInfo{
+		IngressId: "ingress1",
+		StreamKey: "key1",
+		RoomName:  "room1",
+	}))
+	require.NoError(t, s.StoreSIPInboundTrunk(ctx, &livekit.SIPInboundTrunkInfo{SipTrunkId: "trunk1"}))
+	require.NoError(t, s.StoreSIPDispatchRule(ctx, &livekit.SIPDispatchRuleInfo{SipDispatchRuleId: "rule1"}))
+	require.NoError(t, s.StoreEgress(ctx, &livekit.EgressInfo{EgressId: "egress1", RoomName: "room1"}))
+	require.NoError(t, s.StoreAgentDispatch(ctx, &livekit.AgentDispatch{Id: "dispatch1", Room: "room1"}))
+	require.NoError(t, s.StoreRoom(ctx, &livekit.Room{Name: "room1"}, nil))
+	s.Close()
+
+	// reopen
+	s = boltStore(t, path)
+	defer s.Close()
+
+	info, err := s.LoadIngressFromStreamKey(ctx, "key1")
+	require.NoError(t, err)
+	require.Equal(t, "ingress1", info.IngressId)
+	require.NotNil(t, info.State)
+
+	trunk, err := s.LoadSIPTrunk(ctx, "trunk1")
+	require.NoError(t, err)
+	require.Equal(t, "trunk1", trunk.SipTrunkId)
+
+	rules, err := s.ListSIPDispatchRule(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+
+	egress, err := s.ListEgress(ctx, "room1", true)
+	require.NoError(t, err)
+	require.Len(t, egress, 1)
+
+	// rooms do not outlive the node, neither do their agent dispatches
+	_, _, err = s.LoadRoom(ctx, "room1", false)
+	require.ErrorIs(t, err, service.ErrRoomNotFound)
+	dispatches, err := s.ListAgentDispatches(ctx, "room1")
+	require.NoError(t, err)
+	require.Empty(t, dispatches)
+}
+
+func TestBoltStoreIngress(t *testing.T) {
+	ctx := context.Background()
+	s := boltStore(t, filepath.Join(t.TempDir(), "livekit.db"))
+	defer s.Close()
+
+	info := &livekit.IngressInfo{
+		IngressId: "ingress1",
+		StreamKey: "key1",
+		RoomName:  "room1",
+	}
+	require.NoError(t, s.StoreIngress(ctx, info))
+
+	state := &livekit.IngressState{
+		Status:    livekit.IngressState_ENDPOINT_PUBLISHING,
+		StartedAt: 2,
+		UpdatedAt: 2,
+	}
+	require.NoError(t, s.UpdateIngressState(ctx, info.IngressId, state))
+
+	// older session is rejected
+	err := s.UpdateIngressState(ctx, info.IngressId, &livekit.IngressState{StartedAt: 1})
+	require.ErrorIs(t, err, ingress.ErrIngressOutOfDate)
+
+	// out of order update is ignored
+	require.NoError(t, s.UpdateIngressState(ctx, info.IngressId, &livekit.IngressState{StartedAt: 2, UpdatedAt: 1}))
+	loaded, err := s.LoadIngress(ctx, info.IngressId)
+	require.NoError(t, err)
+	require.Equal(t, livekit.IngressState_ENDPOINT_PUBLISHING, loaded.State.Status)
+
+	// changing the stream key drops the old one
+	info.StreamKey = "key2"
+	info.RoomName = "room2"
+	require.NoError(t, s.UpdateIngress(ctx, info))
+	_, err = s.LoadIngressFromStreamKey(ctx, "key1")
+	require.ErrorIs(t, err, service.ErrIngressNotFound)
+
+	infos, err := s.ListIngress(ctx, "room1")
+	require.NoError(t, err)
+	require.Empty(t, infos)
+	infos, err = s.ListIngress(ctx, "room2")
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+
+	require.NoError(t, s.DeleteIngress(ctx, info))
+	_, err = s.LoadIngress(ctx, info.IngressId)
+	require.ErrorIs(t, err, service.ErrIngressNotFound)
+	_, err = s.LoadIngressFromStreamKey(ctx, "key2")
+	require.ErrorIs(t, err, service.ErrIngressNotFound)
+}
+
+func TestBoltStoreEgress(t *testing.T) {
+	ctx := context.Background()
+	s := boltStore(t, filepath.Join(t.TempDir(), "livekit.db"))
+	defer s.Close()
+
+	require.NoError(t, s.StoreEgress(ctx, &livekit.EgressInfo{
+		EgressId: "active",
+		RoomName: "room1",
+		Status:   livekit.EgressStatus_EGRESS_ACTIVE,
+	}))
+	require.NoError(t, s.StoreEgress(ctx, &livekit.EgressInfo{
+		EgressId: "ended",
+		RoomName: "room1",
+		Status:   livekit.EgressStatus_EGRESS_COMPLETE,
+		EndedAt:  time.Now().Add(-25 * time.Hour).UnixNano(),
+	}))
+	require.NoError(t, s.StoreEgress(ctx, &livekit.EgressInfo{
+		EgressId: "other",
+		RoomName: "room2",
+		Status:   livekit.EgressStatus_EGRESS_ACTIVE,
+	}))
+
+	infos, err := s.ListEgress(ctx, "", false)
+	require.NoError(t, err)
+	require.Len(t, infos, 3)
+
+	infos, err = s.ListEgress(ctx, "room1", true)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.Equal(t, "active", infos[0].EgressId)
+
+	require.NoError(t, s.CleanEndedEgress())
+	_, err = s.LoadEgress(ctx, "ended")
+	require.ErrorIs(t, err, service.ErrEgressNotFound)
+	infos, err = s.ListEgress(ctx, "", false)
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+}
+
+func TestBoltStoreAgentDispatch(t *testing.T) {
+	ctx := context.Background()
+	s := boltStore(t, filepath.Join(t.TempDir(), "livekit.db"))
+	defer s.Close()
+
+	room := &livekit.Room{Name: "room1"}
+	require.NoError(t, s.StoreRoom(ctx, room, nil))
+	require.NoError(t, s.StoreAgentDispatch(ctx, &livekit.AgentDispatch{
+		Id:        "dispatch1",
+		AgentName: "agent",
+		Room:      room.Name,
+	}))
+	require.NoError(t, s.StoreAgentJob(ctx, &livekit.Job{
+		Id:          "job1",
+		DispatchId:  "dispatch1",
+		Room:        room,
+		Participant: &livekit.ParticipantInfo{Identity: "p1", Name: "participant"},
+	}))
+
+	dispatches, err := s.ListAgentDispatches(ctx, "room1")
+	require.NoError(t, err)
+	require.Len(t, dispatches, 1)
+	require.Len(t, dispatches[0].State.Jobs, 1)
+	job := dispatches[0].State.Jobs[0]
+	require.Nil(t, job.Room)
+	require.Equal(t, "p1", job.Participant.Identity)
+	require.Empty(t, job.Participant.Name)
+
+	require.NoError(t, s.DeleteRoom(ctx, "room1"))
+	dispatches, err = s.ListAgentDispatches(ctx, "room1")
+	require.NoError(t, err)
+	require.Empty(t, dispatches)
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	"github.com/livekit/livekit-server/pkg/sfu/capture"
+)
+
+const (
+	defaultCaptureDuration = 10 * time.Second
+	maxCaptureDuration     = 5 * time.Minute
+)
+
+var errCaptureInProgress = errors.New("capture already in progress")
+
+type captureTarget interface {
+	SetCapture(c *capture.Capture)
+}
+
+// captureTargetSet holds targets being captured, a target takes one capture at a time
+type captureTargetSet struct {
+	lock    sync.Mutex
+	targets map[captureTarget]struct{}
+}
+
+// shared by the capture handlers of the debug and the authenticated endpoints
+var activeCaptureTargets = &captureTargetSet{targets: make(map[captureTarget]struct{})}
+
+// acquire takes all targets, or none of them when one of them is being captured
+func (s *captureTargetSet) acquire(targets []captureTarget) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, t := range targets {
+		if _, ok := s.targets[t]; ok {
+			return false
+		}
+	}
+	for _, t := range targets {
+		s.targets[t] = struct{}{}
+	}
+	return true
+}
+
+func (s *captureTargetSet) release(targets []captureTarget) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, t := range targets {
+		delete(s.targets, t)
+	}
+}
+
+// CaptureService streams a time bounded pcapng capture of the decrypted RTP/RTCP of a participant,
+// or of one of its tracks. The room must be hosted on the node receiving the request.
+//
+// Query parameters: room, identity, track (optional), duration (e.g. 30s), payload (true to keep RTP payloads)
+// A track or participant already being captured is answered with 409 Conflict.
+type CaptureService struct {
+	roomManager *RoomManager
+	requireAuth bool
+}
+
+// NewCaptureService creates a handler, when requireAuth is set requests need a token with roomAdmin for the room
+func NewCaptureService(roomManager *RoomManager, requireAuth bool) *CaptureService {
+	return &CaptureService{
+		roomManager: roomManager,
+		requireAuth: requireAuth,
+	}
+}
+
+func (s *CaptureService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	roomName := livekit.RoomName(r.FormValue("room"))
+	identity := livekit.ParticipantIdentity(r.FormValue("identity"))
+	trackID := livekit.TrackID(r.FormValue("track"))
+	if roomName == "" || identity == "" {
+		handleError(w, r, http.StatusBadRequest, errors.New("room and identity are required"))
+		return
+	}
+
+	if s.requireAuth {
+		if err := EnsureAdminPermission(r.Context(), roomName); err != nil {
+			handleError(w, r, http.StatusUnauthorized, err)
+			return
+		}
+	}
+
+	duration := defaultCaptureDuration
+	if d := r.FormValue("duration"); d != "" {
+		var err error
+		if duration, err = time.ParseDuration(d); err != nil || duration <= 0 {
+			handleError(w, r, http.StatusBadRequest, fmt.Errorf("invalid duration %q", d))
+			return
+		}
+		if duration > maxCaptureDuration {
+			duration = maxCaptureDuration
+		}
+	}
+
+	room := s.roomManager.GetRoom(r.Context(), roomName)
+	if room == nil {
+		handleError(w, r, http.StatusNotFound, ErrRoomNotFound, "room", roomName)
+		return
+	}
+	lp := room.GetParticipant(identity)
+	if lp == nil {
+		handleError(w, r, http.StatusNotFound, ErrParticipantNotFound, "room", roomName, "participant", identity)
+		return
+	}
+	targets := captureTargets(lp, trackID)
+	if len(targets) == 0 {
+		handleError(w, r, http.StatusNotFound, ErrTrackNotFound, "room", roomName, "participant", identity, "trackID", trackID)
+		return
+	}
+
+	if !activeCaptureTargets.acquire(targets) {
+		handleError(w, r, http.StatusConflict, errCaptureInProgress, "room", roomName, "participant", identity, "trackID", trackID)
+		return
+	}
+	defer activeCaptureTargets.release(targets)
+
+	name := string(identity)
+	if trackID != "" {
+		name = string(trackID)
+	}
+	w.Header().Set("Content-Type", "application/x-pcapng")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s-%s.pcapng", name, time.Now().UTC().Format("20060102T150405Z"))))
+
+	c, err := capture.New(w, capture.Params{
+		Duration:       duration,
+		IncludePayload: boolValue(r.FormValue("payload")),
+		Logger:         lp.GetLogger(),
+	})
+	if err != nil {
+		handleError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	lp.GetLogger().Infow("starting packet capture", "trackID", trackID, "duration", duration, "targets", len(targets))
+	for _, t := range targets {
+		t.SetCapture(c)
+	}
+
+	select {
+	case <-c.Done():
+	case <-r.Context().Done():
+		c.Stop()
+		<-c.Done()
+	}
+
+	for _, t := range targets {
+		t.SetCapture(nil)
+	}
+	packets, dropped := c.Stats()
+	lp.GetLogger().Infow("packet capture finished", "trackID", trackID, "packets", packets, "dropped", dropped)
+}
+
+// captureTargets returns receivers of published tracks and down tracks of subscribed tracks,
+// restricted to trackID when it is set
+func captureTargets(lp types.LocalParticipant, trackID livekit.TrackID) []captureTarget {
+	var targets []captureTarget
+	for _, track := range lp.GetPublishedTracks() {
+		if trackID != "" && track.ID() != trackID {
+			continue
+		}
+		for _, receiver := range track.Receivers() {
+			if t, ok := receiver.(captureTarget); ok {
+				targets = append(targets, t)
+			}
+		}
+	}
+	for _, subTrack := range lp.GetSubscribedTracks() {
+		if trackID != "" && subTrack.ID() != trackID {
+			continue
+		}
+		if dt := subTrack.DownTrack(); dt != nil {
+			targets = append(targets, dt)
+		}
+	}
+	return targets
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/livekit-server/pkg/sfu/capture"
+)
+
+type testCaptureTarget struct {
+	c *capture.Capture
+}
+
+func (t *testCaptureTarget) SetCapture(c *capture.Capture) {
+	t.c = c
+}
+
+func TestCaptureTargetSet(t *testing.T) {
+	s := &captureTargetSet{targets: make(map[captureTarget]struct{})}
+	a, b, c := &testCaptureTarget{}, &testCaptureTarget{}, &testCaptureTarget{}
+
+	require.True(t, s.acquire([]captureTarget{a, b}))
+	// overlapping capture is refused without taking any target
+	require.False(t, s.acquire([]captureTarget{b, c}))
+	require.True(t, s.acquire([]captureTarget{c}))
+
+	s.release([]captureTarget{a, b})
+	require.True(t, s.acquire([]captureTarget{a, b}))
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"net"
+	"strings"
+
+	"github.com/oschwald/maxminddb-golang"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+
+	"github.com/livekit/livekit-server/pkg/config"
+	"github.com/livekit/livekit-server/pkg/routing/selector"
+)
+
+// ClientLocation is where a client address is located according to the GeoIP database
+type ClientLocation struct {
+	Country string
+	City    string
+	Lat     float64
+	Lon     float64
+	// configured region closest to the location
+	Region string
+}
+
+// geohash of client locations in analytics, 5 characters are about 5km
+const clientGeoHashPrecision = 5
+
+const geoHashBase32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// GeoHash returns the geohash of the location with the precision used in analytics
+func (l *ClientLocation) GeoHash() string {
+	latRange, lonRange := [2]float64{-90, 90}, [2]float64{-180, 180}
+	hash := make([]byte, 0, clientGeoHashPrecision)
+	// bits alternate between longitude and latitude, starting with longitude
+	even := true
+	for len(hash) < clientGeoHashPrecision {
+		var c byte
+		for bit := 0; bit < 5; bit++ {
+			r, v := &latRange, l.Lat
+			if even {
+				r, v = &lonRange, l.Lon
+			}
+			mid := (r[0] + r[1]) / 2
+			c <<= 1
+			if v >= mid {
+				c |= 1
+				r[0] = mid
+			} else {
+				r[1] = mid
+			}
+			even = !even
+		}
+		hash = append(hash, geoHashBase32[c])
+	}
+	return string(hash)
+}
+
+// UpdateClientMeta sets the country and geohash of the location in analytics about the client
+func (l *ClientLocation) UpdateClientMeta(meta *livekit.AnalyticsClientMeta) {
+	if l.Country != "" {
+		meta.Country = &l.Country
+	}
+	geoHash := l.GeoHash()
+	meta.GeoHash = &geoHash
+}
+
+// subset of the GeoIP2/GeoLite2 City record
+type geoIPCityRecord struct {
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	Location struct {
+		Latitude  *float64 `maxminddb:"latitude"`
+		Longitude *float64 `maxminddb:"longitude"`
+	} `maxminddb:"location"`
+}
+
+// GeoIPResolver locates clients using a MaxMind format city database, and maps them to
+// the closest region configured for the node selector
+type GeoIPResolver struct {
+	db      *maxminddb.Reader
+	regions []config.RegionConfig
+}
+
+// NewGeoIPResolver opens the configured GeoIP database, it returns nil when none is configured
+func NewGeoIPResolver(conf *config.Config) (*GeoIPResolver, error) {
+	if conf.GeoIP.DatabaseFile == "" {
+		return nil, nil
+	}
+
+	db, err := maxminddb.Open(conf.GeoIP.DatabaseFile)
+	if err != nil {
+		return nil, err
+	}
+	return &GeoIPResolver{
+		db:      db,
+		regions: conf.NodeSelector.Regions,
+	}, nil
+}
+
+// Locate returns the location of a client address, which may be a X-Forwarded-For list.
+// It returns nil when the address cannot be located.
+func (g *GeoIPResolver) Locate(address string) *ClientLocation {
+	if g == nil {
+		return nil
+	}
+
+	// first address of a forwarded list is the client
+	address, _, _ = strings.Cut(address, ",")
+	ip := net.ParseIP(strings.TrimSpace(address))
+	if ip == nil {
+		return nil
+	}
+
+	var record geoIPCityRecord
+	if err := g.db.Lookup(ip, &record); err != nil {
+		logger.Debugw("could not look up client location", "error", err, "address", address)
+		return nil
+	}
+	if record.Location.Latitude == nil || record.Location.Longitude == nil {
+		return nil
+	}
+
+	loc := &ClientLocation{
+		Country: record.Country.ISOCode,
+		City:    record.City.Names["en"],
+		Lat:     *record.Location.Latitude,
+		Lon:     *record.Location.Longitude,
+	}
+	loc.Region = selector.ClosestRegion(g.regions, loc.Lat, loc.Lon)
+	return loc
+}
+
+func (g *GeoIPResolver) Close() {
+	if g == nil {
+		return
+	}
+	if err := g.db.Close(); err != nil {
+		logger.Errorw("could not close GeoIP database", err)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/livekit"
+)
+
+func TestClientLocationClientMeta(t *testing.T) {
+	loc := &ClientLocation{Country: "DK", Lat: 57.64911, Lon: 10.40744}
+	require.Equal(t, "u4pru", loc.GeoHash())
+
+	meta := &livekit.AnalyticsClientMeta{Region: "eu"}
+	loc.UpdateClientMeta(meta)
+	require.Equal(t, "DK", meta.GetCountry())
+	require.Equal(t, "u4pru", meta.GetGeoHash())
+	require.Equal(t, "eu", meta.GetRegion())
+}
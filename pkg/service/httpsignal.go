@@ -0,0 +1,241 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/utils/guid"
+)
+
+const (
+	eventStreamContentType = "text/event-stream"
+	jsonContentType        = "application/json"
+
+	signalSessionEvent      = "session"
+	signalSessionPrefix     = "SG_"
+	maxSignalRequestSize    = 1024 * 1024
+	signalRequestBufferSize = 32
+)
+
+var (
+	ErrSignalSessionClosed    = errors.New("signal session closed")
+	ErrSignalSessionNotFound  = errors.New("signal session not found")
+	ErrSignalRequestQueueFull = errors.New("signal request queue full")
+)
+
+// SignalConnection carries the signal messages of a participant session
+type SignalConnection interface {
+	ReadRequest() (*livekit.SignalRequest, int, error)
+	WriteResponse(msg *livekit.SignalResponse) (int, error)
+	Close() error
+}
+
+type httpSignalRequest struct {
+	req  *livekit.SignalRequest
+	size int
+}
+
+// HTTPSignalConnection is a signal connection for clients that cannot use WebSockets, e. g. behind proxies
+// blocking upgrades. Responses are streamed as server-sent events, the first event ("session") holds the ID of
+// the session, requests are then POSTed to /rtc/signal/<session ID> with a token of the same participant.
+//
+// Sessions only exist on the node serving the event stream, requests are not relayed between nodes.
+// Behind a load balancer, requests of a client have to be routed to the same node, e. g. with sticky sessions.
+//
+// Messages are base64 encoded protobuf, or JSON once the client sends JSON, same as text messages on a WebSocket.
+type HTTPSignalConnection struct {
+	id       string
+	identity string
+
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	useJSON bool
+	closed  bool
+
+	requests  chan httpSignalRequest
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewHTTPSignalConnection starts the event stream of a session, identity is the participant allowed to send requests
+func NewHTTPSignalConnection(w http.ResponseWriter, r *http.Request, identity string) (*HTTPSignalConnection, error) {
+	c := &HTTPSignalConnection{
+		id:       guid.New(signalSessionPrefix),
+		identity: identity,
+		w:        w,
+		rc:       http.NewResponseController(w),
+		requests: make(chan httpSignalRequest, signalRequestBufferSize),
+		done:     make(chan struct{}),
+	}
+
+	w.Header().Set("Content-Type", eventStreamContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	// ask reverse proxies not to buffer the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := c.writeEvent(signalSessionEvent, c.id); err != nil {
+		return nil, err
+	}
+
+	go c.worker(r.Context())
+	return c, nil
+}
+
+func (c *HTTPSignalConnection) ID() string {
+	return c.id
+}
+
+func (c *HTTPSignalConnection) Identity() string {
+	return c.identity
+}
+
+func (c *HTTPSignalConnection) Close() error {
+	c.closeOnce.Do(func() {
+		c.mu.Lock()
+		c.closed = true
+		c.mu.Unlock()
+		close(c.done)
+	})
+	return nil
+}
+
+func (c *HTTPSignalConnection) ReadRequest() (*livekit.SignalRequest, int, error) {
+	select {
+	case r := <-c.requests:
+		return r.req, r.size, nil
+	case <-c.done:
+		return nil, 0, io.EOF
+	}
+}
+
+// HandleRequest queues a request POSTed by the client
+func (c *HTTPSignalConnection) HandleRequest(payload []byte, contentType string) error {
+	msg := &livekit.SignalRequest{}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == jsonContentType {
+		if err := protojson.Unmarshal(payload, msg); err != nil {
+			return err
+		}
+	} else if err := proto.Unmarshal(payload, msg); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrSignalSessionClosed
+	}
+	// json encoded, also write back JSON
+	c.useJSON = mediaType == jsonContentType
+
+	select {
+	case c.requests <- httpSignalRequest{msg, len(payload)}:
+		return nil
+	default:
+		return ErrSignalRequestQueueFull
+	}
+}
+
+func (c *HTTPSignalConnection) WriteResponse(msg *livekit.SignalResponse) (int, error) {
+	c.mu.Lock()
+	useJSON := c.useJSON
+	c.mu.Unlock()
+
+	var data string
+	if useJSON {
+		payload, err := protojson.Marshal(msg)
+		if err != nil {
+			return 0, err
+		}
+		data = string(payload)
+	} else {
+		payload, err := proto.Marshal(msg)
+		if err != nil {
+			return 0, err
+		}
+		data = base64.StdEncoding.EncodeToString(payload)
+	}
+	return len(data), c.writeEvent("", data)
+}
+
+func (c *HTTPSignalConnection) writeEvent(event, data string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrSignalSessionClosed
+	}
+
+	var sb strings.Builder
+	if event != "" {
+		fmt.Fprintf(&sb, "event: %s\n", event)
+	}
+	if data != "" {
+		fmt.Fprintf(&sb, "data: %s\n", data)
+	} else {
+		// comment, keeps proxies from timing out idle streams
+		sb.WriteString(":\n")
+	}
+	sb.WriteString("\n")
+
+	if _, err := io.WriteString(c.w, sb.String()); err != nil {
+		return err
+	}
+	return c.rc.Flush()
+}
+
+func (c *HTTPSignalConnection) worker(ctx context.Context) {
+	ticker := time.NewTicker(pingFrequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ctx.Done():
+			// client went away
+			_ = c.Close()
+			return
+		case <-ticker.C:
+			if err := c.writeEvent("", ""); err != nil {
+				_ = c.Close()
+				return
+			}
+		}
+	}
+}
+
+func acceptsEventStream(r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		if mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept)); err == nil && mediaType == eventStreamContentType {
+			return true
+		}
+	}
+	return false
+}
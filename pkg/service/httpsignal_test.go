@@ -0,0 +1,87 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service_test
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/service"
+)
+
+func TestHTTPSignalConnection(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/rtc", nil)
+	r.Header.Set("Accept", "text/event-stream")
+
+	c, err := service.NewHTTPSignalConnection(w, r, "p1")
+	require.NoError(t, err)
+	require.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	require.Equal(t, "event: session\ndata: "+c.ID()+"\n\n", w.Body.String())
+	w.Body.Reset()
+
+	// protobuf request, protobuf response
+	ping := &livekit.SignalRequest{Message: &livekit.SignalRequest_Ping{Ping: 1}}
+	payload, err := proto.Marshal(ping)
+	require.NoError(t, err)
+	require.NoError(t, c.HandleRequest(payload, "application/x-protobuf"))
+	req, size, err := c.ReadRequest()
+	require.NoError(t, err)
+	require.Equal(t, len(payload), size)
+	require.True(t, proto.Equal(ping, req))
+
+	pong := &livekit.SignalResponse{Message: &livekit.SignalResponse_Pong{Pong: 2}}
+	_, err = c.WriteResponse(pong)
+	require.NoError(t, err)
+	data, ok := strings.CutPrefix(strings.TrimSpace(w.Body.String()), "data: ")
+	require.True(t, ok)
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	require.NoError(t, err)
+	res := &livekit.SignalResponse{}
+	require.NoError(t, proto.Unmarshal(decoded, res))
+	require.True(t, proto.Equal(pong, res))
+	w.Body.Reset()
+
+	// switches to JSON when the client sends JSON
+	require.NoError(t, c.HandleRequest([]byte(`{"ping": "3"}`), "application/json; charset=utf-8"))
+	req, _, err = c.ReadRequest()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), req.GetPing())
+	_, err = c.WriteResponse(pong)
+	require.NoError(t, err)
+	data, ok = strings.CutPrefix(strings.TrimSpace(w.Body.String()), "data: ")
+	require.True(t, ok)
+	res = &livekit.SignalResponse{}
+	require.NoError(t, protojson.Unmarshal([]byte(data), res))
+	require.True(t, proto.Equal(pong, res))
+
+	require.Error(t, c.HandleRequest([]byte("not json"), "application/json"))
+
+	require.NoError(t, c.Close())
+	_, _, err = c.ReadRequest()
+	require.ErrorIs(t, err, io.EOF)
+	require.ErrorIs(t, c.HandleRequest(payload, ""), service.ErrSignalSessionClosed)
+	_, err = c.WriteResponse(pong)
+	require.ErrorIs(t, err, service.ErrSignalSessionClosed)
+}
@@ -36,10 +36,20 @@ type ObjectStore interface {
 
 	StoreRoom(ctx context.Context, room *livekit.Room, internal *livekit.RoomInternal) error
 
+	// constraints the room was placed with, used when it is placed again on another node
+	StoreRoomPlacement(ctx context.Context, roomName livekit.RoomName, placement *RoomPlacementInfo) error
+	LoadRoomPlacement(ctx context.Context, roomName livekit.RoomName) (*RoomPlacementInfo, error)
+
 	StoreParticipant(ctx context.Context, roomName livekit.RoomName, participant *livekit.ParticipantInfo) error
 	DeleteParticipant(ctx context.Context, roomName livekit.RoomName, identity livekit.ParticipantIdentity) error
 }
 
+// RoomPlacementInfo holds what node selection constraints of a room are derived from
+type RoomPlacementInfo struct {
+	RoomPreset string `json:"room_preset,omitempty"`
+	APIKey     string `json:"api_key,omitempty"`
+}
+
 //counterfeiter:generate . ServiceStore
 type ServiceStore interface {
 	LoadRoom(ctx context.Context, roomName livekit.RoomName, includeInternal bool) (*livekit.Room, *livekit.RoomInternal, error)
@@ -74,7 +84,8 @@ type IngressStore interface {
 //counterfeiter:generate . RoomAllocator
 type RoomAllocator interface {
 	AutoCreateEnabled(ctx context.Context) bool
-	SelectRoomNode(ctx context.Context, roomName livekit.RoomName, nodeID livekit.NodeID) error
+	SelectRoomNode(ctx context.Context, roomName livekit.RoomName, nodeID livekit.NodeID, roomPreset string, clientRegion string) (string, error)
+	SelectMigrationNode(ctx context.Context, roomName livekit.RoomName, fromNodeID livekit.NodeID) (livekit.NodeID, error)
 	CreateRoom(ctx context.Context, req *livekit.CreateRoomRequest, isExplicit bool) (*livekit.Room, *livekit.RoomInternal, bool, error)
 	ValidateCreateRoom(ctx context.Context, roomName livekit.RoomName) error
 }
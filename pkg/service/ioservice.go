@@ -65,13 +65,19 @@ func NewIOInfoService(
 }
 
 func (s *IOInfoService) Start() error {
-	if s.es != nil {
-		rs := s.es.(*RedisStore)
-		err := rs.Start()
+	switch store := s.es.(type) {
+	case *RedisStore:
+		err := store.Start()
 		if err != nil {
 			logger.Errorw("failed to start redis egress worker", err)
 			return err
 		}
+	case *BoltStore:
+		err := store.Start()
+		if err != nil {
+			logger.Errorw("failed to start egress worker", err)
+			return err
+		}
 	}
 
 	return nil
@@ -80,6 +86,10 @@ func (s *IOInfoService) Start() error {
 func (s *IOInfoService) Stop() {
 	close(s.shutdown)
 
+	if store, ok := s.es.(*BoltStore); ok {
+		store.Stop()
+	}
+
 	if s.ioServer != nil {
 		s.ioServer.Shutdown()
 	}
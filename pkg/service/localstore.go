@@ -30,6 +30,7 @@ type LocalStore struct {
 	// map of roomName => room
 	rooms        map[livekit.RoomName]*livekit.Room
 	roomInternal map[livekit.RoomName]*livekit.RoomInternal
+	placements   map[livekit.RoomName]*RoomPlacementInfo
 	// map of roomName => { identity: participant }
 	participants map[livekit.RoomName]map[livekit.ParticipantIdentity]*livekit.ParticipantInfo
 
@@ -44,6 +45,7 @@ func NewLocalStore() *LocalStore {
 	return &LocalStore{
 		rooms:           make(map[livekit.RoomName]*livekit.Room),
 		roomInternal:    make(map[livekit.RoomName]*livekit.RoomInternal),
+		placements:      make(map[livekit.RoomName]*RoomPlacementInfo),
 		participants:    make(map[livekit.RoomName]map[livekit.ParticipantIdentity]*livekit.ParticipantInfo),
 		agentDispatches: make(map[livekit.RoomName]map[string]*livekit.AgentDispatch),
 		agentJobs:       make(map[livekit.RoomName]map[string]*livekit.Job),
@@ -110,11 +112,30 @@ func (s *LocalStore) DeleteRoom(ctx context.Context, roomName livekit.RoomName)
 	delete(s.participants, livekit.RoomName(room.Name))
 	delete(s.rooms, livekit.RoomName(room.Name))
 	delete(s.roomInternal, livekit.RoomName(room.Name))
+	delete(s.placements, livekit.RoomName(room.Name))
 	delete(s.agentDispatches, livekit.RoomName(room.Name))
 	delete(s.agentJobs, livekit.RoomName(room.Name))
 	return nil
 }
 
+func (s *LocalStore) StoreRoomPlacement(_ context.Context, roomName livekit.RoomName, placement *RoomPlacementInfo) error {
+	s.lock.Lock()
+	s.placements[roomName] = placement
+	s.lock.Unlock()
+	return nil
+}
+
+func (s *LocalStore) LoadRoomPlacement(_ context.Context, roomName livekit.RoomName) (*RoomPlacementInfo, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	placement := s.placements[roomName]
+	if placement == nil {
+		return nil, ErrRoomNotFound
+	}
+	return placement, nil
+}
+
 func (s *LocalStore) LockRoom(_ context.Context, _ livekit.RoomName, _ time.Duration) (string, error) {
 	// local rooms lock & unlock globally
 	s.globalLock.Lock()
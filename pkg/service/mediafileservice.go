@@ -0,0 +1,262 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/config"
+	"github.com/livekit/livekit-server/pkg/routing"
+	"github.com/livekit/livekit-server/pkg/rtc"
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	"github.com/livekit/livekit-server/pkg/telemetry/prometheus"
+)
+
+const maxMediaFileRequestSize = 64 * 1024
+
+var errInvalidMediaFile = errors.New("invalid media file request")
+
+type mediaFileTrack struct {
+	Name string `json:"name,omitempty"`
+	// name of a livekit.TrackSource, e. g. MICROPHONE
+	Source string `json:"source,omitempty"`
+	// relative to the media file directory
+	Path string `json:"path"`
+	// frame rate of Annex-B H264, which has no timing, 30 when not set
+	FrameRate float64 `json:"frame_rate,omitempty"`
+	Loop      bool    `json:"loop,omitempty"`
+	Width     uint32  `json:"width,omitempty"`
+	Height    uint32  `json:"height,omitempty"`
+}
+
+type mediaFilePublishRequest struct {
+	Room     string           `json:"room"`
+	Identity string           `json:"identity"`
+	Name     string           `json:"name,omitempty"`
+	Metadata string           `json:"metadata,omitempty"`
+	Tracks   []mediaFileTrack `json:"tracks"`
+}
+
+type mediaFilePublishedTrack struct {
+	SID      livekit.TrackID `json:"sid"`
+	Name     string          `json:"name,omitempty"`
+	MimeType string          `json:"mime_type"`
+}
+
+type mediaFilePublishResponse struct {
+	ParticipantSID livekit.ParticipantID     `json:"participant_sid"`
+	Tracks         []mediaFilePublishedTrack `json:"tracks"`
+}
+
+// MediaFileService lets the server publish media files it reads itself, e. g. for tests, hold music and
+// announcements. Files are published as tracks of a server-owned participant, paced as a real-time producer and
+// forwarded to subscribers as any other track. Supported files are Ogg/Opus (.ogg, .opus), IVF with VP8, VP9 or
+// AV1 (.ivf) and Annex-B H264 (.h264, .264).
+// Requests need a room admin token. A room which is not hosted yet is created on the node receiving them, requests
+// for a room hosted on another node are refused with 503.
+//
+// POST /media-files/publish: body is a JSON mediaFilePublishRequest, responds with the published tracks and the
+// participant resource in the Location header. The participant leaves when all files have played to the end,
+// looping files play until it is removed.
+// DELETE <resource>: removes the participant
+type MediaFileService struct {
+	conf        config.MediaFilesConfig
+	roomManager *RoomManager
+}
+
+func NewMediaFileService(conf *config.Config, roomManager *RoomManager) *MediaFileService {
+	return &MediaFileService{
+		conf:        conf.MediaFiles,
+		roomManager: roomManager,
+	}
+}
+
+func (s *MediaFileService) SetupRoutes(mux *http.ServeMux) {
+	if !s.conf.Enabled {
+		return
+	}
+	mux.HandleFunc("POST /media-files/publish", s.publish)
+	mux.HandleFunc("DELETE /media-files/publish/{room}/{participant}", s.unpublish)
+}
+
+func (s *MediaFileService) publish(w http.ResponseWriter, r *http.Request) {
+	if !hasContentType(r, jsonContentType) {
+		handleError(w, r, http.StatusUnsupportedMediaType, fmt.Errorf("content type must be %s", jsonContentType))
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMediaFileRequestSize))
+	if err != nil {
+		handleError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	pi, params, err := parseMediaFilePublishRequest(body, s.conf.Directory)
+	if err != nil {
+		handleError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	roomName := livekit.RoomName(pi.CreateRoom.GetName())
+	if err = EnsureAdminPermission(r.Context(), roomName); err != nil {
+		handleError(w, r, http.StatusUnauthorized, err)
+		return
+	}
+	for _, t := range params.Tracks {
+		if _, err = os.Stat(t.Path); err != nil {
+			handleError(w, r, http.StatusNotFound, fmt.Errorf("%w: %s", errInvalidMediaFile, filepath.Base(t.Path)))
+			return
+		}
+	}
+
+	// the participant outlives the request
+	room, lp, err := s.roomManager.StartOneShotSession(context.WithoutCancel(r.Context()), pi)
+	if err != nil {
+		prometheus.IncrementParticipantJoinFail(1)
+		handleError(w, r, oneShotSessionErrorStatus(err), err, "room", roomName, "participant", pi.Identity)
+		return
+	}
+
+	tracks, err := lp.PublishMediaFiles(params)
+	if err != nil {
+		prometheus.IncrementParticipantJoinFail(1)
+		room.RemoveParticipant(lp.Identity(), lp.ID(), types.ParticipantCloseReasonNegotiateFailed)
+		status := http.StatusBadRequest
+		if errors.Is(err, rtc.ErrPermissionDenied) {
+			status = http.StatusUnauthorized
+		}
+		handleError(w, r, status, err, "room", roomName, "participant", pi.Identity)
+		return
+	}
+	prometheus.IncrementParticipantJoin(1)
+
+	res := mediaFilePublishResponse{ParticipantSID: lp.ID()}
+	for _, ti := range tracks {
+		var mimeType string
+		if codecs := ti.GetCodecs(); len(codecs) != 0 {
+			mimeType = codecs[0].MimeType
+		}
+		res.Tracks = append(res.Tracks, mediaFilePublishedTrack{
+			SID:      livekit.TrackID(ti.Sid),
+			Name:     ti.Name,
+			MimeType: mimeType,
+		})
+	}
+
+	lp.GetLogger().Infow("media files published", "tracks", len(tracks))
+	w.Header().Set("Content-Type", jsonContentType)
+	w.Header().Set("Location", oneShotResourcePath("/media-files/publish", roomName, lp.ID()))
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func (s *MediaFileService) unpublish(w http.ResponseWriter, r *http.Request) {
+	roomName := livekit.RoomName(r.PathValue("room"))
+	pID := livekit.ParticipantID(r.PathValue("participant"))
+	if err := EnsureAdminPermission(r.Context(), roomName); err != nil {
+		handleError(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	room := s.roomManager.GetRoom(r.Context(), roomName)
+	if room == nil {
+		handleError(w, r, http.StatusNotFound, ErrRoomNotFound, "room", roomName)
+		return
+	}
+	lp := room.GetParticipantByID(pID)
+	if lp == nil {
+		handleError(w, r, http.StatusNotFound, ErrParticipantNotFound, "room", roomName, "participantID", pID)
+		return
+	}
+
+	lp.GetLogger().Infow("media files unpublished", "path", r.URL.Path)
+	room.RemoveParticipant(lp.Identity(), lp.ID(), types.ParticipantCloseReasonClientRequestLeave)
+	w.WriteHeader(http.StatusOK)
+}
+
+// parseMediaFilePublishRequest returns the participant publishing the files and its tracks,
+// paths are resolved in the media file directory and cannot point outside of it
+func parseMediaFilePublishRequest(body []byte, directory string) (routing.ParticipantInit, types.MediaFileParams, error) {
+	var req mediaFilePublishRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return routing.ParticipantInit{}, types.MediaFileParams{}, fmt.Errorf("%w: %w", errInvalidMediaFile, err)
+	}
+	if req.Room == "" || req.Identity == "" {
+		return routing.ParticipantInit{}, types.MediaFileParams{}, fmt.Errorf("%w: room and identity are required", errInvalidMediaFile)
+	}
+	if len(req.Tracks) == 0 {
+		return routing.ParticipantInit{}, types.MediaFileParams{}, fmt.Errorf("%w: no tracks", errInvalidMediaFile)
+	}
+
+	var params types.MediaFileParams
+	for _, t := range req.Tracks {
+		if !filepath.IsLocal(t.Path) {
+			return routing.ParticipantInit{}, types.MediaFileParams{}, fmt.Errorf("%w: invalid path %q", errInvalidMediaFile, t.Path)
+		}
+		if t.FrameRate < 0 {
+			return routing.ParticipantInit{}, types.MediaFileParams{}, fmt.Errorf("%w: invalid frame rate %v", errInvalidMediaFile, t.FrameRate)
+		}
+
+		source := livekit.TrackSource_UNKNOWN
+		if t.Source != "" {
+			value, ok := livekit.TrackSource_value[strings.ToUpper(t.Source)]
+			if !ok {
+				return routing.ParticipantInit{}, types.MediaFileParams{}, fmt.Errorf("%w: invalid source %q", errInvalidMediaFile, t.Source)
+			}
+			source = livekit.TrackSource(value)
+		}
+
+		params.Tracks = append(params.Tracks, types.MediaFileTrackParams{
+			Request: &livekit.AddTrackRequest{
+				Name:   t.Name,
+				Source: source,
+				Width:  t.Width,
+				Height: t.Height,
+			},
+			Path:      filepath.Join(directory, t.Path),
+			FrameRate: t.FrameRate,
+			Loop:      t.Loop,
+		})
+	}
+
+	canPublish, canSubscribe := true, false
+	pi := routing.ParticipantInit{
+		Identity: livekit.ParticipantIdentity(req.Identity),
+		Name:     livekit.ParticipantName(req.Name),
+		Client:   &livekit.ClientInfo{Protocol: types.CurrentProtocol},
+		Grants: &auth.ClaimGrants{
+			Identity: req.Identity,
+			Name:     req.Name,
+			Metadata: req.Metadata,
+			Video: &auth.VideoGrant{
+				Room:         req.Room,
+				RoomJoin:     true,
+				CanPublish:   &canPublish,
+				CanSubscribe: &canSubscribe,
+			},
+		},
+		CreateRoom: &livekit.CreateRoomRequest{Name: req.Room},
+	}
+	return pi, params, nil
+}
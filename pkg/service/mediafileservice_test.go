@@ -0,0 +1,67 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/livekit"
+)
+
+func TestParseMediaFilePublishRequest(t *testing.T) {
+	pi, params, err := parseMediaFilePublishRequest([]byte(`{
+		"room": "lobby",
+		"identity": "music",
+		"name": "Hold Music",
+		"tracks": [
+			{"name": "audio", "source": "microphone", "path": "hold/music.ogg", "loop": true},
+			{"name": "video", "source": "CAMERA", "path": "intro.h264", "frame_rate": 25, "width": 1280, "height": 720}
+		]
+	}`), "/media")
+	require.NoError(t, err)
+	require.Equal(t, livekit.ParticipantIdentity("music"), pi.Identity)
+	require.Equal(t, "lobby", pi.CreateRoom.Name)
+	require.Equal(t, "lobby", pi.Grants.Video.Room)
+	require.True(t, pi.Grants.Video.GetCanPublish())
+	require.False(t, pi.Grants.Video.GetCanSubscribe())
+	require.NotNil(t, pi.Client)
+
+	require.Len(t, params.Tracks, 2)
+	require.Equal(t, filepath.Join("/media", "hold", "music.ogg"), params.Tracks[0].Path)
+	require.Equal(t, livekit.TrackSource_MICROPHONE, params.Tracks[0].Request.Source)
+	require.True(t, params.Tracks[0].Loop)
+	require.Equal(t, livekit.TrackSource_CAMERA, params.Tracks[1].Request.Source)
+	require.Equal(t, uint32(1280), params.Tracks[1].Request.Width)
+	require.Equal(t, 25.0, params.Tracks[1].FrameRate)
+	require.False(t, params.Tracks[1].Loop)
+
+	for _, body := range []string{
+		`{}`,
+		`{"room": "lobby", "identity": "music"}`,
+		`{"identity": "music", "tracks": [{"path": "music.ogg"}]}`,
+		`{"room": "lobby", "identity": "music", "tracks": [{"path": "../secret.ogg"}]}`,
+		`{"room": "lobby", "identity": "music", "tracks": [{"path": "/etc/music.ogg"}]}`,
+		`{"room": "lobby", "identity": "music", "tracks": [{"path": ""}]}`,
+		`{"room": "lobby", "identity": "music", "tracks": [{"path": "music.ogg", "source": "LIDAR"}]}`,
+		`{"room": "lobby", "identity": "music", "tracks": [{"path": "intro.h264", "frame_rate": -1}]}`,
+		`not json`,
+	} {
+		_, _, err := parseMediaFilePublishRequest([]byte(body), "/media")
+		require.ErrorIs(t, err, errInvalidMediaFile, body)
+	}
+}
@@ -0,0 +1,516 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pion/srtp/v3"
+	"github.com/pion/webrtc/v4"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+
+	"github.com/livekit/livekit-server/pkg/config"
+	"github.com/livekit/livekit-server/pkg/rtc"
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	"github.com/livekit/livekit-server/pkg/telemetry/prometheus"
+)
+
+const maxPlainRTPRequestSize = 64 * 1024
+
+var (
+	errNoPlainRTPPort    = errors.New("no plain RTP port available")
+	errInvalidPlainRTP   = errors.New("invalid plain RTP request")
+	errNoPlainRTPForward = errors.New("plain RTP forward not found")
+	plainRTPSRTPProfile  = map[string]srtp.ProtectionProfile{
+		"AES_CM_128_HMAC_SHA1_80": srtp.ProtectionProfileAes128CmHmacSha1_80,
+		"AES_CM_128_HMAC_SHA1_32": srtp.ProtectionProfileAes128CmHmacSha1_32,
+		"AEAD_AES_128_GCM":        srtp.ProtectionProfileAeadAes128Gcm,
+		"AEAD_AES_256_GCM":        srtp.ProtectionProfileAeadAes256Gcm,
+	}
+)
+
+type plainRTPCodec struct {
+	MimeType    string `json:"mime_type"`
+	ClockRate   uint32 `json:"clock_rate"`
+	Channels    uint16 `json:"channels,omitempty"`
+	PayloadType uint8  `json:"payload_type"`
+	SDPFmtpLine string `json:"sdp_fmtp_line,omitempty"`
+}
+
+type plainRTPTrack struct {
+	Name string `json:"name,omitempty"`
+	// name of a livekit.TrackSource, e. g. CAMERA
+	Source string        `json:"source,omitempty"`
+	SSRC   uint32        `json:"ssrc"`
+	Codec  plainRTPCodec `json:"codec"`
+	Width  uint32        `json:"width,omitempty"`
+	Height uint32        `json:"height,omitempty"`
+}
+
+type plainRTPSRTP struct {
+	// one of AES_CM_128_HMAC_SHA1_80, AES_CM_128_HMAC_SHA1_32, AEAD_AES_128_GCM, AEAD_AES_256_GCM
+	Profile string `json:"profile"`
+	// master key followed by master salt
+	KeyMaterial []byte `json:"key"`
+}
+
+type plainRTPPublishRequest struct {
+	Tracks []plainRTPTrack `json:"tracks"`
+	SRTP   *plainRTPSRTP   `json:"srtp,omitempty"`
+	// host:port the producer sends from, learned from the first packet which authenticates or is of a declared stream when not set
+	RemoteAddress string `json:"remote_address,omitempty"`
+}
+
+type plainRTPPublishedTrack struct {
+	SID  livekit.TrackID `json:"sid"`
+	Name string          `json:"name,omitempty"`
+	SSRC uint32          `json:"ssrc"`
+}
+
+type plainRTPPublishResponse struct {
+	Address string                   `json:"address"`
+	Port    int                      `json:"port"`
+	Tracks  []plainRTPPublishedTrack `json:"tracks"`
+	// keys of RTCP sent by the server
+	SRTP *plainRTPSRTP `json:"srtp,omitempty"`
+}
+
+type plainRTPForwardRequest struct {
+	Room     string `json:"room"`
+	TrackSID string `json:"track_sid"`
+	// host:port RTP is sent to
+	Address string `json:"address"`
+	// name of a livekit.VideoQuality, the layer forwarded for simulcast video tracks, HIGH when not set
+	Quality string `json:"quality,omitempty"`
+	// keys of RTCP sent by the destination
+	SRTP *plainRTPSRTP `json:"srtp,omitempty"`
+}
+
+type plainRTPForwardResponse struct {
+	ID    string        `json:"id"`
+	SSRC  uint32        `json:"ssrc"`
+	Codec plainRTPCodec `json:"codec"`
+	// keys of RTP sent by the server
+	SRTP *plainRTPSRTP `json:"srtp,omitempty"`
+}
+
+type plainRTPEgress struct {
+	roomName livekit.RoomName
+	egress   *rtc.PlainEgress
+}
+
+// PlainRTPService lets server-side producers (e.g. ffmpeg, GStreamer) publish RTP into a room on a plain UDP socket,
+// without ICE and DTLS, optionally protected with SRTP keys exchanged in the request. Streams are declared upfront
+// with their SSRC and codec, and published as tracks of a publisher-only participant. RTP and RTCP are multiplexed on
+// the same port. Requests are authenticated with a join token. A room which is not hosted yet is created on the node
+// receiving them, requests for a room hosted on another node are refused with 503.
+//
+// POST /plain-rtp/publish: body is a JSON plainRTPPublishRequest, responds with where to send RTP to and the session
+// resource in the Location header
+// DELETE <resource>: ends the session, sessions also end when nothing is received for a while
+//
+// The reverse is also available, forwarding a published track to a UDP destination, e.g. broadcast gear or ffmpeg,
+// without going through the egress service. These requests need a room admin token.
+//
+// POST /plain-rtp/forward: body is a JSON plainRTPForwardRequest, responds with the SSRC and codec of the stream sent
+// and the forward resource in the Location header
+// DELETE <resource>: stops forwarding, it also stops when the track is unpublished
+type PlainRTPService struct {
+	conf        config.PlainRTPConfig
+	rtcConf     config.RTCConfig
+	rtcService  *RTCService
+	roomManager *RoomManager
+
+	egressLock sync.Mutex
+	egresses   map[string]plainRTPEgress
+}
+
+func NewPlainRTPService(conf *config.Config, rtcService *RTCService, roomManager *RoomManager) *PlainRTPService {
+	return &PlainRTPService{
+		conf:        conf.PlainRTP,
+		rtcConf:     conf.RTC,
+		rtcService:  rtcService,
+		roomManager: roomManager,
+		egresses:    make(map[string]plainRTPEgress),
+	}
+}
+
+func (s *PlainRTPService) SetupRoutes(mux *http.ServeMux) {
+	if !s.conf.Enabled {
+		return
+	}
+	mux.HandleFunc("POST /plain-rtp/publish", s.createSession)
+	mux.HandleFunc("DELETE /plain-rtp/publish/{room}/{participant}", s.deleteSession)
+	mux.HandleFunc("POST /plain-rtp/forward", s.createForward)
+	mux.HandleFunc("DELETE /plain-rtp/forward/{id}", s.deleteForward)
+}
+
+func (s *PlainRTPService) createSession(w http.ResponseWriter, r *http.Request) {
+	if !hasContentType(r, jsonContentType) {
+		handleError(w, r, http.StatusUnsupportedMediaType, fmt.Errorf("content type must be %s", jsonContentType))
+		return
+	}
+
+	roomName, pi, code, err := s.rtcService.validateInternal(r)
+	if err != nil {
+		handleError(w, r, code, err)
+		return
+	}
+	if !pi.Grants.Video.GetCanPublish() {
+		handleError(w, r, http.StatusUnauthorized, rtc.ErrPermissionDenied)
+		return
+	}
+	// publisher only, same as WHIP
+	pi.Grants.Video.SetCanSubscribe(false)
+	pi.AutoSubscribe = false
+	pi.Reconnect = false
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPlainRTPRequestSize))
+	if err != nil {
+		handleError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	params, err := parsePlainRTPPublishRequest(body)
+	if err != nil {
+		handleError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	conn, err := s.listen()
+	if err != nil {
+		handleError(w, r, http.StatusServiceUnavailable, err)
+		return
+	}
+	params.Conn = conn
+
+	// the session outlives the request
+	room, lp, err := s.roomManager.StartOneShotSession(context.WithoutCancel(r.Context()), pi)
+	if err != nil {
+		_ = conn.Close()
+		prometheus.IncrementParticipantJoinFail(1)
+		handleError(w, r, oneShotSessionErrorStatus(err), err, "room", roomName, "participant", pi.Identity)
+		return
+	}
+
+	info, err := lp.PublishPlainTransport(params)
+	if err != nil {
+		_ = conn.Close()
+		prometheus.IncrementParticipantJoinFail(1)
+		room.RemoveParticipant(lp.Identity(), lp.ID(), types.ParticipantCloseReasonNegotiateFailed)
+		status := http.StatusBadRequest
+		if errors.Is(err, rtc.ErrPermissionDenied) {
+			status = http.StatusUnauthorized
+		}
+		handleError(w, r, status, err, "room", roomName, "participant", pi.Identity)
+		return
+	}
+	prometheus.IncrementParticipantJoin(1)
+
+	res := plainRTPPublishResponse{
+		Address: s.rtcConf.NodeIP,
+		Port:    conn.LocalAddr().(*net.UDPAddr).Port,
+	}
+	for idx, ti := range info.Tracks {
+		res.Tracks = append(res.Tracks, plainRTPPublishedTrack{
+			SID:  livekit.TrackID(ti.Sid),
+			Name: ti.Name,
+			SSRC: params.Tracks[idx].SSRC,
+		})
+	}
+	if info.SRTP != nil {
+		res.SRTP = &plainRTPSRTP{
+			Profile:     plainRTPSRTPProfileName(info.SRTP.Profile),
+			KeyMaterial: info.SRTP.KeyMaterial,
+		}
+	}
+
+	lp.GetLogger().Infow("plain RTP session started", "port", res.Port)
+	w.Header().Set("Content-Type", jsonContentType)
+	w.Header().Set("Location", oneShotResourcePath("/plain-rtp/publish", roomName, lp.ID()))
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func (s *PlainRTPService) deleteSession(w http.ResponseWriter, r *http.Request) {
+	deleteOneShotSession(w, r, s.roomManager)
+}
+
+func (s *PlainRTPService) createForward(w http.ResponseWriter, r *http.Request) {
+	if !hasContentType(r, jsonContentType) {
+		handleError(w, r, http.StatusUnsupportedMediaType, fmt.Errorf("content type must be %s", jsonContentType))
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPlainRTPRequestSize))
+	if err != nil {
+		handleError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	roomName, trackID, params, err := parsePlainRTPForwardRequest(body)
+	if err != nil {
+		handleError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err = EnsureAdminPermission(r.Context(), roomName); err != nil {
+		handleError(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	room := s.roomManager.GetRoom(r.Context(), roomName)
+	if room == nil {
+		handleError(w, r, http.StatusNotFound, ErrRoomNotFound, "room", roomName)
+		return
+	}
+	var track types.MediaTrack
+	for _, p := range room.GetParticipants() {
+		if track = p.GetPublishedTrack(trackID); track != nil {
+			break
+		}
+	}
+	if track == nil {
+		handleError(w, r, http.StatusNotFound, ErrTrackNotFound, "room", roomName, "trackID", trackID)
+		return
+	}
+
+	conn, err := s.listen()
+	if err != nil {
+		handleError(w, r, http.StatusServiceUnavailable, err)
+		return
+	}
+	params.Track = track
+	params.Conn = conn
+	params.BufferFactory = room.GetBufferFactory()
+	params.MaxTrack = s.rtcConf.PacketBufferSizeAudio
+	if track.Kind() == livekit.TrackType_VIDEO {
+		params.MaxTrack = s.rtcConf.PacketBufferSizeVideo
+	}
+	params.Logger = logger.GetLogger().WithValues("room", roomName)
+
+	egress, err := rtc.NewPlainEgress(params)
+	if err != nil {
+		_ = conn.Close()
+		handleError(w, r, http.StatusBadRequest, err, "room", roomName, "trackID", trackID)
+		return
+	}
+	egress.OnClosed(func() {
+		s.egressLock.Lock()
+		delete(s.egresses, egress.ID())
+		s.egressLock.Unlock()
+	})
+	s.egressLock.Lock()
+	s.egresses[egress.ID()] = plainRTPEgress{roomName: roomName, egress: egress}
+	s.egressLock.Unlock()
+
+	if err = egress.Start(); err != nil {
+		s.closeForward(egress.ID())
+		handleError(w, r, http.StatusInternalServerError, err, "room", roomName, "trackID", trackID)
+		return
+	}
+
+	codec := egress.Codec()
+	res := plainRTPForwardResponse{
+		ID:   egress.ID(),
+		SSRC: egress.SSRC(),
+		Codec: plainRTPCodec{
+			MimeType:    codec.MimeType,
+			ClockRate:   codec.ClockRate,
+			Channels:    codec.Channels,
+			PayloadType: uint8(codec.PayloadType),
+			SDPFmtpLine: codec.SDPFmtpLine,
+		},
+	}
+	if keys := egress.LocalSRTP(); keys != nil {
+		res.SRTP = &plainRTPSRTP{
+			Profile:     plainRTPSRTPProfileName(keys.Profile),
+			KeyMaterial: keys.KeyMaterial,
+		}
+	}
+
+	w.Header().Set("Content-Type", jsonContentType)
+	w.Header().Set("Location", "/plain-rtp/forward/"+egress.ID())
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func (s *PlainRTPService) deleteForward(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	s.egressLock.Lock()
+	e, ok := s.egresses[id]
+	s.egressLock.Unlock()
+	if !ok {
+		handleError(w, r, http.StatusNotFound, errNoPlainRTPForward, "id", id)
+		return
+	}
+	if err := EnsureAdminPermission(r.Context(), e.roomName); err != nil {
+		handleError(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	s.closeForward(id)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *PlainRTPService) closeForward(id string) {
+	s.egressLock.Lock()
+	e, ok := s.egresses[id]
+	delete(s.egresses, id)
+	s.egressLock.Unlock()
+	if ok {
+		e.egress.Close()
+	}
+}
+
+// listen opens the socket of a plain transport, on a free port of the configured range
+func (s *PlainRTPService) listen() (*net.UDPConn, error) {
+	if s.conf.PortRangeStart == 0 || s.conf.PortRangeEnd < s.conf.PortRangeStart {
+		return net.ListenUDP("udp", &net.UDPAddr{})
+	}
+
+	numPorts := int(s.conf.PortRangeEnd-s.conf.PortRangeStart) + 1
+	offset := rand.IntN(numPorts)
+	for i := range numPorts {
+		port := int(s.conf.PortRangeStart) + (offset+i)%numPorts
+		if conn, err := net.ListenUDP("udp", &net.UDPAddr{Port: port}); err == nil {
+			return conn, nil
+		}
+	}
+	return nil, errNoPlainRTPPort
+}
+
+func parsePlainRTPPublishRequest(body []byte) (types.PlainTransportParams, error) {
+	var req plainRTPPublishRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return types.PlainTransportParams{}, fmt.Errorf("%w: %w", errInvalidPlainRTP, err)
+	}
+	if len(req.Tracks) == 0 {
+		return types.PlainTransportParams{}, fmt.Errorf("%w: no tracks", errInvalidPlainRTP)
+	}
+
+	var params types.PlainTransportParams
+	for _, t := range req.Tracks {
+		if t.SSRC == 0 || t.Codec.ClockRate == 0 {
+			return types.PlainTransportParams{}, fmt.Errorf("%w: ssrc and codec clock rate are required", errInvalidPlainRTP)
+		}
+		if !strings.HasPrefix(t.Codec.MimeType, "audio/") && !strings.HasPrefix(t.Codec.MimeType, "video/") {
+			return types.PlainTransportParams{}, fmt.Errorf("%w: invalid mime type %q", errInvalidPlainRTP, t.Codec.MimeType)
+		}
+
+		source := livekit.TrackSource_UNKNOWN
+		if t.Source != "" {
+			value, ok := livekit.TrackSource_value[strings.ToUpper(t.Source)]
+			if !ok {
+				return types.PlainTransportParams{}, fmt.Errorf("%w: invalid source %q", errInvalidPlainRTP, t.Source)
+			}
+			source = livekit.TrackSource(value)
+		}
+
+		params.Tracks = append(params.Tracks, types.PlainTrackParams{
+			Request: &livekit.AddTrackRequest{
+				Name:   t.Name,
+				Source: source,
+				Width:  t.Width,
+				Height: t.Height,
+			},
+			SSRC: t.SSRC,
+			Codec: webrtc.RTPCodecParameters{
+				RTPCodecCapability: webrtc.RTPCodecCapability{
+					MimeType:    t.Codec.MimeType,
+					ClockRate:   t.Codec.ClockRate,
+					Channels:    t.Codec.Channels,
+					SDPFmtpLine: t.Codec.SDPFmtpLine,
+				},
+				PayloadType: webrtc.PayloadType(t.Codec.PayloadType),
+			},
+		})
+	}
+
+	if req.SRTP != nil {
+		profile, ok := plainRTPSRTPProfile[req.SRTP.Profile]
+		if !ok {
+			return types.PlainTransportParams{}, fmt.Errorf("%w: unsupported SRTP profile %q", errInvalidPlainRTP, req.SRTP.Profile)
+		}
+		params.SRTP = &types.PlainTransportSRTP{
+			Profile:     profile,
+			KeyMaterial: req.SRTP.KeyMaterial,
+		}
+	}
+
+	if req.RemoteAddress != "" {
+		addr, err := net.ResolveUDPAddr("udp", req.RemoteAddress)
+		if err != nil {
+			return types.PlainTransportParams{}, fmt.Errorf("%w: %w", errInvalidPlainRTP, err)
+		}
+		params.RemoteAddr = addr
+	}
+	return params, nil
+}
+
+func parsePlainRTPForwardRequest(body []byte) (livekit.RoomName, livekit.TrackID, rtc.PlainEgressParams, error) {
+	var req plainRTPForwardRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return "", "", rtc.PlainEgressParams{}, fmt.Errorf("%w: %w", errInvalidPlainRTP, err)
+	}
+	if req.Room == "" || req.TrackSID == "" || req.Address == "" {
+		return "", "", rtc.PlainEgressParams{}, fmt.Errorf("%w: room, track_sid and address are required", errInvalidPlainRTP)
+	}
+
+	var params rtc.PlainEgressParams
+	addr, err := net.ResolveUDPAddr("udp", req.Address)
+	if err != nil {
+		return "", "", rtc.PlainEgressParams{}, fmt.Errorf("%w: %w", errInvalidPlainRTP, err)
+	}
+	params.RemoteAddr = addr
+
+	params.Quality = livekit.VideoQuality_HIGH
+	if req.Quality != "" {
+		value, ok := livekit.VideoQuality_value[strings.ToUpper(req.Quality)]
+		if !ok || livekit.VideoQuality(value) == livekit.VideoQuality_OFF {
+			return "", "", rtc.PlainEgressParams{}, fmt.Errorf("%w: invalid quality %q", errInvalidPlainRTP, req.Quality)
+		}
+		params.Quality = livekit.VideoQuality(value)
+	}
+
+	if req.SRTP != nil {
+		profile, ok := plainRTPSRTPProfile[req.SRTP.Profile]
+		if !ok {
+			return "", "", rtc.PlainEgressParams{}, fmt.Errorf("%w: unsupported SRTP profile %q", errInvalidPlainRTP, req.SRTP.Profile)
+		}
+		params.SRTP = &types.PlainTransportSRTP{
+			Profile:     profile,
+			KeyMaterial: req.SRTP.KeyMaterial,
+		}
+	}
+	return livekit.RoomName(req.Room), livekit.TrackID(req.TrackSID), params, nil
+}
+
+func plainRTPSRTPProfileName(profile srtp.ProtectionProfile) string {
+	for name, p := range plainRTPSRTPProfile {
+		if p == profile {
+			return name
+		}
+	}
+	return profile.String()
+}
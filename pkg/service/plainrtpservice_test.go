@@ -0,0 +1,94 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/pion/srtp/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/livekit"
+)
+
+func TestParsePlainRTPPublishRequest(t *testing.T) {
+	params, err := parsePlainRTPPublishRequest([]byte(`{
+		"tracks": [
+			{"name": "mic", "source": "microphone", "ssrc": 1111, "codec": {"mime_type": "audio/opus", "clock_rate": 48000, "channels": 2, "payload_type": 111}},
+			{"name": "cam", "source": "CAMERA", "ssrc": 2222, "width": 1280, "height": 720, "codec": {"mime_type": "video/H264", "clock_rate": 90000, "payload_type": 96, "sdp_fmtp_line": "packetization-mode=1"}}
+		],
+		"srtp": {"profile": "AES_CM_128_HMAC_SHA1_80", "key": "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwd"},
+		"remote_address": "192.0.2.1:5004"
+	}`))
+	require.NoError(t, err)
+	require.Len(t, params.Tracks, 2)
+	require.Equal(t, livekit.TrackSource_MICROPHONE, params.Tracks[0].Request.Source)
+	require.Equal(t, uint32(1111), params.Tracks[0].SSRC)
+	require.Equal(t, "audio/opus", params.Tracks[0].Codec.MimeType)
+	require.Equal(t, uint16(2), params.Tracks[0].Codec.Channels)
+	require.Equal(t, livekit.TrackSource_CAMERA, params.Tracks[1].Request.Source)
+	require.Equal(t, uint32(1280), params.Tracks[1].Request.Width)
+	require.Equal(t, "packetization-mode=1", params.Tracks[1].Codec.SDPFmtpLine)
+	require.EqualValues(t, 96, params.Tracks[1].Codec.PayloadType)
+	require.Equal(t, srtp.ProtectionProfileAes128CmHmacSha1_80, params.SRTP.Profile)
+	require.Len(t, params.SRTP.KeyMaterial, 30)
+	require.Equal(t, "192.0.2.1:5004", params.RemoteAddr.String())
+	require.Equal(t, "AES_CM_128_HMAC_SHA1_80", plainRTPSRTPProfileName(params.SRTP.Profile))
+
+	for _, body := range []string{
+		`{}`,
+		`{"tracks": [{"ssrc": 1, "codec": {"mime_type": "opus", "clock_rate": 48000}}]}`,
+		`{"tracks": [{"ssrc": 0, "codec": {"mime_type": "audio/opus", "clock_rate": 48000}}]}`,
+		`{"tracks": [{"ssrc": 1, "source": "LIDAR", "codec": {"mime_type": "audio/opus", "clock_rate": 48000}}]}`,
+		`{"tracks": [{"ssrc": 1, "codec": {"mime_type": "audio/opus", "clock_rate": 48000}}], "srtp": {"profile": "NULL"}}`,
+		`not json`,
+	} {
+		_, err := parsePlainRTPPublishRequest([]byte(body))
+		require.ErrorIs(t, err, errInvalidPlainRTP, body)
+	}
+}
+
+func TestParsePlainRTPForwardRequest(t *testing.T) {
+	roomName, trackID, params, err := parsePlainRTPForwardRequest([]byte(`{
+		"room": "studio",
+		"track_sid": "TR_cam",
+		"address": "192.0.2.1:5004",
+		"quality": "low",
+		"srtp": {"profile": "AEAD_AES_128_GCM", "key": "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwd"}
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, livekit.RoomName("studio"), roomName)
+	require.Equal(t, livekit.TrackID("TR_cam"), trackID)
+	require.Equal(t, "192.0.2.1:5004", params.RemoteAddr.String())
+	require.Equal(t, livekit.VideoQuality_LOW, params.Quality)
+	require.Equal(t, srtp.ProtectionProfileAeadAes128Gcm, params.SRTP.Profile)
+
+	_, _, params, err = parsePlainRTPForwardRequest([]byte(`{"room": "studio", "track_sid": "TR_mic", "address": "192.0.2.1:5004"}`))
+	require.NoError(t, err)
+	require.Equal(t, livekit.VideoQuality_HIGH, params.Quality)
+	require.Nil(t, params.SRTP)
+
+	for _, body := range []string{
+		`{}`,
+		`{"room": "studio", "track_sid": "TR_mic"}`,
+		`{"room": "studio", "track_sid": "TR_mic", "address": "nowhere"}`,
+		`{"room": "studio", "track_sid": "TR_mic", "address": "192.0.2.1:5004", "quality": "OFF"}`,
+		`{"room": "studio", "track_sid": "TR_mic", "address": "192.0.2.1:5004", "srtp": {"profile": "NULL"}}`,
+		`not json`,
+	} {
+		_, _, _, err := parsePlainRTPForwardRequest([]byte(body))
+		require.ErrorIs(t, err, errInvalidPlainRTP, body)
+	}
+}
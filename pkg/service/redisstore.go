@@ -16,6 +16,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -42,6 +43,8 @@ const (
 	// RoomsKey is hash of room_name => Room proto
 	RoomsKey        = "rooms"
 	RoomInternalKey = "room_internal"
+	// RoomPlacementKey is hash of room_name => RoomPlacementInfo json
+	RoomPlacementKey = "room_placement"
 
 	// EgressKey is a hash of egressID => egress info
 	EgressKey        = "egress"
@@ -57,6 +60,9 @@ const (
 	// RoomParticipantsPrefix is hash of participant_name => ParticipantInfo
 	RoomParticipantsPrefix = "room_participants:"
 
+	// ReaperLockKey holds the ID of the node reaping rooms of dead nodes
+	ReaperLockKey = "room_reaper_lock"
+
 	// RoomLockPrefix is a simple key containing a provided lock uid
 	RoomLockPrefix = "room_lock:"
 
@@ -238,6 +244,7 @@ func (s *RedisStore) DeleteRoom(ctx context.Context, roomName livekit.RoomName)
 	pp := s.rc.Pipeline()
 	pp.HDel(s.ctx, RoomsKey, string(roomName))
 	pp.HDel(s.ctx, RoomInternalKey, string(roomName))
+	pp.HDel(s.ctx, RoomPlacementKey, string(roomName))
 	pp.Del(s.ctx, RoomParticipantsPrefix+string(roomName))
 	pp.Del(s.ctx, AgentDispatchPrefix+string(roomName))
 	pp.Del(s.ctx, AgentJobPrefix+string(roomName))
@@ -246,6 +253,48 @@ func (s *RedisStore) DeleteRoom(ctx context.Context, roomName livekit.RoomName)
 	return err
 }
 
+func (s *RedisStore) StoreRoomPlacement(_ context.Context, roomName livekit.RoomName, placement *RoomPlacementInfo) error {
+	data, err := json.Marshal(placement)
+	if err != nil {
+		return err
+	}
+	return s.rc.HSet(s.ctx, RoomPlacementKey, string(roomName), data).Err()
+}
+
+func (s *RedisStore) LoadRoomPlacement(_ context.Context, roomName livekit.RoomName) (*RoomPlacementInfo, error) {
+	data, err := s.rc.HGet(s.ctx, RoomPlacementKey, string(roomName)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			err = ErrRoomNotFound
+		}
+		return nil, err
+	}
+
+	placement := &RoomPlacementInfo{}
+	if err = json.Unmarshal([]byte(data), placement); err != nil {
+		return nil, err
+	}
+	return placement, nil
+}
+
+func (s *RedisStore) LockReaper(_ context.Context, nodeID livekit.NodeID, duration time.Duration) (bool, error) {
+	locked, err := s.rc.SetNX(s.ctx, ReaperLockKey, string(nodeID), duration).Result()
+	if err != nil || locked {
+		return locked, err
+	}
+
+	holder, err := s.rc.Get(s.ctx, ReaperLockKey).Result()
+	if err == redis.Nil {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if holder != string(nodeID) {
+		return false, nil
+	}
+	return true, s.rc.Expire(s.ctx, ReaperLockKey, duration).Err()
+}
+
 func (s *RedisStore) LockRoom(_ context.Context, roomName livekit.RoomName, duration time.Duration) (string, error) {
 	token := guid.New("LOCK")
 	key := RoomLockPrefix + string(roomName)
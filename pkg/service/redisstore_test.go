@@ -161,6 +161,30 @@ func TestRoomLock(t *testing.T) {
 	})
 }
 
+func TestReaperLock(t *testing.T) {
+	ctx := context.Background()
+	rs := redisStore(t)
+	lockInterval := 50 * time.Millisecond
+
+	locked, err := rs.LockReaper(ctx, "ND_a", lockInterval)
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	// held by the first node, which renews it
+	locked, err = rs.LockReaper(ctx, "ND_b", lockInterval)
+	require.NoError(t, err)
+	require.False(t, locked)
+	locked, err = rs.LockReaper(ctx, "ND_a", lockInterval)
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	// taken over once expired
+	time.Sleep(lockInterval + 10*time.Millisecond)
+	locked, err = rs.LockReaper(ctx, "ND_b", lockInterval)
+	require.NoError(t, err)
+	require.True(t, locked)
+}
+
 func TestEgressStore(t *testing.T) {
 	ctx := context.Background()
 	rs := redisStore(t)
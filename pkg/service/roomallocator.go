@@ -19,6 +19,8 @@ import (
 	"errors"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/livekit/protocol/livekit"
 	"github.com/livekit/protocol/logger"
 	"github.com/livekit/protocol/utils"
@@ -28,6 +30,8 @@ import (
 	"github.com/livekit/livekit-server/pkg/config"
 	"github.com/livekit/livekit-server/pkg/routing"
 	"github.com/livekit/livekit-server/pkg/routing/selector"
+	"github.com/livekit/livekit-server/pkg/telemetry/prometheus"
+	"github.com/livekit/livekit-server/pkg/telemetry/tracing"
 )
 
 type StandardRoomAllocator struct {
@@ -131,45 +135,145 @@ func (r *StandardRoomAllocator) CreateRoom(ctx context.Context, req *livekit.Cre
 	return rm, internal, created, nil
 }
 
-func (r *StandardRoomAllocator) SelectRoomNode(ctx context.Context, roomName livekit.RoomName, nodeID livekit.NodeID) error {
+// reasons reported by SelectRoomNode when no node is selected for the room
+const (
+	nodeSelectionReasonExistingRoom  = "existing_room"
+	nodeSelectionReasonRequestedNode = "requested_node"
+)
+
+// SelectRoomNode assigns a node to the room if it is not hosted yet, nodeID is used when set.
+// Otherwise a node is selected, honoring placement constraints configured for roomPreset and the API key,
+// and preferring the region of the client creating the room when known.
+// It returns why the node hosting the room was selected.
+func (r *StandardRoomAllocator) SelectRoomNode(ctx context.Context, roomName livekit.RoomName, nodeID livekit.NodeID, roomPreset string, clientRegion string) (string, error) {
+	ctx, span := tracing.Start(ctx, "RoomAllocator.SelectRoomNode", trace.WithAttributes(
+		tracing.AttrRoomName.String(string(roomName)),
+	))
+	reason, err := r.selectRoomNode(ctx, roomName, nodeID, roomPreset, clientRegion)
+	tracing.EndSpan(span, err)
+	return reason, err
+}
+
+func (r *StandardRoomAllocator) selectRoomNode(ctx context.Context, roomName livekit.RoomName, nodeID livekit.NodeID, roomPreset string, clientRegion string) (string, error) {
 	// check if room already assigned
 	existing, err := r.router.GetNodeForRoom(ctx, roomName)
 	if !errors.Is(err, routing.ErrNotFound) && err != nil {
-		return err
+		return "", err
 	}
 
 	// if already assigned and still available, keep it on that node
 	if err == nil && selector.IsAvailable(existing) {
 		// if node hosting the room is full, deny entry
 		if selector.LimitsReached(r.config.Limit, existing.Stats) {
-			return routing.ErrNodeLimitReached
+			return "", routing.ErrNodeLimitReached
 		}
 
-		return nil
+		return nodeSelectionReasonExistingRoom, nil
 	}
 
 	// select a new node
+	info := &RoomPlacementInfo{
+		RoomPreset: roomPreset,
+		APIKey:     GetAPIKey(ctx),
+	}
+	reason := nodeSelectionReasonRequestedNode
 	if nodeID == "" {
 		nodes, err := r.router.ListNodes()
 		if err != nil {
-			return err
+			return "", err
 		}
 
-		node, err := r.selector.SelectNode(nodes)
+		placement, err := r.roomPlacement(roomName, info)
 		if err != nil {
-			return err
+			return "", err
+		}
+		placement.Region = clientRegion
+
+		var node *livekit.Node
+		node, reason, err = selector.SelectNodeForRoomWithReason(r.selector, nodes, placement)
+		if err != nil {
+			return "", err
 		}
 
 		nodeID = livekit.NodeID(node.Id)
 	}
 
-	logger.Infow("selected node for room", "room", roomName, "selectedNodeID", nodeID)
+	logger.Infow("selected node for room", "room", roomName, "selectedNodeID", nodeID, "reason", reason)
+	trace.SpanFromContext(ctx).SetAttributes(tracing.AttrNodeID.String(string(nodeID)))
+	if err = r.roomStore.StoreRoomPlacement(ctx, roomName, info); err != nil {
+		return "", err
+	}
 	err = r.router.SetNodeForRoom(ctx, roomName, nodeID)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
+	// counted once per placement, joining a hosted room is not a selection
+	prometheus.RecordNodeSelection(reason)
+	return reason, nil
+}
+
+// SelectMigrationNode picks a node other than fromNodeID to take over the room and assigns the room to it.
+// The node has to satisfy the constraints the room was originally placed with.
+func (r *StandardRoomAllocator) SelectMigrationNode(ctx context.Context, roomName livekit.RoomName, fromNodeID livekit.NodeID) (livekit.NodeID, error) {
+	nodes, err := r.router.ListNodes()
+	if err != nil {
+		return "", err
+	}
+
+	candidates := make([]*livekit.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if livekit.NodeID(node.Id) != fromNodeID {
+			candidates = append(candidates, node)
+		}
+	}
+
+	info, err := r.roomStore.LoadRoomPlacement(ctx, roomName)
+	if errors.Is(err, ErrRoomNotFound) || info == nil {
+		// placed without constraints being stored
+		info = &RoomPlacementInfo{}
+	} else if err != nil {
+		return "", err
+	}
+
+	placement, err := r.roomPlacement(roomName, info)
+	if err != nil {
+		return "", err
+	}
+
+	node, err := selector.SelectNodeForRoom(r.selector, candidates, placement)
+	if err != nil {
+		return "", err
+	}
+
+	nodeID := livekit.NodeID(node.Id)
+	logger.Infow("selected node for room migration", "room", roomName, "fromNodeID", fromNodeID, "selectedNodeID", nodeID)
+	if err = r.router.SetNodeForRoom(ctx, roomName, nodeID); err != nil {
+		return "", err
+	}
+	return nodeID, nil
+}
+
+func (r *StandardRoomAllocator) roomPlacement(roomName livekit.RoomName, info *RoomPlacementInfo) (selector.RoomPlacement, error) {
+	placement := selector.RoomPlacement{
+		RoomName: roomName,
+	}
+	if info.RoomPreset != "" {
+		conf := r.config.Room.Placement[info.RoomPreset]
+		placement.RequiredLabels = conf.RequiredLabels
+		placement.PreferredLabels = conf.PreferredLabels
+		placement.ExcludedRegions = append(placement.ExcludedRegions, conf.ExcludedRegions...)
+	}
+	if info.APIKey != "" {
+		placement.ExcludedRegions = append(placement.ExcludedRegions, r.config.NodeSelector.APIKeyExcludedRegions[info.APIKey]...)
+	}
+
+	nodeLabels, err := r.router.ListNodeLabels()
+	if err != nil {
+		return placement, err
+	}
+	placement.NodeLabels = nodeLabels
+	return placement, nil
 }
 
 func (r *StandardRoomAllocator) ValidateCreateRoom(ctx context.Context, roomName livekit.RoomName) error {
@@ -20,11 +20,13 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/livekit/protocol/auth"
 	"github.com/livekit/protocol/livekit"
 
 	"github.com/livekit/livekit-server/pkg/config"
 	"github.com/livekit/livekit-server/pkg/routing"
 	"github.com/livekit/livekit-server/pkg/routing/routingfakes"
+	"github.com/livekit/livekit-server/pkg/routing/selector"
 	"github.com/livekit/livekit-server/pkg/service"
 	"github.com/livekit/livekit-server/pkg/service/servicefakes"
 )
@@ -62,7 +64,7 @@ func SelectRoomNode(t *testing.T) {
 
 		ra, _ := newTestRoomAllocator(t, conf, node.Clone())
 
-		err = ra.SelectRoomNode(context.Background(), "low-limit-room", "")
+		_, err = ra.SelectRoomNode(context.Background(), "low-limit-room", "", "", "")
 		require.ErrorIs(t, err, routing.ErrNodeLimitReached)
 	})
 
@@ -80,11 +82,114 @@ func SelectRoomNode(t *testing.T) {
 
 		ra, _ := newTestRoomAllocator(t, conf, node.Clone())
 
-		err = ra.SelectRoomNode(context.Background(), "low-limit-room", "")
+		_, err = ra.SelectRoomNode(context.Background(), "low-limit-room", "", "", "")
 		require.ErrorIs(t, err, routing.ErrNodeLimitReached)
 	})
 }
 
+func TestSelectMigrationNode(t *testing.T) {
+	conf, err := config.NewConfig("", true, nil, nil)
+	require.NoError(t, err)
+
+	nodes := []*livekit.Node{
+		{Id: "draining", State: livekit.NodeState_SERVING},
+		{Id: "target", State: livekit.NodeState_SERVING},
+	}
+	router := &routingfakes.FakeRouter{}
+	router.ListNodesReturns(nodes, nil)
+
+	ra, err := service.NewRoomAllocator(conf, router, &servicefakes.FakeObjectStore{})
+	require.NoError(t, err)
+
+	t.Run("selects another node", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			nodeID, err := ra.SelectMigrationNode(context.Background(), "myroom", "draining")
+			require.NoError(t, err)
+			require.Equal(t, livekit.NodeID("target"), nodeID)
+		}
+
+		_, roomName, nodeID := router.SetNodeForRoomArgsForCall(router.SetNodeForRoomCallCount() - 1)
+		require.Equal(t, livekit.RoomName("myroom"), roomName)
+		require.Equal(t, livekit.NodeID("target"), nodeID)
+	})
+
+	t.Run("fails without another node", func(t *testing.T) {
+		router.ListNodesReturns(nodes[:1], nil)
+		_, err := ra.SelectMigrationNode(context.Background(), "myroom", "draining")
+		require.Error(t, err)
+	})
+}
+
+func TestSelectRoomNodeWithLabels(t *testing.T) {
+	conf, err := config.NewConfig("", true, nil, nil)
+	require.NoError(t, err)
+	conf.Room.Placement = map[string]config.RoomPlacementConfig{
+		"enterprise": {
+			RequiredLabels:  map[string]string{"tier": "premium"},
+			PreferredLabels: map[string]string{"hw": "highbw"},
+		},
+	}
+
+	router := &routingfakes.FakeRouter{}
+	router.GetNodeForRoomReturns(nil, routing.ErrNotFound)
+	router.ListNodesReturns([]*livekit.Node{
+		{Id: "standard", State: livekit.NodeState_SERVING},
+		{Id: "premium", State: livekit.NodeState_SERVING},
+		{Id: "premium-highbw", State: livekit.NodeState_SERVING},
+	}, nil)
+	router.ListNodeLabelsReturns(map[livekit.NodeID]map[string]string{
+		"premium":        {"tier": "premium"},
+		"premium-highbw": {"tier": "premium", "hw": "highbw"},
+	}, nil)
+
+	ra, err := service.NewRoomAllocator(conf, router, &servicefakes.FakeObjectStore{})
+	require.NoError(t, err)
+
+	t.Run("selects node with required and preferred labels", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			_, err := ra.SelectRoomNode(context.Background(), "myroom", "", "enterprise", "")
+			require.NoError(t, err)
+			_, _, nodeID := router.SetNodeForRoomArgsForCall(router.SetNodeForRoomCallCount() - 1)
+			require.Equal(t, livekit.NodeID("premium-highbw"), nodeID)
+		}
+	})
+
+	t.Run("fails without nodes with required labels", func(t *testing.T) {
+		router.ListNodeLabelsReturns(map[livekit.NodeID]map[string]string{
+			"premium": {"tier": "standard"},
+		}, nil)
+		_, err := ra.SelectRoomNode(context.Background(), "myroom", "", "enterprise", "")
+		require.ErrorIs(t, err, selector.ErrNoNodesWithLabels)
+	})
+}
+
+func TestSelectRoomNodeExcludedRegions(t *testing.T) {
+	conf, err := config.NewConfig("", true, nil, nil)
+	require.NoError(t, err)
+	conf.NodeSelector.APIKeyExcludedRegions = map[string][]string{
+		"eu-key": {"us-east"},
+	}
+
+	router := &routingfakes.FakeRouter{}
+	router.GetNodeForRoomReturns(nil, routing.ErrNotFound)
+	router.ListNodesReturns([]*livekit.Node{
+		{Id: "east", Region: "us-east", State: livekit.NodeState_SERVING},
+		{Id: "eu", Region: "eu", State: livekit.NodeState_SERVING},
+	}, nil)
+
+	ra, err := service.NewRoomAllocator(conf, router, &servicefakes.FakeObjectStore{})
+	require.NoError(t, err)
+
+	ctx := service.WithAPIKey(context.Background(), &auth.ClaimGrants{}, "eu-key")
+	for i := 0; i < 10; i++ {
+		reason, err := ra.SelectRoomNode(ctx, "myroom", "", "", "")
+		require.NoError(t, err)
+		require.Equal(t, selector.SelectionReasonSelected, reason)
+		_, _, nodeID := router.SetNodeForRoomArgsForCall(router.SetNodeForRoomCallCount() - 1)
+		require.Equal(t, livekit.NodeID("eu"), nodeID)
+	}
+}
+
 func newTestRoomAllocator(t *testing.T, conf *config.Config, node *livekit.Node) (service.RoomAllocator, *config.Config) {
 	store := &servicefakes.FakeObjectStore{}
 	store.LoadRoomReturns(nil, nil, service.ErrRoomNotFound)
@@ -96,3 +201,38 @@ func newTestRoomAllocator(t *testing.T, conf *config.Config, node *livekit.Node)
 	require.NoError(t, err)
 	return ra, conf
 }
+
+func TestSelectMigrationNodePlacement(t *testing.T) {
+	conf, err := config.NewConfig("", true, nil, nil)
+	require.NoError(t, err)
+	conf.Room.Placement = map[string]config.RoomPlacementConfig{
+		"enterprise": {
+			RequiredLabels:  map[string]string{"tier": "premium"},
+			ExcludedRegions: []string{"us-east"},
+		},
+	}
+
+	router := &routingfakes.FakeRouter{}
+	router.GetNodeForRoomReturns(nil, routing.ErrNotFound)
+	router.ListNodesReturns([]*livekit.Node{
+		{Id: "draining", Region: "eu", State: livekit.NodeState_SERVING},
+		{Id: "standard", Region: "eu", State: livekit.NodeState_SERVING},
+		{Id: "premium-east", Region: "us-east", State: livekit.NodeState_SERVING},
+	}, nil)
+	router.ListNodeLabelsReturns(map[livekit.NodeID]map[string]string{
+		"draining":     {"tier": "premium"},
+		"premium-east": {"tier": "premium"},
+	}, nil)
+
+	ra, err := service.NewRoomAllocator(conf, router, service.NewLocalStore())
+	require.NoError(t, err)
+
+	_, err = ra.SelectRoomNode(context.Background(), "myroom", "draining", "enterprise", "")
+	require.NoError(t, err)
+	require.Equal(t, 1, router.SetNodeForRoomCallCount())
+
+	// the only node with the required labels is in an excluded region
+	_, err = ra.SelectMigrationNode(context.Background(), "myroom", "draining")
+	require.Error(t, err)
+	require.Equal(t, 1, router.SetNodeForRoomCallCount())
+}
@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/exp/maps"
 
 	"github.com/livekit/livekit-server/pkg/agent"
@@ -41,10 +43,12 @@ import (
 	"github.com/livekit/livekit-server/pkg/clientconfiguration"
 	"github.com/livekit/livekit-server/pkg/config"
 	"github.com/livekit/livekit-server/pkg/routing"
+	"github.com/livekit/livekit-server/pkg/routing/selector"
 	"github.com/livekit/livekit-server/pkg/rtc"
 	"github.com/livekit/livekit-server/pkg/rtc/types"
 	"github.com/livekit/livekit-server/pkg/telemetry"
 	"github.com/livekit/livekit-server/pkg/telemetry/prometheus"
+	"github.com/livekit/livekit-server/pkg/telemetry/tracing"
 	"github.com/livekit/livekit-server/version"
 )
 
@@ -81,8 +85,11 @@ type RoomManager struct {
 	versionGenerator  utils.TimedVersionGenerator
 	turnAuthHandler   *TURNAuthHandler
 	bus               psrpc.MessageBus
+	geoIP             *GeoIPResolver
 
 	rooms map[livekit.RoomName]*rtc.Room
+	// state of participants in rooms migrated to this node, until they join
+	migratedParticipants map[livekit.RoomName]map[livekit.ParticipantIdentity]*livekit.ParticipantInfo
 
 	roomServers          utils.MultitonService[rpc.RoomTopic]
 	agentDispatchServers utils.MultitonService[rpc.RoomTopic]
@@ -108,6 +115,7 @@ func NewLocalRoomManager(
 	turnAuthHandler *TURNAuthHandler,
 	bus psrpc.MessageBus,
 	forwardStats *sfu.ForwardStats,
+	geoIP *GeoIPResolver,
 ) (*RoomManager, error) {
 	rtcConf, err := rtc.NewWebRTCConfig(conf)
 	if err != nil {
@@ -124,6 +132,7 @@ func NewLocalRoomManager(
 		telemetry:         telemetry,
 		clientConfManager: clientConfManager,
 		egressLauncher:    egressLauncher,
+		geoIP:             geoIP,
 		agentClient:       agentClient,
 		agentStore:        agentStore,
 		versionGenerator:  versionGenerator,
@@ -131,7 +140,8 @@ func NewLocalRoomManager(
 		bus:               bus,
 		forwardStats:      forwardStats,
 
-		rooms: make(map[livekit.RoomName]*rtc.Room),
+		rooms:                make(map[livekit.RoomName]*rtc.Room),
+		migratedParticipants: make(map[livekit.RoomName]map[livekit.ParticipantIdentity]*livekit.ParticipantInfo),
 
 		iceConfigCache: sutils.NewIceConfigCache[iceConfigCacheKey](0),
 
@@ -145,7 +155,7 @@ func NewLocalRoomManager(
 		},
 	}
 
-	r.roomManagerServer, err = rpc.NewTypedRoomManagerServer(r, bus, rpc.WithServerLogger(logger.GetLogger()), middleware.WithServerMetrics(rpc.PSRPCMetricsObserver{}), psrpc.WithServerChannelSize(conf.PSRPC.BufferSize))
+	r.roomManagerServer, err = rpc.NewTypedRoomManagerServer(r, bus, rpc.WithServerLogger(logger.GetLogger()), middleware.WithServerMetrics(rpc.PSRPCMetricsObserver{}), psrpc.WithServerChannelSize(conf.PSRPC.BufferSize), tracing.WithServerTracing())
 	if err != nil {
 		return nil, err
 	}
@@ -167,6 +177,7 @@ func (r *RoomManager) deleteRoom(ctx context.Context, roomName livekit.RoomName)
 	logger.Infow("deleting room state", "room", roomName)
 	r.lock.Lock()
 	delete(r.rooms, roomName)
+	delete(r.migratedParticipants, roomName)
 	r.lock.Unlock()
 
 	var err, err2 error
@@ -261,6 +272,62 @@ func (r *RoomManager) StartSession(
 	requestSource routing.MessageSource,
 	responseSink routing.MessageSink,
 	useOneShotSignallingMode bool,
+) error {
+	ctx, span := tracing.Start(ctx, "RoomManager.StartSession", trace.WithAttributes(
+		tracing.AttrRoomName.String(pi.CreateRoom.GetName()),
+		tracing.AttrParticipantIdentity.String(string(pi.Identity)),
+		attribute.Bool("livekit.reconnect", pi.Reconnect),
+	))
+	err := r.startSession(ctx, pi, requestSource, responseSink, useOneShotSignallingMode)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// StartOneShotSession starts the session of a participant negotiating its single peer connection with
+// one offer/answer exchange, e. g. a WHIP or WHEP client. There is no signal connection to relay, so a
+// room which is not hosted yet is placed on this node, and a room hosted on another node is refused.
+func (r *RoomManager) StartOneShotSession(ctx context.Context, pi routing.ParticipantInit) (*rtc.Room, types.LocalParticipant, error) {
+	roomName := livekit.RoomName(pi.CreateRoom.GetName())
+	if _, err := r.roomAllocator.SelectRoomNode(ctx, roomName, r.currentNode.NodeID(), pi.CreateRoom.GetRoomPreset(), pi.ClientRegion); err != nil {
+		return nil, nil, err
+	}
+
+	node, err := r.router.GetNodeForRoom(ctx, roomName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if livekit.NodeID(node.Id) != r.currentNode.NodeID() {
+		return nil, nil, routing.ErrIncorrectRTCNode
+	}
+
+	prometheus.IncrementParticipantRtcInit(1)
+
+	connID := livekit.ConnectionID(guid.New("CO_"))
+	requestSource := routing.NewDefaultMessageChannel(connID)
+	if err = r.StartSession(ctx, pi, requestSource, routing.NewNullMessageSink(connID), true); err != nil {
+		requestSource.Close()
+		return nil, nil, err
+	}
+
+	room := r.GetRoom(ctx, roomName)
+	if room == nil {
+		requestSource.Close()
+		return nil, nil, ErrRoomNotFound
+	}
+	participant := room.GetParticipant(pi.Identity)
+	if participant == nil {
+		requestSource.Close()
+		return nil, nil, ErrParticipantNotFound
+	}
+	return room, participant, nil
+}
+
+func (r *RoomManager) startSession(
+	ctx context.Context,
+	pi routing.ParticipantInit,
+	requestSource routing.MessageSource,
+	responseSink routing.MessageSink,
+	useOneShotSignallingMode bool,
 ) error {
 	sessionStartTime := time.Now()
 
@@ -282,6 +349,11 @@ func (r *RoomManager) StartSession(
 	// since this is used for TURN server credentials, we don't want to fail the request even if there's no TURN for the session
 	apiKey, _, _ := r.getFirstKeyPair()
 
+	// a new subscriber adds to the egress of the node, refuse it before replacing a participant with the same identity
+	if !pi.Reconnect && canSubscribe(pi) && selector.EgressBudgetExceeded(r.config.Limit, r.currentNode.Clone().Stats) {
+		return r.refuseOverBudgetSession(ctx, room, pi, responseSink)
+	}
+
 	participant := room.GetParticipant(pi.Identity)
 	if participant != nil {
 		// When reconnecting, it means WS has interrupted but underlying peer connection is still ok in this state,
@@ -374,7 +446,18 @@ func (r *RoomManager) StartSession(
 		return errors.New("could not restart participant")
 	}
 
+	// participants are in the configured region closest to them when they can be located
+	clientLoc := r.geoIP.Locate(pi.Client.GetAddress())
+	if clientLoc != nil && clientLoc.Region != "" {
+		pi.Region = clientLoc.Region
+	}
+
+	// participants of a room migrated to this node keep their SID, and the SIDs of the tracks they publish again
+	migrated := r.takeMigratedParticipant(room.Name(), pi.Identity)
 	sid := livekit.ParticipantID(guid.New(utils.ParticipantPrefix))
+	if migrated.GetSid() != "" {
+		sid = livekit.ParticipantID(migrated.Sid)
+	}
 	pLogger := rtc.LoggerWithParticipant(
 		rtc.LoggerWithRoom(logger.GetLogger(), room.Name(), room.ID()),
 		pi.Identity,
@@ -420,6 +503,24 @@ func (r *RoomManager) StartSession(
 	if pi.SubscriberAllowPause != nil {
 		subscriberAllowPause = *pi.SubscriberAllowPause
 	}
+	var signalRecorder *rtc.SignalRecorder
+	if r.config.SignalRecording.IsEnabledFor(string(pi.Identity)) {
+		signalRecorder, err = rtc.CreateSignalRecording(r.config.SignalRecording.Directory, rtc.SignalRecordSession{
+			Room:                     room.Name(),
+			Identity:                 pi.Identity,
+			SID:                      sid,
+			ProtocolVersion:          pv,
+			Client:                   pi.Client,
+			Grants:                   pi.Grants,
+			Reconnect:                pi.Reconnect,
+			UseOneShotSignallingMode: useOneShotSignallingMode,
+			UseSinglePeerConnection:  pi.UseSinglePeerConnection,
+		}, pLogger)
+		if err != nil {
+			// the session does not depend on the recording
+			pLogger.Warnw("could not create signal recording", err)
+		}
+	}
 	participant, err = rtc.NewParticipant(rtc.ParticipantParams{
 		Identity:                pi.Identity,
 		Name:                    pi.Name,
@@ -431,6 +532,7 @@ func (r *RoomManager) StartSession(
 		LimitConfig:             r.config.Limit,
 		ProtocolVersion:         pv,
 		SessionStartTime:        sessionStartTime,
+		TraceContext:            ctx,
 		Telemetry:               r.telemetry,
 		Trailer:                 room.Trailer(),
 		PLIThrottleConfig:       r.config.RTC.PLIThrottle,
@@ -465,13 +567,20 @@ func (r *RoomManager) StartSession(
 		ForwardStats:                 r.forwardStats,
 		MetricConfig:                 r.config.Metric,
 		UseOneShotSignallingMode:     useOneShotSignallingMode,
+		UseSinglePeerConnection:      pi.UseSinglePeerConnection,
 		DataChannelMaxBufferedAmount: r.config.RTC.DataChannelMaxBufferedAmount,
 		DatachannelSlowThreshold:     r.config.RTC.DatachannelSlowThreshold,
 		FireOnTrackBySdp:             true,
+		SignalRecorder:               signalRecorder,
+		MigratedTracks:               migrated.GetTracks(),
 	})
 	if err != nil {
+		signalRecorder.Close()
 		return err
 	}
+	if migrated != nil {
+		restoreMigratedParticipant(participant, migrated)
+	}
 	iceConfig := r.setIceConfig(room.Name(), participant)
 
 	// join room
@@ -486,7 +595,7 @@ func (r *RoomManager) StartSession(
 	}
 
 	participantTopic := rpc.FormatParticipantTopic(room.Name(), participant.Identity())
-	participantServer := must.Get(rpc.NewTypedParticipantServer(r, r.bus))
+	participantServer := must.Get(rpc.NewTypedParticipantServer(r, r.bus, tracing.WithServerTracing()))
 	killParticipantServer := r.participantServers.Replace(participantTopic, participantServer)
 	if err := participantServer.RegisterAllParticipantTopics(participantTopic); err != nil {
 		killParticipantServer()
@@ -512,12 +621,18 @@ func (r *RoomManager) StartSession(
 	persistRoomForParticipantCount(room.ToProto())
 
 	clientMeta := &livekit.AnalyticsClientMeta{Region: r.currentNode.Region(), Node: string(r.currentNode.NodeID())}
+	if clientLoc != nil {
+		clientLoc.UpdateClientMeta(clientMeta)
+	}
 	r.telemetry.ParticipantJoined(ctx, protoRoom, participant.ToProto(), pi.Client, clientMeta, true)
 	participant.OnClose(func(p types.LocalParticipant) {
 		killParticipantServer()
 
-		if err := r.roomStore.DeleteParticipant(ctx, room.Name(), p.Identity()); err != nil {
-			pLogger.Errorw("could not delete participant", err)
+		// when migrating, the stored participant is restored on the node the room moves to
+		if p.CloseReason() != types.ParticipantCloseReasonMigrationRequested {
+			if err := r.roomStore.DeleteParticipant(ctx, room.Name(), p.Identity()); err != nil {
+				pLogger.Errorw("could not delete participant", err)
+			}
 		}
 
 		// update room store with new numParticipants
@@ -539,6 +654,59 @@ func (r *RoomManager) StartSession(
 	return nil
 }
 
+func canSubscribe(pi routing.ParticipantInit) bool {
+	return pi.Grants != nil && pi.Grants.Video != nil && pi.Grants.Video.GetCanSubscribe()
+}
+
+// refuseOverBudgetSession turns away a subscriber joining while this node is out of egress budget.
+// When no one is in the room yet, it is moved to another node and the participant is asked to reconnect there.
+func (r *RoomManager) refuseOverBudgetSession(
+	ctx context.Context,
+	room *rtc.Room,
+	pi routing.ParticipantInit,
+	responseSink routing.MessageSink,
+) error {
+	relocated := false
+	if room.GetParticipantCount() == 0 {
+		if err := r.migrateRoom(ctx, room); err != nil {
+			room.Logger.Infow("could not relocate room over egress budget", "error", err)
+		} else {
+			relocated = true
+		}
+	}
+	room.Logger.Infow("refusing participant, node over egress budget",
+		"participant", pi.Identity,
+		"relocated", relocated,
+	)
+
+	var leave *livekit.LeaveRequest
+	pv := types.ProtocolVersion(pi.Client.Protocol)
+	if pv.SupportsRegionsInLeaveRequest() {
+		leave = &livekit.LeaveRequest{
+			Reason: livekit.DisconnectReason_JOIN_FAILURE,
+			Action: livekit.LeaveRequest_DISCONNECT,
+		}
+		if relocated {
+			leave.Reason = livekit.DisconnectReason_MIGRATION
+			leave.Action = livekit.LeaveRequest_RECONNECT
+		}
+	} else {
+		leave = &livekit.LeaveRequest{
+			CanReconnect: relocated,
+			Reason:       livekit.DisconnectReason_JOIN_FAILURE,
+		}
+		if relocated {
+			leave.Reason = livekit.DisconnectReason_MIGRATION
+		}
+	}
+	_ = responseSink.WriteMessage(&livekit.SignalResponse{
+		Message: &livekit.SignalResponse_Leave{
+			Leave: leave,
+		},
+	})
+	return rtc.ErrLimitExceeded
+}
+
 // create the actual room object, to be used on RTC node
 func (r *RoomManager) getOrCreateRoom(ctx context.Context, createRoom *livekit.CreateRoomRequest) (*rtc.Room, error) {
 	roomName := livekit.RoomName(createRoom.Name)
@@ -557,6 +725,13 @@ func (r *RoomManager) getOrCreateRoom(ctx context.Context, createRoom *livekit.C
 		return nil, err
 	}
 
+	var migration *rtc.RoomMigrationParams
+	var migratedParticipants map[livekit.ParticipantIdentity]*livekit.ParticipantInfo
+	if fromNodeID := migratedFromNode(ctx); fromNodeID != "" {
+		logger.Infow("taking over migrated room", "room", roomName, "fromNodeID", fromNodeID)
+		migration, migratedParticipants = r.loadMigratedRoomState(ctx, roomName)
+	}
+
 	r.lock.Lock()
 
 	currentRoom := r.rooms[roomName]
@@ -572,17 +747,17 @@ func (r *RoomManager) getOrCreateRoom(ctx context.Context, createRoom *livekit.C
 	}
 
 	// construct ice servers
-	newRoom := rtc.NewRoom(ri, internal, *r.rtcConfig, r.config.Room, &r.config.Audio, r.serverInfo, r.telemetry, r.agentClient, r.agentStore, r.egressLauncher)
+	newRoom := rtc.NewRoom(ri, internal, *r.rtcConfig, r.config.Room, &r.config.Audio, r.serverInfo, r.telemetry, r.agentClient, r.agentStore, r.egressLauncher, migration)
 
 	roomTopic := rpc.FormatRoomTopic(roomName)
-	roomServer := must.Get(rpc.NewTypedRoomServer(r, r.bus))
+	roomServer := must.Get(rpc.NewTypedRoomServer(r, r.bus, tracing.WithServerTracing()))
 	killRoomServer := r.roomServers.Replace(roomTopic, roomServer)
 	if err := roomServer.RegisterAllRoomTopics(roomTopic); err != nil {
 		killRoomServer()
 		r.lock.Unlock()
 		return nil, err
 	}
-	agentDispatchServer := must.Get(rpc.NewTypedAgentDispatchInternalServer(r, r.bus))
+	agentDispatchServer := must.Get(rpc.NewTypedAgentDispatchInternalServer(r, r.bus, tracing.WithServerTracing()))
 	killDispServer := r.agentDispatchServers.Replace(roomTopic, agentDispatchServer)
 	if err := agentDispatchServer.RegisterAllRoomTopics(roomTopic); err != nil {
 		killRoomServer()
@@ -596,6 +771,19 @@ func (r *RoomManager) getOrCreateRoom(ctx context.Context, createRoom *livekit.C
 		killDispServer()
 
 		roomInfo := newRoom.ToProto()
+		if newRoom.CloseReason() == types.ParticipantCloseReasonMigrationRequested {
+			// the room continues on another node, which owns its state now
+			r.lock.Lock()
+			if r.rooms[roomName] == newRoom {
+				delete(r.rooms, roomName)
+			}
+			r.lock.Unlock()
+
+			prometheus.RoomEnded(time.Unix(roomInfo.CreationTime, 0))
+			newRoom.Logger.Infow("room migrated")
+			return
+		}
+
 		r.telemetry.RoomEnded(ctx, roomInfo)
 		prometheus.RoomEnded(time.Unix(roomInfo.CreationTime, 0))
 		if err := r.deleteRoom(ctx, roomName); err != nil {
@@ -620,6 +808,12 @@ func (r *RoomManager) getOrCreateRoom(ctx context.Context, createRoom *livekit.C
 	})
 
 	r.rooms[roomName] = newRoom
+	if migratedParticipants != nil {
+		r.migratedParticipants[roomName] = migratedParticipants
+		time.AfterFunc(migratedParticipantTimeout, func() {
+			r.expireMigratedParticipants(newRoom)
+		})
+	}
 
 	r.lock.Unlock()
 
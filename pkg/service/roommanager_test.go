@@ -0,0 +1,69 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/routing"
+	"github.com/livekit/livekit-server/pkg/routing/routingfakes"
+	"github.com/livekit/livekit-server/pkg/rtc"
+)
+
+func TestStartSessionEgressBudget(t *testing.T) {
+	subscribe := true
+	newParticipantInit := func(subscribe *bool) routing.ParticipantInit {
+		return routing.ParticipantInit{
+			Identity:   "viewer",
+			Grants:     &auth.ClaimGrants{Video: &auth.VideoGrant{Room: "lobby", RoomJoin: true, CanSubscribe: subscribe}},
+			Client:     &livekit.ClientInfo{Protocol: 15},
+			CreateRoom: &livekit.CreateRoomRequest{Name: "lobby"},
+		}
+	}
+
+	t.Run("subscriber is refused and room relocated", func(t *testing.T) {
+		allocator := newTestMigrationAllocator()
+		rm := newTestMigrationRoomManager(t, &routingfakes.FakeRouter{}, allocator, NewLocalStore())
+		rm.config.Limit.EgressBytesPerSec = 1000
+		rm.currentNode.(*routing.LocalNodeImpl).SetStats(&livekit.NodeStats{
+			NumClients:     2,
+			BytesOutPerSec: 900,
+		})
+
+		sink := &routingfakes.FakeMessageSink{}
+		err := rm.StartSession(context.Background(), newParticipantInit(&subscribe), &routingfakes.FakeMessageSource{}, sink, false)
+		require.ErrorIs(t, err, rtc.ErrLimitExceeded)
+
+		require.Len(t, allocator.fromNodeIDs, 1)
+		require.Equal(t, 1, sink.WriteMessageCallCount())
+		leave := sink.WriteMessageArgsForCall(0).(*livekit.SignalResponse).GetLeave()
+		require.Equal(t, livekit.DisconnectReason_MIGRATION, leave.Reason)
+		require.Equal(t, livekit.LeaveRequest_RECONNECT, leave.Action)
+	})
+
+	t.Run("only subscribers count against the budget", func(t *testing.T) {
+		noSubscribe := false
+		require.True(t, canSubscribe(newParticipantInit(nil)))
+		require.True(t, canSubscribe(newParticipantInit(&subscribe)))
+		require.False(t, canSubscribe(newParticipantInit(&noSubscribe)))
+		require.False(t, canSubscribe(routing.ParticipantInit{}))
+	})
+}
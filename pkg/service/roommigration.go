@@ -0,0 +1,175 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+	"github.com/livekit/psrpc/pkg/metadata"
+
+	"github.com/livekit/livekit-server/pkg/rtc"
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+)
+
+const (
+	// set on the CreateRoom request sent to the node taking over a room
+	migrateFromNodeMetadataKey = "lk-migrate-from-node"
+
+	// state of participants which have not joined the new node by then is discarded
+	migratedParticipantTimeout = time.Minute
+)
+
+// MigrateRooms moves rooms hosted on this node to other nodes, it is used when draining the node.
+// The room, its participants and agent dispatches are persisted to the store, a target node is
+// selected to host the room and participants are asked to reconnect, which brings them to the
+// target node. Rooms that cannot be migrated stay on this node.
+//
+// Participants do a full reconnect, not a resume: they join the target node as new sessions and publish their
+// tracks again, so media is interrupted for the time of a reconnect. They keep their participant SID, tracks
+// published again with the same name, type and source keep their SID, and name, metadata, attributes and
+// permissions set while in the room are restored on the target node.
+func (r *RoomManager) MigrateRooms(ctx context.Context) {
+	r.lock.RLock()
+	rooms := maps.Values(r.rooms)
+	r.lock.RUnlock()
+
+	for _, room := range rooms {
+		if err := r.migrateRoom(ctx, room); err != nil {
+			room.Logger.Warnw("could not migrate room", err)
+		}
+	}
+}
+
+func (r *RoomManager) migrateRoom(ctx context.Context, room *rtc.Room) error {
+	if room.IsClosed() {
+		return nil
+	}
+
+	// persist latest state, the target node loads it when creating the room
+	roomName := room.Name()
+	if err := r.roomStore.StoreRoom(ctx, room.ToProto(), room.Internal()); err != nil {
+		return err
+	}
+	for _, p := range room.GetParticipants() {
+		if err := r.roomStore.StoreParticipant(ctx, roomName, p.ToProto()); err != nil {
+			return err
+		}
+	}
+
+	currentNodeID := r.currentNode.NodeID()
+	nodeID, err := r.roomAllocator.SelectMigrationNode(ctx, roomName, currentNodeID)
+	if err != nil {
+		return err
+	}
+
+	room.Logger.Infow("migrating room", "toNodeID", nodeID, "numParticipants", room.GetParticipantCount())
+	createCtx := metadata.AppendMetadataToOutgoingContext(ctx, migrateFromNodeMetadataKey, string(currentNodeID))
+	if _, err = r.router.CreateRoom(createCtx, &livekit.CreateRoomRequest{Name: string(roomName)}); err != nil {
+		// keep serving the room from this node
+		if err := r.router.SetNodeForRoom(ctx, roomName, currentNodeID); err != nil {
+			room.Logger.Errorw("could not restore node for room", err)
+		}
+		return err
+	}
+
+	room.Close(types.ParticipantCloseReasonMigrationRequested)
+	return nil
+}
+
+// migratedFromNode returns the node a room is being migrated from, when ctx is of a CreateRoom request sent by MigrateRooms
+func migratedFromNode(ctx context.Context) livekit.NodeID {
+	head := metadata.IncomingHeader(ctx)
+	if head == nil {
+		return ""
+	}
+	return livekit.NodeID(head.Metadata[migrateFromNodeMetadataKey])
+}
+
+// loadMigratedRoomState returns agent dispatches and participants persisted by the node the room migrated from
+func (r *RoomManager) loadMigratedRoomState(
+	ctx context.Context,
+	roomName livekit.RoomName,
+) (*rtc.RoomMigrationParams, map[livekit.ParticipantIdentity]*livekit.ParticipantInfo) {
+	migration := &rtc.RoomMigrationParams{}
+	if r.agentStore != nil {
+		stored, err := r.agentStore.ListAgentDispatches(ctx, roomName)
+		if err != nil {
+			logger.Warnw("could not load agent dispatches of migrated room", err, "room", roomName)
+		}
+		migration.AgentDispatches = stored
+	}
+
+	participants := make(map[livekit.ParticipantIdentity]*livekit.ParticipantInfo)
+	stored, err := r.roomStore.ListParticipants(ctx, roomName)
+	if err != nil {
+		logger.Warnw("could not load participants of migrated room", err, "room", roomName)
+	}
+	for _, pi := range stored {
+		participants[livekit.ParticipantIdentity(pi.Identity)] = pi
+	}
+
+	return migration, participants
+}
+
+// takeMigratedParticipant returns the state a participant had before its room migrated to this node, only once
+func (r *RoomManager) takeMigratedParticipant(roomName livekit.RoomName, identity livekit.ParticipantIdentity) *livekit.ParticipantInfo {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	pi := r.migratedParticipants[roomName][identity]
+	if pi != nil {
+		delete(r.migratedParticipants[roomName], identity)
+	}
+	return pi
+}
+
+// expireMigratedParticipants removes stored participants which did not follow their room to this node
+func (r *RoomManager) expireMigratedParticipants(room *rtc.Room) {
+	r.lock.Lock()
+	participants := r.migratedParticipants[room.Name()]
+	delete(r.migratedParticipants, room.Name())
+	r.lock.Unlock()
+
+	for identity := range participants {
+		if room.GetParticipant(identity) != nil {
+			continue
+		}
+		if err := r.roomStore.DeleteParticipant(context.Background(), room.Name(), identity); err != nil {
+			room.Logger.Warnw("could not delete migrated participant", err, "participant", identity)
+		}
+	}
+}
+
+// restoreMigratedParticipant applies updates made to a participant while it was on the previous node,
+// they are not part of the token it joins with
+func restoreMigratedParticipant(participant types.LocalParticipant, pi *livekit.ParticipantInfo) {
+	if pi.Name != "" {
+		participant.SetName(pi.Name)
+	}
+	if pi.Metadata != "" {
+		participant.SetMetadata(pi.Metadata)
+	}
+	if len(pi.Attributes) != 0 {
+		participant.SetAttributes(pi.Attributes)
+	}
+	if pi.Permission != nil {
+		participant.SetPermission(pi.Permission)
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/utils"
+	"github.com/livekit/psrpc"
+	"github.com/livekit/psrpc/pkg/metadata"
+
+	"github.com/livekit/livekit-server/pkg/clientconfiguration"
+	"github.com/livekit/livekit-server/pkg/config"
+	"github.com/livekit/livekit-server/pkg/routing"
+	"github.com/livekit/livekit-server/pkg/routing/routingfakes"
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	"github.com/livekit/livekit-server/pkg/telemetry/telemetryfakes"
+)
+
+func newTestMigrationRoomManager(t *testing.T, router routing.Router, allocator RoomAllocator, store interface {
+	ObjectStore
+	AgentStore
+}) *RoomManager {
+	conf, err := config.NewConfig("", true, nil, nil)
+	require.NoError(t, err)
+	// disable mux, it doesn't play too well with unit test
+	conf.RTC.TCPPort = 0
+
+	node, err := routing.NewLocalNode(conf)
+	require.NoError(t, err)
+
+	rm, err := NewLocalRoomManager(
+		conf,
+		store,
+		node,
+		router,
+		allocator,
+		&telemetryfakes.FakeTelemetryService{},
+		clientconfiguration.NewStaticClientConfigurationManager(nil),
+		nil,
+		store,
+		nil,
+		utils.NewDefaultTimedVersionGenerator(),
+		nil,
+		psrpc.NewLocalMessageBus(),
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+	t.Cleanup(rm.Stop)
+	return rm
+}
+
+// testMigrationAllocator creates rooms and selects a migration target, servicefakes cannot be used from this package
+type testMigrationAllocator struct {
+	RoomAllocator
+
+	targetNodeID livekit.NodeID
+	targetErr    error
+	fromNodeIDs  []livekit.NodeID
+}
+
+func newTestMigrationAllocator() *testMigrationAllocator {
+	return &testMigrationAllocator{targetNodeID: "ND_target"}
+}
+
+func (a *testMigrationAllocator) CreateRoom(_ context.Context, req *livekit.CreateRoomRequest, _ bool) (*livekit.Room, *livekit.RoomInternal, bool, error) {
+	return &livekit.Room{Sid: "RM_migrating", Name: req.Name}, &livekit.RoomInternal{}, true, nil
+}
+
+func (a *testMigrationAllocator) SelectMigrationNode(_ context.Context, _ livekit.RoomName, fromNodeID livekit.NodeID) (livekit.NodeID, error) {
+	a.fromNodeIDs = append(a.fromNodeIDs, fromNodeID)
+	return a.targetNodeID, a.targetErr
+}
+
+func TestMigrateRooms(t *testing.T) {
+	t.Run("room moves to selected node", func(t *testing.T) {
+		router := &routingfakes.FakeRouter{}
+		allocator := newTestMigrationAllocator()
+		store := NewLocalStore()
+		rm := newTestMigrationRoomManager(t, router, allocator, store)
+
+		room, err := rm.getOrCreateRoom(context.Background(), &livekit.CreateRoomRequest{Name: "lobby"})
+		require.NoError(t, err)
+		room.Release()
+
+		rm.MigrateRooms(context.Background())
+
+		require.Equal(t, []livekit.NodeID{rm.currentNode.NodeID()}, allocator.fromNodeIDs)
+
+		// the target node creates the room, knowing where it comes from
+		require.Equal(t, 1, router.CreateRoomCallCount())
+		ctx, req := router.CreateRoomArgsForCall(0)
+		require.Equal(t, "lobby", req.Name)
+		require.Equal(t, string(rm.currentNode.NodeID()), metadata.OutgoingContextMetadata(ctx)[migrateFromNodeMetadataKey])
+
+		// state is left in the store for the target node
+		stored, _, err := store.LoadRoom(context.Background(), "lobby", false)
+		require.NoError(t, err)
+		require.Equal(t, "RM_migrating", stored.Sid)
+
+		require.True(t, room.IsClosed())
+		require.Equal(t, types.ParticipantCloseReasonMigrationRequested, room.CloseReason())
+		require.Nil(t, rm.GetRoom(context.Background(), "lobby"))
+		require.Zero(t, router.SetNodeForRoomCallCount())
+	})
+
+	t.Run("room stays when target node fails", func(t *testing.T) {
+		router := &routingfakes.FakeRouter{}
+		router.CreateRoomReturns(nil, errors.New("unavailable"))
+		allocator := newTestMigrationAllocator()
+		rm := newTestMigrationRoomManager(t, router, allocator, NewLocalStore())
+
+		room, err := rm.getOrCreateRoom(context.Background(), &livekit.CreateRoomRequest{Name: "lobby"})
+		require.NoError(t, err)
+		room.Release()
+
+		rm.MigrateRooms(context.Background())
+
+		require.False(t, room.IsClosed())
+		require.Equal(t, room, rm.GetRoom(context.Background(), "lobby"))
+		require.Equal(t, 1, router.SetNodeForRoomCallCount())
+		_, roomName, nodeID := router.SetNodeForRoomArgsForCall(0)
+		require.Equal(t, livekit.RoomName("lobby"), roomName)
+		require.Equal(t, rm.currentNode.NodeID(), nodeID)
+	})
+
+	t.Run("room stays without target node", func(t *testing.T) {
+		router := &routingfakes.FakeRouter{}
+		allocator := newTestMigrationAllocator()
+		allocator.targetNodeID, allocator.targetErr = "", routing.ErrNodeNotFound
+		rm := newTestMigrationRoomManager(t, router, allocator, NewLocalStore())
+
+		room, err := rm.getOrCreateRoom(context.Background(), &livekit.CreateRoomRequest{Name: "lobby"})
+		require.NoError(t, err)
+		room.Release()
+
+		rm.MigrateRooms(context.Background())
+
+		require.False(t, room.IsClosed())
+		require.Zero(t, router.CreateRoomCallCount())
+	})
+
+	t.Run("target node restores state", func(t *testing.T) {
+		store := NewLocalStore()
+		require.NoError(t, store.StoreParticipant(context.Background(), "lobby", &livekit.ParticipantInfo{
+			Sid:      "PA_alice",
+			Identity: "alice",
+			Metadata: "updated",
+		}))
+		require.NoError(t, store.StoreAgentDispatch(context.Background(), &livekit.AgentDispatch{
+			Id:        "AD_test",
+			AgentName: "agent",
+			Room:      "lobby",
+		}))
+		rm := newTestMigrationRoomManager(t, &routingfakes.FakeRouter{}, newTestMigrationAllocator(), store)
+
+		ctx := metadata.NewContextWithIncomingHeader(context.Background(), &metadata.Header{
+			Metadata: map[string]string{migrateFromNodeMetadataKey: "ND_source"},
+		})
+		room, err := rm.getOrCreateRoom(ctx, &livekit.CreateRoomRequest{Name: "lobby"})
+		require.NoError(t, err)
+		defer room.Release()
+
+		dispatches, err := room.GetAgentDispatches("")
+		require.NoError(t, err)
+		require.Len(t, dispatches, 1)
+		require.Equal(t, "AD_test", dispatches[0].Id)
+
+		pi := rm.takeMigratedParticipant("lobby", "alice")
+		require.NotNil(t, pi)
+		require.Equal(t, "updated", pi.Metadata)
+		// only restored once
+		require.Nil(t, rm.takeMigratedParticipant("lobby", "alice"))
+	})
+
+	t.Run("participant keeps its SID", func(t *testing.T) {
+		store := NewLocalStore()
+		require.NoError(t, store.StoreParticipant(context.Background(), "lobby", &livekit.ParticipantInfo{
+			Sid:      "PA_alice",
+			Identity: "alice",
+			Metadata: "updated",
+		}))
+		rm := newTestMigrationRoomManager(t, &routingfakes.FakeRouter{}, newTestMigrationAllocator(), store)
+
+		ctx := metadata.NewContextWithIncomingHeader(context.Background(), &metadata.Header{
+			Metadata: map[string]string{migrateFromNodeMetadataKey: "ND_source"},
+		})
+		room, err := rm.getOrCreateRoom(ctx, &livekit.CreateRoomRequest{Name: "lobby"})
+		require.NoError(t, err)
+		defer room.Release()
+
+		grants := &auth.ClaimGrants{Identity: "alice", Video: &auth.VideoGrant{RoomJoin: true, Room: "lobby"}}
+		connID := livekit.ConnectionID("CO_alice")
+		requestSource := routing.NewDefaultMessageChannel(connID)
+		defer requestSource.Close()
+		require.NoError(t, rm.StartSession(context.Background(), routing.ParticipantInit{
+			Identity:   "alice",
+			Client:     &livekit.ClientInfo{Protocol: 15},
+			Grants:     grants,
+			CreateRoom: &livekit.CreateRoomRequest{Name: "lobby"},
+		}, requestSource, routing.NewNullMessageSink(connID), false))
+
+		p := room.GetParticipant("alice")
+		require.NotNil(t, p)
+		require.Equal(t, livekit.ParticipantID("PA_alice"), p.ID())
+		require.Equal(t, "updated", p.ToProto().Metadata)
+	})
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+
+	"github.com/livekit/livekit-server/pkg/routing"
+)
+
+const (
+	deadNodeReapInterval = 30 * time.Second
+	roomReapLockDuration = 5 * time.Second
+	// held by the reaping node while it is alive, another node takes over when it expires
+	reaperLockDuration = 2 * deadNodeReapInterval
+
+	// nodes refresh their stats every few seconds, a node that stopped for that long is considered dead
+	deadNodeTimeout = 30 * time.Second
+)
+
+// ReaperLocker is implemented by stores shared between nodes, electing the node which reaps rooms of dead nodes
+type ReaperLocker interface {
+	// LockReaper acquires or renews the lock for nodeID, returning false when another node holds it
+	LockReaper(ctx context.Context, nodeID livekit.NodeID, duration time.Duration) (bool, error)
+}
+
+// ReapDeadNodeRooms cleans up rooms whose node is gone, i. e. crashed without closing them.
+// Their state is removed so that they can be recreated on another node, and the webhooks
+// their node did not get to send are sent. Only the node holding the reaper lock of the store reaps,
+// stores which are not shared between nodes do not have rooms of other nodes.
+// Rooms are also locked while reaped, so that each is reaped only once.
+func (r *RoomManager) ReapDeadNodeRooms(ctx context.Context) {
+	locker, ok := r.roomStore.(ReaperLocker)
+	if !ok {
+		return
+	}
+	if locked, err := locker.LockReaper(ctx, r.currentNode.NodeID(), reaperLockDuration); err != nil {
+		logger.Warnw("could not lock room reaper", err)
+		return
+	} else if !locked {
+		return
+	}
+
+	rooms, err := r.roomStore.ListRooms(ctx, nil)
+	if err != nil {
+		logger.Warnw("could not list rooms to reap", err)
+		return
+	}
+
+	for _, room := range rooms {
+		roomName := livekit.RoomName(room.Name)
+		if !r.isRoomNodeGone(ctx, roomName) {
+			continue
+		}
+		if err := r.reapRoom(ctx, roomName); err != nil {
+			logger.Warnw("could not reap room", err, "room", roomName)
+		}
+	}
+}
+
+// isRoomNodeGone checks if the node hosting a room is no longer registered or stopped updating its stats.
+// Rooms not assigned to a node are not hosted anywhere yet, e. g. created but not joined.
+func (r *RoomManager) isRoomNodeGone(ctx context.Context, roomName livekit.RoomName) bool {
+	node, err := r.router.GetNodeForRoom(ctx, roomName)
+	if err != nil {
+		return errors.Is(err, routing.ErrNodeNotFound)
+	}
+	return node.Stats != nil && time.Since(time.Unix(node.Stats.UpdatedAt, 0)) > deadNodeTimeout
+}
+
+func (r *RoomManager) reapRoom(ctx context.Context, roomName livekit.RoomName) error {
+	token, err := r.roomStore.LockRoom(ctx, roomName, roomReapLockDuration)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.roomStore.UnlockRoom(ctx, roomName, token)
+	}()
+
+	// check again under lock, another node may have reaped or recreated the room
+	room, _, err := r.roomStore.LoadRoom(ctx, roomName, false)
+	if errors.Is(err, ErrRoomNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !r.isRoomNodeGone(ctx, roomName) {
+		return nil
+	}
+
+	participants, err := r.roomStore.ListParticipants(ctx, roomName)
+	if err != nil {
+		return err
+	}
+
+	logger.Infow("reaping room of dead node", "room", roomName, "roomID", room.Sid, "numParticipants", len(participants))
+	if err = r.router.ClearRoomState(ctx, roomName); err != nil {
+		return err
+	}
+	if err = r.roomStore.DeleteRoom(ctx, roomName); err != nil {
+		return err
+	}
+
+	r.telemetry.RoomEndedOnNodeFailure(ctx, room, participants)
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/routing"
+	"github.com/livekit/livekit-server/pkg/routing/routingfakes"
+	"github.com/livekit/livekit-server/pkg/telemetry/telemetryfakes"
+)
+
+// testReaperStore is a store shared between nodes, holding the reaper lock for one of them
+type testReaperStore struct {
+	*LocalStore
+
+	holder livekit.NodeID
+}
+
+func (s *testReaperStore) LockReaper(_ context.Context, nodeID livekit.NodeID, _ time.Duration) (bool, error) {
+	if s.holder == "" {
+		s.holder = nodeID
+	}
+	return s.holder == nodeID, nil
+}
+
+func TestReapDeadNodeRooms(t *testing.T) {
+	setup := func(t *testing.T, holder livekit.NodeID) (*RoomManager, *routingfakes.FakeRouter, *testReaperStore) {
+		router := &routingfakes.FakeRouter{}
+		store := &testReaperStore{LocalStore: NewLocalStore(), holder: holder}
+		rm := newTestMigrationRoomManager(t, router, newTestMigrationAllocator(), store)
+
+		require.NoError(t, store.StoreRoom(context.Background(), &livekit.Room{Sid: "RM_lobby", Name: "lobby"}, nil))
+		require.NoError(t, store.StoreParticipant(context.Background(), "lobby", &livekit.ParticipantInfo{Identity: "alice"}))
+		return rm, router, store
+	}
+
+	requireReaped := func(t *testing.T, rm *RoomManager, router *routingfakes.FakeRouter, store *testReaperStore, reaped bool) {
+		_, _, err := store.LoadRoom(context.Background(), "lobby", false)
+		fakeTelemetry := rm.telemetry.(*telemetryfakes.FakeTelemetryService)
+		if !reaped {
+			require.NoError(t, err)
+			require.Zero(t, router.ClearRoomStateCallCount())
+			require.Zero(t, fakeTelemetry.RoomEndedOnNodeFailureCallCount())
+			return
+		}
+
+		require.ErrorIs(t, err, ErrRoomNotFound)
+		require.Equal(t, 1, router.ClearRoomStateCallCount())
+		require.Equal(t, 1, fakeTelemetry.RoomEndedOnNodeFailureCallCount())
+		_, room, participants := fakeTelemetry.RoomEndedOnNodeFailureArgsForCall(0)
+		require.Equal(t, "RM_lobby", room.Sid)
+		require.Len(t, participants, 1)
+	}
+
+	t.Run("reaps room of unregistered node", func(t *testing.T) {
+		rm, router, store := setup(t, "")
+		router.GetNodeForRoomReturns(nil, fmt.Errorf("%w: %w", routing.ErrNodeNotFound, routing.ErrNotFound))
+
+		rm.ReapDeadNodeRooms(context.Background())
+		requireReaped(t, rm, router, store, true)
+	})
+
+	t.Run("reaps room of node not updating its stats", func(t *testing.T) {
+		rm, router, store := setup(t, "")
+		router.GetNodeForRoomReturns(&livekit.Node{
+			Id:    "ND_dead",
+			Stats: &livekit.NodeStats{UpdatedAt: time.Now().Add(-time.Minute).Unix()},
+		}, nil)
+
+		rm.ReapDeadNodeRooms(context.Background())
+		requireReaped(t, rm, router, store, true)
+	})
+
+	t.Run("keeps room of live node", func(t *testing.T) {
+		rm, router, store := setup(t, "")
+		router.GetNodeForRoomReturns(&livekit.Node{
+			Id:    "ND_live",
+			Stats: &livekit.NodeStats{UpdatedAt: time.Now().Unix()},
+		}, nil)
+
+		rm.ReapDeadNodeRooms(context.Background())
+		requireReaped(t, rm, router, store, false)
+	})
+
+	t.Run("keeps room not assigned to a node", func(t *testing.T) {
+		rm, router, store := setup(t, "")
+		router.GetNodeForRoomReturns(nil, routing.ErrNotFound)
+
+		rm.ReapDeadNodeRooms(context.Background())
+		requireReaped(t, rm, router, store, false)
+	})
+
+	t.Run("only the lock holder reaps", func(t *testing.T) {
+		rm, router, store := setup(t, "ND_other")
+		router.GetNodeForRoomReturns(nil, fmt.Errorf("%w: %w", routing.ErrNodeNotFound, routing.ErrNotFound))
+
+		rm.ReapDeadNodeRooms(context.Background())
+		requireReaped(t, rm, router, store, false)
+		require.Zero(t, router.GetNodeForRoomCallCount())
+	})
+
+	t.Run("stores not shared between nodes are not reaped", func(t *testing.T) {
+		router := &routingfakes.FakeRouter{}
+		store := NewLocalStore()
+		rm := newTestMigrationRoomManager(t, router, newTestMigrationAllocator(), store)
+		require.NoError(t, store.StoreRoom(context.Background(), &livekit.Room{Name: "lobby"}, nil))
+		router.GetNodeForRoomReturns(nil, fmt.Errorf("%w: %w", routing.ErrNodeNotFound, routing.ErrNotFound))
+
+		rm.ReapDeadNodeRooms(context.Background())
+		require.Zero(t, router.GetNodeForRoomCallCount())
+	})
+}
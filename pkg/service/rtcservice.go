@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"net/http"
 	"os"
@@ -27,8 +28,11 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/ua-parser/uap-go/uaparser"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/atomic"
 	"golang.org/x/exp/maps"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/livekit/livekit-server/pkg/config"
 	"github.com/livekit/livekit-server/pkg/routing"
@@ -36,6 +40,7 @@ import (
 	"github.com/livekit/livekit-server/pkg/rtc"
 	"github.com/livekit/livekit-server/pkg/telemetry"
 	"github.com/livekit/livekit-server/pkg/telemetry/prometheus"
+	"github.com/livekit/livekit-server/pkg/telemetry/tracing"
 	"github.com/livekit/livekit-server/pkg/utils"
 	"github.com/livekit/protocol/livekit"
 	"github.com/livekit/psrpc"
@@ -51,10 +56,12 @@ type RTCService struct {
 	isDev         bool
 	limits        config.LimitConfig
 	parser        *uaparser.Parser
+	geoIP         *GeoIPResolver
 	telemetry     telemetry.TelemetryService
 
-	mu          sync.Mutex
-	connections map[*websocket.Conn]struct{}
+	mu                    sync.Mutex
+	connections           map[SignalConnection]struct{}
+	httpSignalConnections map[string]*HTTPSignalConnection
 }
 
 func NewRTCService(
@@ -64,6 +71,7 @@ func NewRTCService(
 	router routing.MessageRouter,
 	currentNode routing.LocalNode,
 	telemetry telemetry.TelemetryService,
+	geoIP *GeoIPResolver,
 ) *RTCService {
 	s := &RTCService{
 		router:        router,
@@ -74,8 +82,11 @@ func NewRTCService(
 		isDev:         conf.Development,
 		limits:        conf.Limit,
 		parser:        uaparser.NewFromSaved(),
+		geoIP:         geoIP,
 		telemetry:     telemetry,
-		connections:   map[*websocket.Conn]struct{}{},
+		connections:   map[SignalConnection]struct{}{},
+
+		httpSignalConnections: map[string]*HTTPSignalConnection{},
 	}
 
 	s.upgrader = websocket.Upgrader{
@@ -93,6 +104,7 @@ func NewRTCService(
 
 func (s *RTCService) SetupRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/rtc/validate", s.validate)
+	mux.HandleFunc("POST /rtc/signal/{session}", s.handleSignalRequest)
 }
 
 func (s *RTCService) validate(w http.ResponseWriter, r *http.Request) {
@@ -104,6 +116,38 @@ func (s *RTCService) validate(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write([]byte("success"))
 }
 
+// handleSignalRequest passes a request to the session of a client signalling over HTTP.
+// The session has to be on this node, requests of other nodes' sessions are not found.
+func (s *RTCService) handleSignalRequest(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	c := s.httpSignalConnections[r.PathValue("session")]
+	s.mu.Unlock()
+	if c == nil {
+		handleError(w, r, http.StatusNotFound, ErrSignalSessionNotFound)
+		return
+	}
+	if claims := GetGrants(r.Context()); claims == nil || claims.Identity != c.Identity() {
+		handleError(w, r, http.StatusUnauthorized, ErrPermissionDenied)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxSignalRequestSize))
+	if err != nil {
+		handleError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	switch err = c.HandleRequest(payload, r.Header.Get("Content-Type")); {
+	case errors.Is(err, ErrSignalSessionClosed):
+		handleError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, ErrSignalRequestQueueFull):
+		handleError(w, r, http.StatusServiceUnavailable, err)
+	case err != nil:
+		handleError(w, r, http.StatusBadRequest, err)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
 func (s *RTCService) validateInternal(r *http.Request) (livekit.RoomName, routing.ParticipantInit, int, error) {
 	claims := GetGrants(r.Context())
 	var pi routing.ParticipantInit
@@ -134,6 +178,7 @@ func (s *RTCService) validateInternal(r *http.Request) (livekit.RoomName, routin
 	participantID := r.FormValue("sid")
 	subscriberAllowPauseParam := r.FormValue("subscriber_allow_pause")
 	disableICELite := r.FormValue("disable_ice_lite")
+	singlePeerConnectionParam := r.FormValue("single_peer_connection")
 
 	if onlyName != "" {
 		roomName = onlyName
@@ -179,15 +224,23 @@ func (s *RTCService) validateInternal(r *http.Request) (livekit.RoomName, routin
 	}
 	SetRoomConfiguration(createRequest, claims.GetRoomConfiguration())
 
+	clientInfo := s.ParseClientInfo(r)
+	clientRegion := ""
+	if loc := s.geoIP.Locate(clientInfo.Address); loc != nil && loc.Region != "" {
+		utils.GetLogger(r.Context()).Debugw("located client", "address", clientInfo.Address, "country", loc.Country, "city", loc.City, "region", loc.Region)
+		clientRegion = loc.Region
+	}
+
 	pi = routing.ParticipantInit{
 		Reconnect:       boolValue(reconnectParam),
 		ReconnectReason: livekit.ReconnectReason(reconnectReason),
 		Identity:        livekit.ParticipantIdentity(claims.Identity),
 		Name:            livekit.ParticipantName(claims.Name),
 		AutoSubscribe:   true,
-		Client:          s.ParseClientInfo(r),
+		Client:          clientInfo,
 		Grants:          claims,
 		Region:          region,
+		ClientRegion:    clientRegion,
 		CreateRoom:      createRequest,
 	}
 	if pi.Reconnect {
@@ -207,22 +260,46 @@ func (s *RTCService) validateInternal(r *http.Request) (livekit.RoomName, routin
 	if disableICELite != "" {
 		pi.DisableICELite = boolValue(disableICELite)
 	}
+	if singlePeerConnectionParam != "" {
+		pi.UseSinglePeerConnection = boolValue(singlePeerConnectionParam)
+	}
 
 	return roomName, pi, http.StatusOK, nil
 }
 
 func (s *RTCService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// reject non websocket requests
-	if !websocket.IsWebSocketUpgrade(r) {
+	// reject requests that are neither websocket nor event stream
+	isWebSocket := websocket.IsWebSocketUpgrade(r)
+	if !isWebSocket && !acceptsEventStream(r) {
 		w.WriteHeader(404)
 		return
 	}
+	// requests of event stream clients are authenticated as the participant of the token
+	var tokenIdentity string
+	if claims := GetGrants(r.Context()); claims != nil {
+		tokenIdentity = claims.Identity
+	}
+
+	// the join span covers everything up to the initial response being written to the client
+	ctx, joinSpan := tracing.Start(r.Context(), "RTCService.ServeHTTP", trace.WithSpanKind(trace.SpanKindServer))
+	var joinErr error
+	defer func() {
+		if joinSpan != nil {
+			tracing.EndSpan(joinSpan, joinErr)
+		}
+	}()
 
 	roomName, pi, code, err := s.validateInternal(r)
 	if err != nil {
+		joinErr = err
 		handleError(w, r, code, err)
 		return
 	}
+	joinSpan.SetAttributes(
+		tracing.AttrRoomName.String(string(roomName)),
+		tracing.AttrParticipantIdentity.String(string(pi.Identity)),
+		attribute.Bool("livekit.reconnect", pi.Reconnect),
+	)
 
 	loggerFields := []any{
 		"participant", pi.Identity,
@@ -237,7 +314,7 @@ func (s *RTCService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var initialResponse *livekit.SignalResponse
 	for attempt := 0; attempt < s.config.SignalRelay.ConnectAttempts; attempt++ {
 		connectionTimeout := 3 * time.Second * time.Duration(attempt+1)
-		ctx := utils.ContextWithAttempt(r.Context(), attempt)
+		ctx := utils.ContextWithAttempt(ctx, attempt)
 		cr, initialResponse, err = s.startConnection(ctx, roomName, pi, connectionTimeout)
 		if err == nil || errors.Is(err, context.Canceled) {
 			break
@@ -245,6 +322,7 @@ func (s *RTCService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
+		joinErr = err
 		prometheus.IncrementParticipantJoinFail(1)
 		status := http.StatusInternalServerError
 		var psrpcErr psrpc.Error
@@ -277,9 +355,17 @@ func (s *RTCService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	done := make(chan struct{})
 	// function exits when websocket terminates, it'll close the event reading off of request sink and response source as well
 	defer func() {
+		traffic := signalStats.GetTrafficTotals()
+		sendFrames, sendFrameBytes := signalStats.GetSendFrameTotals()
 		pLogger.Debugw("finishing WS connection",
 			"connID", cr.ConnectionID,
 			"closedByClient", closedByClient.Load(),
+			"sendMessages", traffic.SendMessages,
+			"sendBytes", traffic.SendBytes,
+			"sendFrames", sendFrames,
+			"sendFrameBytes", sendFrameBytes,
+			"recvMessages", traffic.RecvMessages,
+			"recvBytes", traffic.RecvBytes,
 		)
 		cr.ResponseSource.Close()
 		cr.RequestSink.Close()
@@ -289,33 +375,79 @@ func (s *RTCService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}()
 
 	// upgrade only once the basics are good to go
-	conn, err := s.upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		handleError(w, r, http.StatusInternalServerError, err, loggerFields...)
-		return
+	var sigConn SignalConnection
+	var conn *websocket.Conn
+	var encoding SignalEncoding
+	if isWebSocket {
+		encoding = ParseSignalEncoding(r)
+		// frames compressed with zstd are not compressed again
+		upgrader := s.upgrader
+		upgrader.EnableCompression = encoding.Compression != SignalCompressionZstd
+		conn, err = upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			joinErr = err
+			handleError(w, r, http.StatusInternalServerError, err, loggerFields...)
+			return
+		}
+		// websocket established
+		wsConn := NewWSSignalConnection(conn)
+		wsConn.SetSignalEncoding(encoding)
+		wsConn.OnFrame(func(size int) {
+			signalStats.AddSendFrame(uint64(size))
+		})
+		sigConn = wsConn
+	} else {
+		httpConn, err := NewHTTPSignalConnection(w, r, tokenIdentity)
+		if err != nil {
+			joinErr = err
+			pLogger.Warnw("could not start event stream", err)
+			return
+		}
+		s.mu.Lock()
+		s.httpSignalConnections[httpConn.ID()] = httpConn
+		s.mu.Unlock()
+
+		// writes to the event stream have to stop before the handler returns
+		defer func() {
+			s.mu.Lock()
+			delete(s.httpSignalConnections, httpConn.ID())
+			s.mu.Unlock()
+			_ = httpConn.Close()
+		}()
+		sigConn = httpConn
 	}
 
 	s.mu.Lock()
-	s.connections[conn] = struct{}{}
+	s.connections[sigConn] = struct{}{}
 	s.mu.Unlock()
 
 	defer func() {
 		s.mu.Lock()
-		delete(s.connections, conn)
+		delete(s.connections, sigConn)
 		s.mu.Unlock()
 	}()
 
-	// websocket established
-	sigConn := NewWSSignalConnection(conn)
 	count, err := sigConn.WriteResponse(initialResponse)
 	if err != nil {
+		joinErr = err
 		pLogger.Warnw("could not write initial response", err)
 		return
 	}
 	signalStats.AddBytes(uint64(count), true)
 
+	joinSpan.SetAttributes(
+		tracing.AttrParticipantID.String(string(pi.ID)),
+		tracing.AttrConnectionID.String(string(cr.ConnectionID)),
+		tracing.AttrNodeID.String(string(cr.NodeID)),
+	)
+	tracing.EndSpan(joinSpan, nil)
+	joinSpan = nil
+
 	pLogger.Debugw("new client WS connected",
 		"connID", cr.ConnectionID,
+		"webSocket", isWebSocket,
+		"signalBatch", encoding.Batch,
+		"signalCompression", encoding.Compression,
 		"reconnect", pi.Reconnect,
 		"reconnectReason", pi.ReconnectReason,
 		"adaptiveStream", pi.AdaptiveStream,
@@ -328,15 +460,48 @@ func (s *RTCService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			// when the source is terminated, this means Participant.Close had been called and RTC connection is done
 			// we would terminate the signal connection as well
-			closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
-			_ = conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
-			_ = conn.Close()
+			if conn != nil {
+				closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+				_ = conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
+			}
+			_ = sigConn.Close()
 		}()
 		defer func() {
 			if r := rtc.Recover(pLogger); r != nil {
 				os.Exit(1)
 			}
 		}()
+		// logs and picks up room and participant details of a response, returns false for unexpected messages
+		inspect := func(msg proto.Message) (*livekit.SignalResponse, bool) {
+			res, ok := msg.(*livekit.SignalResponse)
+			if !ok {
+				pLogger.Errorw(
+					"unexpected message type", nil,
+					"type", fmt.Sprintf("%T", msg),
+					"connID", cr.ConnectionID,
+				)
+				return nil, false
+			}
+
+			switch m := res.Message.(type) {
+			case *livekit.SignalResponse_Offer:
+				pLogger.Debugw("sending offer", "offer", m)
+			case *livekit.SignalResponse_Answer:
+				pLogger.Debugw("sending answer", "answer", m)
+			case *livekit.SignalResponse_Join:
+				pLogger.Debugw("sending join", "join", m)
+				signalStats.ResolveRoom(m.Join.GetRoom())
+				signalStats.ResolveParticipant(m.Join.GetParticipant())
+			case *livekit.SignalResponse_RoomUpdate:
+				pLogger.Debugw("sending room update", "roomUpdate", m)
+				signalStats.ResolveRoom(m.RoomUpdate.GetRoom())
+			case *livekit.SignalResponse_Update:
+				pLogger.Debugw("sending participant update", "participantUpdate", m)
+			}
+			return res, true
+		}
+
+		var batch []*livekit.SignalResponse
 		for {
 			select {
 			case <-done:
@@ -346,37 +511,48 @@ func (s *RTCService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 					pLogger.Debugw("nothing to read from response source", "connID", cr.ConnectionID)
 					return
 				}
-				res, ok := msg.(*livekit.SignalResponse)
+				res, ok := inspect(msg)
 				if !ok {
-					pLogger.Errorw(
-						"unexpected message type", nil,
-						"type", fmt.Sprintf("%T", msg),
-						"connID", cr.ConnectionID,
-					)
 					continue
 				}
+				batch = append(batch[:0], res)
+
+				// batch responses which are already queued, e. g. bursts of participant updates in large rooms
+				closed := false
+				if encoding.Batch {
+				drain:
+					for len(batch) < maxSignalBatchSize {
+						select {
+						case msg := <-cr.ResponseSource.ReadChan():
+							if msg == nil {
+								closed = true
+								break drain
+							}
+							if res, ok := inspect(msg); ok {
+								batch = append(batch, res)
+							}
+						default:
+							break drain
+						}
+					}
+				}
 
-				switch m := res.Message.(type) {
-				case *livekit.SignalResponse_Offer:
-					pLogger.Debugw("sending offer", "offer", m)
-				case *livekit.SignalResponse_Answer:
-					pLogger.Debugw("sending answer", "answer", m)
-				case *livekit.SignalResponse_Join:
-					pLogger.Debugw("sending join", "join", m)
-					signalStats.ResolveRoom(m.Join.GetRoom())
-					signalStats.ResolveParticipant(m.Join.GetParticipant())
-				case *livekit.SignalResponse_RoomUpdate:
-					pLogger.Debugw("sending room update", "roomUpdate", m)
-					signalStats.ResolveRoom(m.RoomUpdate.GetRoom())
-				case *livekit.SignalResponse_Update:
-					pLogger.Debugw("sending participant update", "participantUpdate", m)
+				var count int
+				var err error
+				if wsConn, ok := sigConn.(*WSSignalConnection); ok {
+					count, err = wsConn.WriteResponses(batch)
+				} else {
+					count, err = sigConn.WriteResponse(res)
+				}
+				if err != nil {
+					pLogger.Warnw("error writing to signal connection", err)
+					return
 				}
+				signalStats.AddMessages(uint32(len(batch)), uint64(count), true)
 
-				if count, err := sigConn.WriteResponse(res); err != nil {
-					pLogger.Warnw("error writing to websocket", err)
+				if closed {
+					pLogger.Debugw("nothing to read from response source", "connID", cr.ConnectionID)
 					return
-				} else {
-					signalStats.AddBytes(uint64(count), true)
 				}
 			}
 		}
@@ -389,7 +565,7 @@ func (s *RTCService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			if IsWebSocketCloseError(err) {
 				closedByClient.Store(true)
 			} else {
-				pLogger.Errorw("error reading from websocket", err, "connID", cr.ConnectionID)
+				pLogger.Errorw("error reading from signal connection", err, "connID", cr.ConnectionID)
 			}
 			return
 		}
@@ -539,7 +715,15 @@ func (s *RTCService) startConnection(
 	var cr connectionResult
 	var err error
 
-	if err := s.roomAllocator.SelectRoomNode(ctx, roomName, ""); err != nil {
+	ctx, span := tracing.Start(ctx, "RTCService.startConnection", trace.WithAttributes(
+		attribute.Int("livekit.attempt", utils.GetAttempt(ctx)),
+	))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	selectionReason, err := s.roomAllocator.SelectRoomNode(ctx, roomName, "", pi.CreateRoom.GetRoomPreset(), pi.ClientRegion)
+	if err != nil {
 		return cr, nil, err
 	}
 
@@ -548,11 +732,19 @@ func (s *RTCService) startConnection(
 	if err != nil {
 		return cr, nil, err
 	}
+	// the node is selected by the allocator, the router only connects to it
+	cr.NodeSelectionReason = selectionReason
+	span.SetAttributes(
+		tracing.AttrNodeID.String(string(cr.NodeID)),
+		attribute.String("livekit.node_selection_reason", cr.NodeSelectionReason),
+	)
 
 	// wait for the first message before upgrading to websocket. If no one is
 	// responding to our connection attempt, we should terminate the connection
 	// instead of waiting forever on the WebSocket
+	_, waitSpan := tracing.Start(ctx, "RTCService.readInitialResponse")
 	initialResponse, err := readInitialResponse(cr.ResponseSource, timeout)
+	tracing.EndSpan(waitSpan, err)
 	if err != nil {
 		// close the connection to avoid leaking
 		cr.RequestSink.Close()
@@ -0,0 +1,930 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/frostbyte73/core"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/pion/transport/v3/packetio"
+	"github.com/pion/webrtc/v4"
+	"go.uber.org/atomic"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+
+	"github.com/livekit/livekit-server/pkg/config"
+	"github.com/livekit/livekit-server/pkg/rtc"
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	"github.com/livekit/livekit-server/pkg/sfu/buffer"
+)
+
+const (
+	rtspVersion = "RTSP/1.0"
+	rtspRealm   = "livekit"
+	// control of the only stream of a presentation, relative to its URL
+	rtspControl = "streamid=0"
+	// sessions are closed when nothing is received for this long, clients keep alive with requests or RTCP
+	rtspSessionTimeout  = 60 * time.Second
+	rtspWriteTimeout    = 5 * time.Second
+	rtspMaxBodySize     = 64 * 1024
+	rtspFrameQueueSize  = 512
+	rtspUDPPortAttempts = 10
+	rtspPublicMethods   = "OPTIONS, DESCRIBE, SETUP, PLAY, TEARDOWN, GET_PARAMETER, SET_PARAMETER"
+)
+
+var (
+	errRTSPBadRequest           = errors.New("invalid RTSP request")
+	errRTSPNotFound             = errors.New("RTSP resource not found")
+	errRTSPUnauthorized         = errors.New("RTSP request not authorized")
+	errRTSPUnsupportedTransport = errors.New("unsupported RTSP transport")
+	errRTSPNoUDPPorts           = errors.New("no UDP port pair available")
+)
+
+// RTSPServer lets RTSP clients, e. g. CCTV and monitoring tools, subscribe to a track at
+// rtsp://host:port/room/participant/track, where track is a track SID or name. Each session is a down track
+// forwarding the track as is, without transcoding, interleaved on the RTSP connection or over UDP.
+//
+// Requests are authenticated with a token allowing to subscribe in the room, in the access_token query parameter
+// or as a bearer token. Subscription permissions of the publisher apply to the identity of the token as they do to
+// participants, a session ends when they no longer allow it. Only rooms hosted on the node receiving the connection
+// can be played, there is no track to forward in a room which is not hosted yet. A session ends with the RTSP
+// connection.
+type RTSPServer struct {
+	conf          config.RTSPConfig
+	rtcConf       config.RTCConfig
+	bindAddresses []string
+	roomManager   *RoomManager
+	keyProvider   auth.KeyProvider
+
+	lock      sync.Mutex
+	listeners []net.Listener
+	conns     map[*rtspConn]struct{}
+	closed    core.Fuse
+}
+
+func NewRTSPServer(conf *config.Config, roomManager *RoomManager, keyProvider auth.KeyProvider) *RTSPServer {
+	return &RTSPServer{
+		conf:          conf.RTSP,
+		rtcConf:       conf.RTC,
+		bindAddresses: conf.BindAddresses,
+		roomManager:   roomManager,
+		keyProvider:   keyProvider,
+		conns:         make(map[*rtspConn]struct{}),
+	}
+}
+
+func (s *RTSPServer) Start() error {
+	if !s.conf.Enabled {
+		return nil
+	}
+
+	addresses := s.bindAddresses
+	if addresses == nil {
+		addresses = []string{""}
+	}
+	for _, addr := range addresses {
+		ln, err := net.Listen("tcp", net.JoinHostPort(addr, strconv.Itoa(int(s.conf.Port))))
+		if err != nil {
+			s.Stop()
+			return err
+		}
+		s.lock.Lock()
+		s.listeners = append(s.listeners, ln)
+		s.lock.Unlock()
+		go s.acceptWorker(ln)
+	}
+	return nil
+}
+
+func (s *RTSPServer) Stop() {
+	s.closed.Break()
+
+	s.lock.Lock()
+	listeners := s.listeners
+	s.listeners = nil
+	conns := make([]*rtspConn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.lock.Unlock()
+
+	for _, ln := range listeners {
+		_ = ln.Close()
+	}
+	for _, c := range conns {
+		c.close()
+	}
+}
+
+func (s *RTSPServer) acceptWorker(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if !s.closed.IsBroken() {
+				logger.Warnw("could not accept RTSP connection", err)
+			}
+			return
+		}
+
+		c := newRTSPConn(s, conn)
+		s.lock.Lock()
+		if s.closed.IsBroken() {
+			s.lock.Unlock()
+			_ = conn.Close()
+			return
+		}
+		s.conns[c] = struct{}{}
+		s.lock.Unlock()
+		go c.run()
+	}
+}
+
+func (s *RTSPServer) removeConn(c *rtspConn) {
+	s.lock.Lock()
+	delete(s.conns, c)
+	s.lock.Unlock()
+}
+
+// verifyToken checks that the token allows subscribing in the room
+func (s *RTSPServer) verifyToken(token string, roomName livekit.RoomName) (*auth.ClaimGrants, error) {
+	if s.keyProvider == nil {
+		return nil, errRTSPUnauthorized
+	}
+	v, err := auth.ParseAPIToken(token)
+	if err != nil {
+		return nil, ErrInvalidAuthorizationToken
+	}
+	secret := s.keyProvider.GetSecret(v.APIKey())
+	if secret == "" {
+		return nil, errRTSPUnauthorized
+	}
+	grants, err := v.Verify(secret)
+	if err != nil {
+		return nil, ErrInvalidAuthorizationToken
+	}
+
+	video := grants.Video
+	if video == nil || livekit.RoomName(video.Room) != roomName {
+		return nil, ErrPermissionDenied
+	}
+	if !video.RoomAdmin && !(video.RoomJoin && video.GetCanSubscribe()) {
+		return nil, ErrPermissionDenied
+	}
+	return grants, nil
+}
+
+func (s *RTSPServer) findTrack(roomName livekit.RoomName, identity livekit.ParticipantIdentity, name string) (*rtc.Room, types.LocalParticipant, types.MediaTrack) {
+	room := s.roomManager.GetRoom(context.Background(), roomName)
+	if room == nil {
+		return nil, nil, nil
+	}
+	p := room.GetParticipant(identity)
+	if p == nil {
+		return nil, nil, nil
+	}
+	for _, track := range p.GetPublishedTracks() {
+		if string(track.ID()) == name || track.Name() == name {
+			return room, p, track
+		}
+	}
+	return nil, nil, nil
+}
+
+// hasRTSPTrackPermission applies the subscription permissions of the publisher to the identity of the token,
+// recorders are exempt as they are when subscribing as participants
+func hasRTSPTrackPermission(publisher types.Participant, trackID livekit.TrackID, grants *auth.ClaimGrants) bool {
+	return grants.Video.Recorder || publisher.HasPermission(trackID, livekit.ParticipantIdentity(grants.Identity))
+}
+
+// ------------------------------------------------------
+
+type rtspRequest struct {
+	method string
+	url    *url.URL
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+type rtspSession struct {
+	track     types.MediaTrack
+	egress    *rtc.PlainEgress
+	transport rtspTransport
+	playing   bool
+}
+
+type rtspConn struct {
+	server *RTSPServer
+	conn   net.Conn
+	reader *bufio.Reader
+	logger logger.Logger
+	// grants of presentations authorized on this connection, SETUP and PLAY URLs do not carry the token of DESCRIBE
+	authorized map[string]*auth.ClaimGrants
+
+	writeLock sync.Mutex
+	frames    chan []byte
+
+	sessionLock sync.Mutex
+	session     *rtspSession
+
+	closed core.Fuse
+}
+
+func newRTSPConn(server *RTSPServer, conn net.Conn) *rtspConn {
+	return &rtspConn{
+		server:     server,
+		conn:       conn,
+		reader:     bufio.NewReader(conn),
+		logger:     logger.GetLogger().WithValues("rtspRemote", conn.RemoteAddr().String()),
+		authorized: make(map[string]*auth.ClaimGrants),
+		frames:     make(chan []byte, rtspFrameQueueSize),
+	}
+}
+
+func (c *rtspConn) run() {
+	defer c.close()
+	go c.writeWorker()
+
+	for {
+		c.keepAlive()
+		b, err := c.reader.Peek(1)
+		if err != nil {
+			return
+		}
+		if b[0] == '$' {
+			if err = c.readInterleaved(); err != nil {
+				return
+			}
+			continue
+		}
+
+		req, err := c.readRequest()
+		if err != nil {
+			if errors.Is(err, errRTSPBadRequest) {
+				c.writeResponse(nil, http.StatusBadRequest, nil)
+			}
+			return
+		}
+		c.handleRequest(req)
+	}
+}
+
+func (c *rtspConn) keepAlive() {
+	_ = c.conn.SetReadDeadline(time.Now().Add(rtspSessionTimeout))
+}
+
+func (c *rtspConn) close() {
+	if c.closed.IsBroken() {
+		return
+	}
+	c.closed.Break()
+
+	c.closeSession()
+	_ = c.conn.Close()
+	c.server.removeConn(c)
+}
+
+func (c *rtspConn) readRequest() (*rtspRequest, error) {
+	tp := textproto.NewReader(c.reader)
+	line, err := tp.ReadLine()
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Fields(line)
+	if len(parts) != 3 || parts[2] != rtspVersion {
+		return nil, fmt.Errorf("%w: %q", errRTSPBadRequest, line)
+	}
+	u, err := url.Parse(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errRTSPBadRequest, err)
+	}
+	header, err := tp.ReadMIMEHeader()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errRTSPBadRequest, err)
+	}
+
+	req := &rtspRequest{method: parts[0], url: u, header: header}
+	if cl := header.Get("Content-Length"); cl != "" {
+		n, err := strconv.Atoi(cl)
+		if err != nil || n < 0 || n > rtspMaxBodySize {
+			return nil, fmt.Errorf("%w: invalid content length %q", errRTSPBadRequest, cl)
+		}
+		req.body = make([]byte, n)
+		if _, err = io.ReadFull(c.reader, req.body); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// readInterleaved reads a frame sent on the RTSP connection (RFC 2326 10.12), only RTCP of the session is used
+func (c *rtspConn) readInterleaved() error {
+	var header [4]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return err
+	}
+	payload := make([]byte, binary.BigEndian.Uint16(header[2:]))
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return err
+	}
+
+	c.sessionLock.Lock()
+	session := c.session
+	c.sessionLock.Unlock()
+	if session != nil {
+		if t, ok := session.transport.(*rtspTCPTransport); ok && header[1] == t.rtcpChannel {
+			t.deliverRTCP(payload)
+		}
+	}
+	return nil
+}
+
+func (c *rtspConn) writeResponse(req *rtspRequest, status int, body []byte, keysAndValues ...string) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %d %s\r\n", rtspVersion, status, rtspStatusText(status))
+	if req != nil {
+		if cseq := req.header.Get("CSeq"); cseq != "" {
+			fmt.Fprintf(&sb, "CSeq: %s\r\n", cseq)
+		}
+	}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fmt.Fprintf(&sb, "%s: %s\r\n", keysAndValues[i], keysAndValues[i+1])
+	}
+	if len(body) != 0 {
+		fmt.Fprintf(&sb, "Content-Length: %d\r\n", len(body))
+	}
+	sb.WriteString("\r\n")
+	sb.Write(body)
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	_ = c.conn.SetWriteDeadline(time.Now().Add(rtspWriteTimeout))
+	_, _ = c.conn.Write([]byte(sb.String()))
+}
+
+func (c *rtspConn) writeError(req *rtspRequest, status int, err error) {
+	c.logger.Infow("RTSP request failed", "method", req.method, "url", req.url.Redacted(), "status", status, "error", err)
+	if status == http.StatusUnauthorized {
+		c.writeResponse(req, status, nil, "WWW-Authenticate", fmt.Sprintf("Bearer realm=%q", rtspRealm))
+		return
+	}
+	c.writeResponse(req, status, nil)
+}
+
+// writeInterleaved queues a frame of a channel, frames are dropped when the client does not keep up
+func (c *rtspConn) writeInterleaved(channel uint8, pkt []byte) {
+	if c.closed.IsBroken() || len(pkt) > 0xffff {
+		return
+	}
+	frame := make([]byte, 4+len(pkt))
+	frame[0] = '$'
+	frame[1] = channel
+	binary.BigEndian.PutUint16(frame[2:], uint16(len(pkt)))
+	copy(frame[4:], pkt)
+
+	select {
+	case c.frames <- frame:
+	default:
+	}
+}
+
+func (c *rtspConn) writeWorker() {
+	for {
+		select {
+		case <-c.closed.Watch():
+			return
+		case frame := <-c.frames:
+			c.writeLock.Lock()
+			_ = c.conn.SetWriteDeadline(time.Now().Add(rtspWriteTimeout))
+			_, err := c.conn.Write(frame)
+			c.writeLock.Unlock()
+			if err != nil {
+				c.close()
+				return
+			}
+		}
+	}
+}
+
+func (c *rtspConn) handleRequest(req *rtspRequest) {
+	switch req.method {
+	case "OPTIONS":
+		c.writeResponse(req, http.StatusOK, nil, "Public", rtspPublicMethods)
+	case "DESCRIBE":
+		c.handleDescribe(req)
+	case "SETUP":
+		c.handleSetup(req)
+	case "PLAY":
+		c.handlePlay(req)
+	case "TEARDOWN":
+		if _, ok := c.checkSession(req); ok {
+			c.closeSession()
+			c.writeResponse(req, http.StatusOK, nil)
+		}
+	case "GET_PARAMETER", "SET_PARAMETER":
+		// keep alive
+		if req.header.Get("Session") == "" {
+			c.writeResponse(req, http.StatusOK, nil)
+		} else if session, ok := c.checkSession(req); ok {
+			c.writeResponse(req, http.StatusOK, nil, "Session", session.egress.ID())
+		}
+	default:
+		c.writeResponse(req, http.StatusNotImplemented, nil, "Public", rtspPublicMethods)
+	}
+}
+
+// resolve authorizes the request and returns the track it is about with the grants of the client
+func (c *rtspConn) resolve(req *rtspRequest) (*rtc.Room, types.MediaTrack, *auth.ClaimGrants, int, error) {
+	roomName, identity, trackName, err := parseRTSPPath(req.url)
+	if err != nil {
+		return nil, nil, nil, http.StatusNotFound, err
+	}
+
+	key := string(roomName) + "/" + string(identity) + "/" + trackName
+	grants := c.authorized[key]
+	if grants == nil {
+		token := req.url.Query().Get(accessTokenParam)
+		if authorization := req.header.Get(authorizationHeader); token == "" && strings.HasPrefix(authorization, bearerPrefix) {
+			token = authorization[len(bearerPrefix):]
+		}
+		if token == "" {
+			return nil, nil, nil, http.StatusUnauthorized, errRTSPUnauthorized
+		}
+		if grants, err = c.server.verifyToken(token, roomName); err != nil {
+			return nil, nil, nil, http.StatusUnauthorized, err
+		}
+		c.authorized[key] = grants
+	}
+
+	room, publisher, track := c.server.findTrack(roomName, identity, trackName)
+	if track == nil {
+		return nil, nil, nil, http.StatusNotFound, errRTSPNotFound
+	}
+	if !hasRTSPTrackPermission(publisher, track.ID(), grants) {
+		return nil, nil, nil, http.StatusForbidden, ErrPermissionDenied
+	}
+	return room, track, grants, http.StatusOK, nil
+}
+
+func (c *rtspConn) handleDescribe(req *rtspRequest) {
+	_, track, _, status, err := c.resolve(req)
+	if err != nil {
+		c.writeError(req, status, err)
+		return
+	}
+	codec, err := rtc.PlainEgressCodec(track)
+	if err != nil {
+		c.writeError(req, http.StatusNotFound, err)
+		return
+	}
+
+	base := *req.url
+	base.RawQuery = ""
+	base.Path = strings.TrimSuffix(base.Path, "/") + "/"
+	c.writeResponse(req, http.StatusOK, []byte(rtspSDP(track, codec)),
+		"Content-Type", "application/sdp",
+		"Content-Base", base.String(),
+	)
+}
+
+func (c *rtspConn) handleSetup(req *rtspRequest) {
+	c.sessionLock.Lock()
+	hasSession := c.session != nil
+	c.sessionLock.Unlock()
+	if hasSession {
+		// one stream per session, presentations only have one
+		c.writeError(req, 459, errors.New("session already set up"))
+		return
+	}
+
+	room, track, grants, status, err := c.resolve(req)
+	if err != nil {
+		c.writeError(req, status, err)
+		return
+	}
+	quality := livekit.VideoQuality_HIGH
+	if q := req.url.Query().Get("quality"); q != "" {
+		value, ok := livekit.VideoQuality_value[strings.ToUpper(q)]
+		if !ok || livekit.VideoQuality(value) == livekit.VideoQuality_OFF {
+			c.writeError(req, http.StatusBadRequest, fmt.Errorf("%w: invalid quality %q", errRTSPBadRequest, q))
+			return
+		}
+		quality = livekit.VideoQuality(value)
+	}
+
+	transport, err := c.newTransport(req.header.Get("Transport"))
+	if err != nil {
+		status := 461
+		if errors.Is(err, errRTSPNoUDPPorts) {
+			status = http.StatusServiceUnavailable
+		}
+		c.writeError(req, status, err)
+		return
+	}
+
+	bufferFactory := room.GetBufferFactory()
+	maxTrack := c.server.rtcConf.PacketBufferSizeAudio
+	if track.Kind() == livekit.TrackType_VIDEO {
+		maxTrack = c.server.rtcConf.PacketBufferSizeVideo
+	}
+	egress, err := rtc.NewPlainEgress(rtc.PlainEgressParams{
+		Track:         track,
+		Transport:     transport,
+		Quality:       quality,
+		BufferFactory: bufferFactory,
+		MaxTrack:      maxTrack,
+		Logger:        c.logger.WithValues("room", room.Name()),
+	})
+	if err != nil {
+		transport.Close()
+		c.writeError(req, http.StatusInternalServerError, err)
+		return
+	}
+	transport.setRTCPReader(bufferFactory.GetOrNew(packetio.RTCPBufferPacket, egress.SSRC()).(*buffer.RTCPReader))
+	egress.OnClosed(func() {
+		// track unpublished, there is nothing more to play
+		c.close()
+	})
+	if !grants.Video.Recorder {
+		track.AddOnRevoke(egress.ID(), livekit.ParticipantIdentity(grants.Identity), func() {
+			c.logger.Infow("RTSP session revoked", "sessionID", egress.ID(), "trackID", track.ID())
+			c.close()
+		})
+	}
+
+	c.sessionLock.Lock()
+	c.session = &rtspSession{track: track, egress: egress, transport: transport}
+	c.sessionLock.Unlock()
+
+	c.writeResponse(req, http.StatusOK, nil,
+		"Transport", transport.header()+fmt.Sprintf(";ssrc=%08X", egress.SSRC()),
+		"Session", fmt.Sprintf("%s;timeout=%d", egress.ID(), int(rtspSessionTimeout.Seconds())),
+	)
+}
+
+func (c *rtspConn) handlePlay(req *rtspRequest) {
+	session, ok := c.checkSession(req)
+	if !ok {
+		return
+	}
+	if !session.playing {
+		if err := session.egress.Start(); err != nil {
+			c.writeError(req, http.StatusInternalServerError, err)
+			c.closeSession()
+			return
+		}
+		session.playing = true
+		c.logger.Infow("RTSP session playing", "sessionID", session.egress.ID(), "trackID", session.egress.TrackID())
+	}
+	c.writeResponse(req, http.StatusOK, nil,
+		"Session", session.egress.ID(),
+		"Range", "npt=0.000-",
+	)
+}
+
+func (c *rtspConn) checkSession(req *rtspRequest) (*rtspSession, bool) {
+	c.sessionLock.Lock()
+	session := c.session
+	c.sessionLock.Unlock()
+
+	id, _, _ := strings.Cut(req.header.Get("Session"), ";")
+	if session == nil || strings.TrimSpace(id) != session.egress.ID() {
+		c.writeError(req, 454, errors.New("session not found"))
+		return nil, false
+	}
+	return session, true
+}
+
+func (c *rtspConn) closeSession() {
+	c.sessionLock.Lock()
+	session := c.session
+	c.session = nil
+	c.sessionLock.Unlock()
+
+	if session != nil {
+		session.track.RemoveOnRevoke(session.egress.ID())
+		session.egress.Close()
+		session.transport.Close()
+	}
+}
+
+// newTransport picks the first transport of the client supported, RTP/AVP over TCP or UDP unicast
+func (c *rtspConn) newTransport(header string) (rtspTransport, error) {
+	for _, spec := range strings.Split(header, ",") {
+		params := strings.Split(strings.TrimSpace(spec), ";")
+		profile := strings.ToUpper(params[0])
+		switch profile {
+		case "RTP/AVP/TCP":
+			rtpChannel, rtcpChannel := uint8(0), uint8(1)
+			for _, p := range params[1:] {
+				if value, ok := strings.CutPrefix(p, "interleaved="); ok {
+					from, to, err := parseRTSPRange(value)
+					if err != nil || from > 0xff || to > 0xff {
+						return nil, fmt.Errorf("%w: %q", errRTSPUnsupportedTransport, spec)
+					}
+					rtpChannel, rtcpChannel = uint8(from), uint8(to)
+				}
+			}
+			return &rtspTCPTransport{conn: c, rtpChannel: rtpChannel, rtcpChannel: rtcpChannel}, nil
+
+		case "RTP/AVP", "RTP/AVP/UDP":
+			var clientPorts string
+			multicast := false
+			for _, p := range params[1:] {
+				if value, ok := strings.CutPrefix(p, "client_port="); ok {
+					clientPorts = value
+				} else if p == "multicast" {
+					multicast = true
+				}
+			}
+			if multicast || clientPorts == "" {
+				continue
+			}
+			rtpPort, rtcpPort, err := parseRTSPRange(clientPorts)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", errRTSPUnsupportedTransport, spec)
+			}
+			// media only goes to where the RTSP connection comes from
+			clientIP := c.conn.RemoteAddr().(*net.TCPAddr).IP
+			return newRTSPUDPTransport(
+				&net.UDPAddr{IP: clientIP, Port: rtpPort},
+				&net.UDPAddr{IP: clientIP, Port: rtcpPort},
+				c.keepAlive,
+			)
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", errRTSPUnsupportedTransport, header)
+}
+
+// ------------------------------------------------------
+
+type rtspTransport interface {
+	rtc.PlainEgressTransport
+	setRTCPReader(reader *buffer.RTCPReader)
+	// Transport header of the SETUP response
+	header() string
+}
+
+// rtspRTCPSink delivers RTCP received from the client to the down track of the session
+type rtspRTCPSink struct {
+	reader atomic.Pointer[buffer.RTCPReader]
+}
+
+func (s *rtspRTCPSink) setRTCPReader(reader *buffer.RTCPReader) {
+	s.reader.Store(reader)
+}
+
+func (s *rtspRTCPSink) deliverRTCP(pkt []byte) {
+	if reader := s.reader.Load(); reader != nil {
+		_, _ = reader.Write(pkt)
+	}
+}
+
+// rtspTCPTransport sends RTP and RTCP interleaved on the RTSP connection
+type rtspTCPTransport struct {
+	rtspRTCPSink
+	conn        *rtspConn
+	rtpChannel  uint8
+	rtcpChannel uint8
+	closed      core.Fuse
+}
+
+func (t *rtspTCPTransport) header() string {
+	return fmt.Sprintf("RTP/AVP/TCP;unicast;interleaved=%d-%d", t.rtpChannel, t.rtcpChannel)
+}
+
+func (t *rtspTCPTransport) WriteRTP(header *rtp.Header, payload []byte) (int, error) {
+	pkt, err := (&rtp.Packet{Header: *header, Payload: payload}).Marshal()
+	if err != nil {
+		return 0, err
+	}
+	return t.Write(pkt)
+}
+
+func (t *rtspTCPTransport) Write(pkt []byte) (int, error) {
+	if t.closed.IsBroken() {
+		return 0, io.ErrClosedPipe
+	}
+	t.conn.writeInterleaved(t.rtpChannel, pkt)
+	return len(pkt), nil
+}
+
+func (t *rtspTCPTransport) WriteRTCP(pkts []rtcp.Packet) error {
+	if t.closed.IsBroken() {
+		return nil
+	}
+	payload, err := rtcp.Marshal(pkts)
+	if err != nil {
+		return err
+	}
+	t.conn.writeInterleaved(t.rtcpChannel, payload)
+	return nil
+}
+
+func (t *rtspTCPTransport) Close() {
+	t.closed.Break()
+}
+
+// rtspUDPTransport sends RTP and RTCP to the client ports from a pair of server ports
+type rtspUDPTransport struct {
+	rtspRTCPSink
+	rtpConn  *net.UDPConn
+	rtcpConn *net.UDPConn
+	rtpAddr  *net.UDPAddr
+	rtcpAddr *net.UDPAddr
+	// called when RTCP is received, clients may only keep alive with receiver reports
+	onRTCP func()
+	closed core.Fuse
+}
+
+func newRTSPUDPTransport(rtpAddr, rtcpAddr *net.UDPAddr, onRTCP func()) (*rtspUDPTransport, error) {
+	// RTP on an even port, RTCP on the next one
+	for range rtspUDPPortAttempts {
+		rtpConn, err := net.ListenUDP("udp", &net.UDPAddr{})
+		if err != nil {
+			return nil, err
+		}
+		port := rtpConn.LocalAddr().(*net.UDPAddr).Port
+		if port%2 != 0 {
+			_ = rtpConn.Close()
+			continue
+		}
+		rtcpConn, err := net.ListenUDP("udp", &net.UDPAddr{Port: port + 1})
+		if err != nil {
+			_ = rtpConn.Close()
+			continue
+		}
+
+		t := &rtspUDPTransport{
+			rtpConn:  rtpConn,
+			rtcpConn: rtcpConn,
+			rtpAddr:  rtpAddr,
+			rtcpAddr: rtcpAddr,
+			onRTCP:   onRTCP,
+		}
+		go t.readWorker()
+		return t, nil
+	}
+	return nil, errRTSPNoUDPPorts
+}
+
+func (t *rtspUDPTransport) header() string {
+	rtpPort := t.rtpConn.LocalAddr().(*net.UDPAddr).Port
+	return fmt.Sprintf("RTP/AVP;unicast;client_port=%d-%d;server_port=%d-%d", t.rtpAddr.Port, t.rtcpAddr.Port, rtpPort, rtpPort+1)
+}
+
+func (t *rtspUDPTransport) WriteRTP(header *rtp.Header, payload []byte) (int, error) {
+	pkt, err := (&rtp.Packet{Header: *header, Payload: payload}).Marshal()
+	if err != nil {
+		return 0, err
+	}
+	return t.Write(pkt)
+}
+
+func (t *rtspUDPTransport) Write(pkt []byte) (int, error) {
+	if t.closed.IsBroken() {
+		return 0, io.ErrClosedPipe
+	}
+	return t.rtpConn.WriteToUDP(pkt, t.rtpAddr)
+}
+
+func (t *rtspUDPTransport) WriteRTCP(pkts []rtcp.Packet) error {
+	if t.closed.IsBroken() {
+		return nil
+	}
+	payload, err := rtcp.Marshal(pkts)
+	if err != nil {
+		return err
+	}
+	_, err = t.rtcpConn.WriteToUDP(payload, t.rtcpAddr)
+	return err
+}
+
+func (t *rtspUDPTransport) Close() {
+	if t.closed.IsBroken() {
+		return
+	}
+	t.closed.Break()
+	_ = t.rtpConn.Close()
+	_ = t.rtcpConn.Close()
+}
+
+func (t *rtspUDPTransport) readWorker() {
+	pkt := make([]byte, 1500)
+	for {
+		n, addr, err := t.rtcpConn.ReadFromUDP(pkt)
+		if err != nil {
+			return
+		}
+		if !addr.IP.Equal(t.rtcpAddr.IP) {
+			continue
+		}
+		t.deliverRTCP(pkt[:n])
+		if t.onRTCP != nil {
+			t.onRTCP()
+		}
+	}
+}
+
+// ------------------------------------------------------
+
+func parseRTSPPath(u *url.URL) (livekit.RoomName, livekit.ParticipantIdentity, string, error) {
+	var parts []string
+	for _, part := range strings.Split(u.Path, "/") {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 4 && parts[3] == rtspControl {
+		parts = parts[:3]
+	}
+	if len(parts) != 3 {
+		return "", "", "", errRTSPNotFound
+	}
+	return livekit.RoomName(parts[0]), livekit.ParticipantIdentity(parts[1]), parts[2], nil
+}
+
+func parseRTSPRange(value string) (int, int, error) {
+	from, to, found := strings.Cut(value, "-")
+	a, err := strconv.Atoi(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !found {
+		return a, a + 1, nil
+	}
+	b, err := strconv.Atoi(to)
+	if err != nil {
+		return 0, 0, err
+	}
+	return a, b, nil
+}
+
+// rtspSDP describes a presentation with the track as its only stream
+func rtspSDP(track types.MediaTrack, codec webrtc.RTPCodecParameters) string {
+	media := "video"
+	if track.Kind() == livekit.TrackType_AUDIO {
+		media = "audio"
+	}
+	_, encoding, _ := strings.Cut(codec.MimeType, "/")
+	rtpmap := fmt.Sprintf("%s/%d", encoding, codec.ClockRate)
+	if codec.Channels > 1 {
+		rtpmap += fmt.Sprintf("/%d", codec.Channels)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("v=0\r\n")
+	fmt.Fprintf(&sb, "o=- %d 1 IN IP4 0.0.0.0\r\n", time.Now().Unix())
+	fmt.Fprintf(&sb, "s=%s\r\n", track.ID())
+	sb.WriteString("c=IN IP4 0.0.0.0\r\n")
+	sb.WriteString("t=0 0\r\n")
+	sb.WriteString("a=control:*\r\n")
+	fmt.Fprintf(&sb, "m=%s 0 RTP/AVP %d\r\n", media, codec.PayloadType)
+	fmt.Fprintf(&sb, "a=rtpmap:%d %s\r\n", codec.PayloadType, rtpmap)
+	if codec.SDPFmtpLine != "" {
+		fmt.Fprintf(&sb, "a=fmtp:%d %s\r\n", codec.PayloadType, codec.SDPFmtpLine)
+	}
+	fmt.Fprintf(&sb, "a=control:%s\r\n", rtspControl)
+	return sb.String()
+}
+
+func rtspStatusText(status int) string {
+	switch status {
+	case 454:
+		return "Session Not Found"
+	case 459:
+		return "Aggregate Operation Not Allowed"
+	case 461:
+		return "Unsupported Transport"
+	default:
+		return http.StatusText(status)
+	}
+}
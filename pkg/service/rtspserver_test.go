@@ -0,0 +1,99 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/pion/webrtc/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/config"
+	"github.com/livekit/livekit-server/pkg/rtc/types/typesfakes"
+)
+
+func TestParseRTSPPath(t *testing.T) {
+	for _, path := range []string{"/room/alice/TR_abc", "/room/alice/TR_abc/", "/room/alice/TR_abc/streamid=0"} {
+		u, err := url.Parse("rtsp://localhost:8554" + path + "?access_token=x")
+		require.NoError(t, err)
+		roomName, identity, track, err := parseRTSPPath(u)
+		require.NoError(t, err, path)
+		require.Equal(t, livekit.RoomName("room"), roomName)
+		require.Equal(t, livekit.ParticipantIdentity("alice"), identity)
+		require.Equal(t, "TR_abc", track)
+	}
+
+	u, err := url.Parse("rtsp://localhost:8554/room/alice")
+	require.NoError(t, err)
+	_, _, _, err = parseRTSPPath(u)
+	require.ErrorIs(t, err, errRTSPNotFound)
+}
+
+func TestRTSPAuth(t *testing.T) {
+	s := NewRTSPServer(&config.Config{}, nil, auth.NewSimpleKeyProvider("key", "secret"))
+
+	t.Run("token", func(t *testing.T) {
+		token := func(grant *auth.VideoGrant) string {
+			jwt, err := auth.NewAccessToken("key", "secret").SetIdentity("viewer").SetVideoGrant(grant).ToJWT()
+			require.NoError(t, err)
+			return jwt
+		}
+		verify := func(token string) error {
+			_, err := s.verifyToken(token, "room")
+			return err
+		}
+		canSubscribe := false
+		require.NoError(t, verify(token(&auth.VideoGrant{Room: "room", RoomJoin: true})))
+		require.NoError(t, verify(token(&auth.VideoGrant{Room: "room", RoomAdmin: true})))
+		require.Error(t, verify(token(&auth.VideoGrant{Room: "other", RoomJoin: true})))
+		require.Error(t, verify(token(&auth.VideoGrant{Room: "room", RoomJoin: true, CanSubscribe: &canSubscribe})))
+		require.Error(t, verify(token(&auth.VideoGrant{Room: "room", RoomCreate: true})))
+		require.Error(t, verify("invalid"))
+
+		grants, err := s.verifyToken(token(&auth.VideoGrant{Room: "room", RoomJoin: true}), "room")
+		require.NoError(t, err)
+		require.Equal(t, "viewer", grants.Identity)
+	})
+
+	t.Run("track permission", func(t *testing.T) {
+		publisher := &typesfakes.FakeParticipant{}
+		publisher.HasPermissionCalls(func(_ livekit.TrackID, identity livekit.ParticipantIdentity) bool {
+			return identity == "viewer"
+		})
+		require.True(t, hasRTSPTrackPermission(publisher, "TR_abc", &auth.ClaimGrants{Identity: "viewer", Video: &auth.VideoGrant{}}))
+		require.False(t, hasRTSPTrackPermission(publisher, "TR_abc", &auth.ClaimGrants{Identity: "other", Video: &auth.VideoGrant{}}))
+		require.True(t, hasRTSPTrackPermission(publisher, "TR_abc", &auth.ClaimGrants{Identity: "other", Video: &auth.VideoGrant{Recorder: true}}))
+	})
+}
+
+func TestRTSPSDP(t *testing.T) {
+	track := &typesfakes.FakeMediaTrack{}
+	track.IDReturns("TR_abc")
+	track.KindReturns(livekit.TrackType_AUDIO)
+
+	sdp := rtspSDP(track, webrtc.RTPCodecParameters{
+		RTPCodecCapability: webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus, ClockRate: 48000, Channels: 2, SDPFmtpLine: "minptime=10;useinbandfec=1"},
+		PayloadType:        111,
+	})
+	require.Contains(t, sdp, "m=audio 0 RTP/AVP 111\r\n")
+	require.Contains(t, sdp, "a=rtpmap:111 opus/48000/2\r\n")
+	require.Contains(t, sdp, "a=fmtp:111 minptime=10;useinbandfec=1\r\n")
+	require.True(t, strings.HasSuffix(sdp, "a=control:streamid=0\r\n"))
+}
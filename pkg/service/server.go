@@ -42,6 +42,9 @@ import (
 
 	"github.com/livekit/livekit-server/pkg/config"
 	"github.com/livekit/livekit-server/pkg/routing"
+	"github.com/livekit/livekit-server/pkg/rtc"
+	"github.com/livekit/livekit-server/pkg/telemetry"
+	"github.com/livekit/livekit-server/pkg/telemetry/tracing"
 	"github.com/livekit/livekit-server/version"
 )
 
@@ -55,6 +58,10 @@ type LivekitServer struct {
 	router       routing.Router
 	roomManager  *RoomManager
 	signalServer *SignalServer
+	rtspServer   *RTSPServer
+	telemetry    telemetry.TelemetryService
+	objectStore  ObjectStore
+	geoIP        *GeoIPResolver
 	turnServer   *turn.Server
 	currentNode  routing.LocalNode
 	running      atomic.Bool
@@ -70,13 +77,21 @@ func NewLivekitServer(conf *config.Config,
 	sipService *SIPService,
 	ioService *IOInfoService,
 	rtcService *RTCService,
+	whipService *WHIPService,
+	whepService *WHEPService,
+	plainRTPService *PlainRTPService,
+	rtspServer *RTSPServer,
+	mediaFileService *MediaFileService,
 	agentService *AgentService,
+	telemetryService telemetry.TelemetryService,
 	keyProvider auth.KeyProvider,
 	router routing.Router,
 	roomManager *RoomManager,
 	signalServer *SignalServer,
 	turnServer *turn.Server,
 	currentNode routing.LocalNode,
+	objectStore ObjectStore,
+	geoIP *GeoIPResolver,
 ) (s *LivekitServer, err error) {
 	s = &LivekitServer{
 		config:       conf,
@@ -86,6 +101,10 @@ func NewLivekitServer(conf *config.Config,
 		router:       router,
 		roomManager:  roomManager,
 		signalServer: signalServer,
+		rtspServer:   rtspServer,
+		telemetry:    telemetryService,
+		objectStore:  objectStore,
+		geoIP:        geoIP,
 		// turn server starts automatically
 		turnServer:  turnServer,
 		currentNode: currentNode,
@@ -101,10 +120,14 @@ func NewLivekitServer(conf *config.Config,
 				return true
 			},
 			AllowedHeaders: []string{"*"},
+			// WHIP/WHEP clients trickle and end sessions with PATCH and DELETE on the resource in Location
+			AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodHead, http.MethodPatch, http.MethodDelete},
+			ExposedHeaders: []string{"Location"},
 			// allow preflight to be cached for a day
 			MaxAge: 86400,
 		}),
 		negroni.HandlerFunc(RemoveDoubleSlashes),
+		negroni.HandlerFunc(tracing.HTTPMiddleware),
 	}
 	if keyProvider != nil {
 		middlewares = append(middlewares, NewAPIKeyAuthMiddleware(keyProvider))
@@ -114,6 +137,7 @@ func NewLivekitServer(conf *config.Config,
 		twirp.WithServerHooks(twirp.ChainHooks(
 			TwirpLogger(),
 			TwirpRequestStatusReporter(),
+			TwirpTracing(),
 		)),
 	}
 	for _, opt := range xtwirp.DefaultServerOptions() {
@@ -131,6 +155,8 @@ func NewLivekitServer(conf *config.Config,
 		mux = http.DefaultServeMux
 		mux.HandleFunc("/debug/goroutine", s.debugGoroutines)
 		mux.HandleFunc("/debug/rooms", s.debugInfo)
+		mux.HandleFunc("/debug/subscriptions", s.debugSubscriptions)
+		mux.Handle("/debug/capture", NewCaptureService(roomManager, false))
 	}
 
 	xtwirp.RegisterServer(mux, roomServer)
@@ -140,7 +166,12 @@ func NewLivekitServer(conf *config.Config,
 	xtwirp.RegisterServer(mux, sipServer)
 	mux.Handle("/rtc", rtcService)
 	rtcService.SetupRoutes(mux)
+	whipService.SetupRoutes(mux)
+	whepService.SetupRoutes(mux)
+	plainRTPService.SetupRoutes(mux)
+	mediaFileService.SetupRoutes(mux)
 	mux.Handle("/agent", agentService)
+	mux.Handle("/capture", NewCaptureService(roomManager, true))
 	mux.HandleFunc("/", s.defaultHandler)
 
 	s.httpServer = &http.Server{
@@ -253,6 +284,9 @@ func (s *LivekitServer) Start() error {
 	if s.config.Prometheus.Port != 0 {
 		values = append(values, "portPrometheus", s.config.Prometheus.Port)
 	}
+	if s.config.RTSP.Enabled {
+		values = append(values, "portRTSP", s.config.RTSP.Port)
+	}
 	if s.config.Region != "" {
 		values = append(values, "region", s.config.Region)
 	}
@@ -269,6 +303,10 @@ func (s *LivekitServer) Start() error {
 		return err
 	}
 
+	if err := s.rtspServer.Start(); err != nil {
+		return err
+	}
+
 	httpGroup := &errgroup.Group{}
 	for _, ln := range listeners {
 		l := ln
@@ -301,9 +339,17 @@ func (s *LivekitServer) Start() error {
 		_ = s.turnServer.Close()
 	}
 
+	s.rtspServer.Stop()
 	s.roomManager.Stop()
 	s.signalServer.Stop()
 	s.ioService.Stop()
+	// after rooms have closed, their last events are sent
+	s.telemetry.Close()
+	// the store is shared by all services, closed once they have stopped
+	if store, ok := s.objectStore.(*BoltStore); ok {
+		store.Close()
+	}
+	s.geoIP.Close()
 
 	close(s.closedChan)
 	return nil
@@ -312,6 +358,9 @@ func (s *LivekitServer) Start() error {
 func (s *LivekitServer) Stop(force bool) {
 	// wait for all participants to exit
 	s.router.Drain()
+	if s.config.Room.MigrateOnDrain && !force {
+		s.roomManager.MigrateRooms(context.Background())
+	}
 	partTicker := time.NewTicker(5 * time.Second)
 	waitingForParticipants := !force && s.roomManager.HasParticipants()
 	for waitingForParticipants {
@@ -357,6 +406,53 @@ func (s *LivekitServer) debugInfo(w http.ResponseWriter, _ *http.Request) {
 	}
 }
 
+type subscriptionDebugger interface {
+	SubscriptionDebugInfo() map[string]interface{}
+}
+
+// debugSubscriptions reports layer selection and bandwidth allocation of subscribers,
+// optionally restricted with room and identity query parameters
+func (s *LivekitServer) debugSubscriptions(w http.ResponseWriter, r *http.Request) {
+	roomName := livekit.RoomName(r.FormValue("room"))
+	identity := livekit.ParticipantIdentity(r.FormValue("identity"))
+
+	s.roomManager.lock.RLock()
+	rooms := make([]*rtc.Room, 0, len(s.roomManager.rooms))
+	for _, room := range s.roomManager.rooms {
+		if roomName == "" || room.Name() == roomName {
+			rooms = append(rooms, room)
+		}
+	}
+	s.roomManager.lock.RUnlock()
+
+	info := make([]map[string]interface{}, 0, len(rooms))
+	for _, room := range rooms {
+		subscribers := make([]map[string]interface{}, 0)
+		for _, p := range room.GetParticipants() {
+			if identity != "" && p.Identity() != identity {
+				continue
+			}
+			if sd, ok := p.(subscriptionDebugger); ok {
+				subscribers = append(subscribers, sd.SubscriptionDebugInfo())
+			}
+		}
+		info = append(info, map[string]interface{}{
+			"Name":        room.Name(),
+			"Sid":         room.ID(),
+			"Subscribers": subscribers,
+		})
+	}
+
+	b, err := json.Marshal(info)
+	if err != nil {
+		w.WriteHeader(400)
+		_, _ = w.Write([]byte(err.Error()))
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	}
+}
+
 func (s *LivekitServer) defaultHandler(w http.ResponseWriter, r *http.Request) {
 	if r.URL.Path == "/" {
 		s.healthCheck(w, r)
@@ -384,12 +480,16 @@ func (s *LivekitServer) healthCheck(w http.ResponseWriter, _ *http.Request) {
 func (s *LivekitServer) backgroundWorker() {
 	roomTicker := time.NewTicker(1 * time.Second)
 	defer roomTicker.Stop()
+	reapTicker := time.NewTicker(deadNodeReapInterval)
+	defer reapTicker.Stop()
 	for {
 		select {
 		case <-s.doneChan:
 			return
 		case <-roomTicker.C:
 			s.roomManager.CloseIdleRooms()
+		case <-reapTicker.C:
+			s.roomManager.ReapDeadNodeRooms(context.Background())
 		}
 	}
 }
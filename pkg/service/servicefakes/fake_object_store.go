@@ -96,6 +96,20 @@ type FakeObjectStore struct {
 		result2 *livekit.RoomInternal
 		result3 error
 	}
+	LoadRoomPlacementStub        func(context.Context, livekit.RoomName) (*service.RoomPlacementInfo, error)
+	loadRoomPlacementMutex       sync.RWMutex
+	loadRoomPlacementArgsForCall []struct {
+		arg1 context.Context
+		arg2 livekit.RoomName
+	}
+	loadRoomPlacementReturns struct {
+		result1 *service.RoomPlacementInfo
+		result2 error
+	}
+	loadRoomPlacementReturnsOnCall map[int]struct {
+		result1 *service.RoomPlacementInfo
+		result2 error
+	}
 	LockRoomStub        func(context.Context, livekit.RoomName, time.Duration) (string, error)
 	lockRoomMutex       sync.RWMutex
 	lockRoomArgsForCall []struct {
@@ -137,6 +151,19 @@ type FakeObjectStore struct {
 	storeRoomReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StoreRoomPlacementStub        func(context.Context, livekit.RoomName, *service.RoomPlacementInfo) error
+	storeRoomPlacementMutex       sync.RWMutex
+	storeRoomPlacementArgsForCall []struct {
+		arg1 context.Context
+		arg2 livekit.RoomName
+		arg3 *service.RoomPlacementInfo
+	}
+	storeRoomPlacementReturns struct {
+		result1 error
+	}
+	storeRoomPlacementReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UnlockRoomStub        func(context.Context, livekit.RoomName, string) error
 	unlockRoomMutex       sync.RWMutex
 	unlockRoomArgsForCall []struct {
@@ -549,6 +576,71 @@ func (fake *FakeObjectStore) LoadRoomReturnsOnCall(i int, result1 *livekit.Room,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeObjectStore) LoadRoomPlacement(arg1 context.Context, arg2 livekit.RoomName) (*service.RoomPlacementInfo, error) {
+	fake.loadRoomPlacementMutex.Lock()
+	ret, specificReturn := fake.loadRoomPlacementReturnsOnCall[len(fake.loadRoomPlacementArgsForCall)]
+	fake.loadRoomPlacementArgsForCall = append(fake.loadRoomPlacementArgsForCall, struct {
+		arg1 context.Context
+		arg2 livekit.RoomName
+	}{arg1, arg2})
+	stub := fake.LoadRoomPlacementStub
+	fakeReturns := fake.loadRoomPlacementReturns
+	fake.recordInvocation("LoadRoomPlacement", []interface{}{arg1, arg2})
+	fake.loadRoomPlacementMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeObjectStore) LoadRoomPlacementCallCount() int {
+	fake.loadRoomPlacementMutex.RLock()
+	defer fake.loadRoomPlacementMutex.RUnlock()
+	return len(fake.loadRoomPlacementArgsForCall)
+}
+
+func (fake *FakeObjectStore) LoadRoomPlacementCalls(stub func(context.Context, livekit.RoomName) (*service.RoomPlacementInfo, error)) {
+	fake.loadRoomPlacementMutex.Lock()
+	defer fake.loadRoomPlacementMutex.Unlock()
+	fake.LoadRoomPlacementStub = stub
+}
+
+func (fake *FakeObjectStore) LoadRoomPlacementArgsForCall(i int) (context.Context, livekit.RoomName) {
+	fake.loadRoomPlacementMutex.RLock()
+	defer fake.loadRoomPlacementMutex.RUnlock()
+	argsForCall := fake.loadRoomPlacementArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeObjectStore) LoadRoomPlacementReturns(result1 *service.RoomPlacementInfo, result2 error) {
+	fake.loadRoomPlacementMutex.Lock()
+	defer fake.loadRoomPlacementMutex.Unlock()
+	fake.LoadRoomPlacementStub = nil
+	fake.loadRoomPlacementReturns = struct {
+		result1 *service.RoomPlacementInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeObjectStore) LoadRoomPlacementReturnsOnCall(i int, result1 *service.RoomPlacementInfo, result2 error) {
+	fake.loadRoomPlacementMutex.Lock()
+	defer fake.loadRoomPlacementMutex.Unlock()
+	fake.LoadRoomPlacementStub = nil
+	if fake.loadRoomPlacementReturnsOnCall == nil {
+		fake.loadRoomPlacementReturnsOnCall = make(map[int]struct {
+			result1 *service.RoomPlacementInfo
+			result2 error
+		})
+	}
+	fake.loadRoomPlacementReturnsOnCall[i] = struct {
+		result1 *service.RoomPlacementInfo
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeObjectStore) LockRoom(arg1 context.Context, arg2 livekit.RoomName, arg3 time.Duration) (string, error) {
 	fake.lockRoomMutex.Lock()
 	ret, specificReturn := fake.lockRoomReturnsOnCall[len(fake.lockRoomArgsForCall)]
@@ -741,6 +833,69 @@ func (fake *FakeObjectStore) StoreRoomReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeObjectStore) StoreRoomPlacement(arg1 context.Context, arg2 livekit.RoomName, arg3 *service.RoomPlacementInfo) error {
+	fake.storeRoomPlacementMutex.Lock()
+	ret, specificReturn := fake.storeRoomPlacementReturnsOnCall[len(fake.storeRoomPlacementArgsForCall)]
+	fake.storeRoomPlacementArgsForCall = append(fake.storeRoomPlacementArgsForCall, struct {
+		arg1 context.Context
+		arg2 livekit.RoomName
+		arg3 *service.RoomPlacementInfo
+	}{arg1, arg2, arg3})
+	stub := fake.StoreRoomPlacementStub
+	fakeReturns := fake.storeRoomPlacementReturns
+	fake.recordInvocation("StoreRoomPlacement", []interface{}{arg1, arg2, arg3})
+	fake.storeRoomPlacementMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeObjectStore) StoreRoomPlacementCallCount() int {
+	fake.storeRoomPlacementMutex.RLock()
+	defer fake.storeRoomPlacementMutex.RUnlock()
+	return len(fake.storeRoomPlacementArgsForCall)
+}
+
+func (fake *FakeObjectStore) StoreRoomPlacementCalls(stub func(context.Context, livekit.RoomName, *service.RoomPlacementInfo) error) {
+	fake.storeRoomPlacementMutex.Lock()
+	defer fake.storeRoomPlacementMutex.Unlock()
+	fake.StoreRoomPlacementStub = stub
+}
+
+func (fake *FakeObjectStore) StoreRoomPlacementArgsForCall(i int) (context.Context, livekit.RoomName, *service.RoomPlacementInfo) {
+	fake.storeRoomPlacementMutex.RLock()
+	defer fake.storeRoomPlacementMutex.RUnlock()
+	argsForCall := fake.storeRoomPlacementArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeObjectStore) StoreRoomPlacementReturns(result1 error) {
+	fake.storeRoomPlacementMutex.Lock()
+	defer fake.storeRoomPlacementMutex.Unlock()
+	fake.StoreRoomPlacementStub = nil
+	fake.storeRoomPlacementReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeObjectStore) StoreRoomPlacementReturnsOnCall(i int, result1 error) {
+	fake.storeRoomPlacementMutex.Lock()
+	defer fake.storeRoomPlacementMutex.Unlock()
+	fake.StoreRoomPlacementStub = nil
+	if fake.storeRoomPlacementReturnsOnCall == nil {
+		fake.storeRoomPlacementReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.storeRoomPlacementReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeObjectStore) UnlockRoom(arg1 context.Context, arg2 livekit.RoomName, arg3 string) error {
 	fake.unlockRoomMutex.Lock()
 	ret, specificReturn := fake.unlockRoomReturnsOnCall[len(fake.unlockRoomArgsForCall)]
@@ -819,12 +974,16 @@ func (fake *FakeObjectStore) Invocations() map[string][][]interface{} {
 	defer fake.loadParticipantMutex.RUnlock()
 	fake.loadRoomMutex.RLock()
 	defer fake.loadRoomMutex.RUnlock()
+	fake.loadRoomPlacementMutex.RLock()
+	defer fake.loadRoomPlacementMutex.RUnlock()
 	fake.lockRoomMutex.RLock()
 	defer fake.lockRoomMutex.RUnlock()
 	fake.storeParticipantMutex.RLock()
 	defer fake.storeParticipantMutex.RUnlock()
 	fake.storeRoomMutex.RLock()
 	defer fake.storeRoomMutex.RUnlock()
+	fake.storeRoomPlacementMutex.RLock()
+	defer fake.storeRoomPlacementMutex.RUnlock()
 	fake.unlockRoomMutex.RLock()
 	defer fake.unlockRoomMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
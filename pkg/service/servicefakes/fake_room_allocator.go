@@ -40,18 +40,37 @@ type FakeRoomAllocator struct {
 		result3 bool
 		result4 error
 	}
-	SelectRoomNodeStub        func(context.Context, livekit.RoomName, livekit.NodeID) error
+	SelectMigrationNodeStub        func(context.Context, livekit.RoomName, livekit.NodeID) (livekit.NodeID, error)
+	selectMigrationNodeMutex       sync.RWMutex
+	selectMigrationNodeArgsForCall []struct {
+		arg1 context.Context
+		arg2 livekit.RoomName
+		arg3 livekit.NodeID
+	}
+	selectMigrationNodeReturns struct {
+		result1 livekit.NodeID
+		result2 error
+	}
+	selectMigrationNodeReturnsOnCall map[int]struct {
+		result1 livekit.NodeID
+		result2 error
+	}
+	SelectRoomNodeStub        func(context.Context, livekit.RoomName, livekit.NodeID, string, string) (string, error)
 	selectRoomNodeMutex       sync.RWMutex
 	selectRoomNodeArgsForCall []struct {
 		arg1 context.Context
 		arg2 livekit.RoomName
 		arg3 livekit.NodeID
+		arg4 string
+		arg5 string
 	}
 	selectRoomNodeReturns struct {
-		result1 error
+		result1 string
+		result2 error
 	}
 	selectRoomNodeReturnsOnCall map[int]struct {
-		result1 error
+		result1 string
+		result2 error
 	}
 	ValidateCreateRoomStub        func(context.Context, livekit.RoomName) error
 	validateCreateRoomMutex       sync.RWMutex
@@ -202,25 +221,93 @@ func (fake *FakeRoomAllocator) CreateRoomReturnsOnCall(i int, result1 *livekit.R
 	}{result1, result2, result3, result4}
 }
 
-func (fake *FakeRoomAllocator) SelectRoomNode(arg1 context.Context, arg2 livekit.RoomName, arg3 livekit.NodeID) error {
+func (fake *FakeRoomAllocator) SelectMigrationNode(arg1 context.Context, arg2 livekit.RoomName, arg3 livekit.NodeID) (livekit.NodeID, error) {
+	fake.selectMigrationNodeMutex.Lock()
+	ret, specificReturn := fake.selectMigrationNodeReturnsOnCall[len(fake.selectMigrationNodeArgsForCall)]
+	fake.selectMigrationNodeArgsForCall = append(fake.selectMigrationNodeArgsForCall, struct {
+		arg1 context.Context
+		arg2 livekit.RoomName
+		arg3 livekit.NodeID
+	}{arg1, arg2, arg3})
+	stub := fake.SelectMigrationNodeStub
+	fakeReturns := fake.selectMigrationNodeReturns
+	fake.recordInvocation("SelectMigrationNode", []interface{}{arg1, arg2, arg3})
+	fake.selectMigrationNodeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRoomAllocator) SelectMigrationNodeCallCount() int {
+	fake.selectMigrationNodeMutex.RLock()
+	defer fake.selectMigrationNodeMutex.RUnlock()
+	return len(fake.selectMigrationNodeArgsForCall)
+}
+
+func (fake *FakeRoomAllocator) SelectMigrationNodeCalls(stub func(context.Context, livekit.RoomName, livekit.NodeID) (livekit.NodeID, error)) {
+	fake.selectMigrationNodeMutex.Lock()
+	defer fake.selectMigrationNodeMutex.Unlock()
+	fake.SelectMigrationNodeStub = stub
+}
+
+func (fake *FakeRoomAllocator) SelectMigrationNodeArgsForCall(i int) (context.Context, livekit.RoomName, livekit.NodeID) {
+	fake.selectMigrationNodeMutex.RLock()
+	defer fake.selectMigrationNodeMutex.RUnlock()
+	argsForCall := fake.selectMigrationNodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeRoomAllocator) SelectMigrationNodeReturns(result1 livekit.NodeID, result2 error) {
+	fake.selectMigrationNodeMutex.Lock()
+	defer fake.selectMigrationNodeMutex.Unlock()
+	fake.SelectMigrationNodeStub = nil
+	fake.selectMigrationNodeReturns = struct {
+		result1 livekit.NodeID
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRoomAllocator) SelectMigrationNodeReturnsOnCall(i int, result1 livekit.NodeID, result2 error) {
+	fake.selectMigrationNodeMutex.Lock()
+	defer fake.selectMigrationNodeMutex.Unlock()
+	fake.SelectMigrationNodeStub = nil
+	if fake.selectMigrationNodeReturnsOnCall == nil {
+		fake.selectMigrationNodeReturnsOnCall = make(map[int]struct {
+			result1 livekit.NodeID
+			result2 error
+		})
+	}
+	fake.selectMigrationNodeReturnsOnCall[i] = struct {
+		result1 livekit.NodeID
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRoomAllocator) SelectRoomNode(arg1 context.Context, arg2 livekit.RoomName, arg3 livekit.NodeID, arg4 string, arg5 string) (string, error) {
 	fake.selectRoomNodeMutex.Lock()
 	ret, specificReturn := fake.selectRoomNodeReturnsOnCall[len(fake.selectRoomNodeArgsForCall)]
 	fake.selectRoomNodeArgsForCall = append(fake.selectRoomNodeArgsForCall, struct {
 		arg1 context.Context
 		arg2 livekit.RoomName
 		arg3 livekit.NodeID
-	}{arg1, arg2, arg3})
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.SelectRoomNodeStub
 	fakeReturns := fake.selectRoomNodeReturns
-	fake.recordInvocation("SelectRoomNode", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("SelectRoomNode", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.selectRoomNodeMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeRoomAllocator) SelectRoomNodeCallCount() int {
@@ -229,40 +316,43 @@ func (fake *FakeRoomAllocator) SelectRoomNodeCallCount() int {
 	return len(fake.selectRoomNodeArgsForCall)
 }
 
-func (fake *FakeRoomAllocator) SelectRoomNodeCalls(stub func(context.Context, livekit.RoomName, livekit.NodeID) error) {
+func (fake *FakeRoomAllocator) SelectRoomNodeCalls(stub func(context.Context, livekit.RoomName, livekit.NodeID, string, string) (string, error)) {
 	fake.selectRoomNodeMutex.Lock()
 	defer fake.selectRoomNodeMutex.Unlock()
 	fake.SelectRoomNodeStub = stub
 }
 
-func (fake *FakeRoomAllocator) SelectRoomNodeArgsForCall(i int) (context.Context, livekit.RoomName, livekit.NodeID) {
+func (fake *FakeRoomAllocator) SelectRoomNodeArgsForCall(i int) (context.Context, livekit.RoomName, livekit.NodeID, string, string) {
 	fake.selectRoomNodeMutex.RLock()
 	defer fake.selectRoomNodeMutex.RUnlock()
 	argsForCall := fake.selectRoomNodeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
-func (fake *FakeRoomAllocator) SelectRoomNodeReturns(result1 error) {
+func (fake *FakeRoomAllocator) SelectRoomNodeReturns(result1 string, result2 error) {
 	fake.selectRoomNodeMutex.Lock()
 	defer fake.selectRoomNodeMutex.Unlock()
 	fake.SelectRoomNodeStub = nil
 	fake.selectRoomNodeReturns = struct {
-		result1 error
-	}{result1}
+		result1 string
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeRoomAllocator) SelectRoomNodeReturnsOnCall(i int, result1 error) {
+func (fake *FakeRoomAllocator) SelectRoomNodeReturnsOnCall(i int, result1 string, result2 error) {
 	fake.selectRoomNodeMutex.Lock()
 	defer fake.selectRoomNodeMutex.Unlock()
 	fake.SelectRoomNodeStub = nil
 	if fake.selectRoomNodeReturnsOnCall == nil {
 		fake.selectRoomNodeReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 string
+			result2 error
 		})
 	}
 	fake.selectRoomNodeReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 string
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeRoomAllocator) ValidateCreateRoom(arg1 context.Context, arg2 livekit.RoomName) error {
@@ -334,6 +424,8 @@ func (fake *FakeRoomAllocator) Invocations() map[string][][]interface{} {
 	defer fake.autoCreateEnabledMutex.RUnlock()
 	fake.createRoomMutex.RLock()
 	defer fake.createRoomMutex.RUnlock()
+	fake.selectMigrationNodeMutex.RLock()
+	defer fake.selectMigrationNodeMutex.RUnlock()
 	fake.selectRoomNodeMutex.RLock()
 	defer fake.selectRoomNodeMutex.RUnlock()
 	fake.validateCreateRoomMutex.RLock()
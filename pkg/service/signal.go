@@ -18,11 +18,13 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/livekit/livekit-server/pkg/config"
 	"github.com/livekit/livekit-server/pkg/routing"
 	"github.com/livekit/livekit-server/pkg/telemetry/prometheus"
+	"github.com/livekit/livekit-server/pkg/telemetry/tracing"
 	"github.com/livekit/protocol/livekit"
 	"github.com/livekit/protocol/logger"
 	"github.com/livekit/protocol/rpc"
@@ -144,6 +146,7 @@ func (r *signalService) RelaySignal(stream psrpc.ServerStream[*rpc.RelaySignalRe
 	if err != nil {
 		return errors.Wrap(err, "failed to read participant from session")
 	}
+	pi.UseSinglePeerConnection = routing.IsSinglePeerConnection(stream.Context())
 
 	l := r.sessionHandler.Logger(stream.Context()).WithValues(
 		"room", ss.RoomName,
@@ -177,7 +180,13 @@ func (r *signalService) RelaySignal(stream psrpc.ServerStream[*rpc.RelaySignalRe
 	// and the delivery of any parting messages from the client. take care to
 	// copy the incoming rpc headers to avoid dropping any session vars.
 	ctx := metadata.NewContextWithIncomingHeader(context.Background(), metadata.IncomingHeader(stream.Context()))
+	ctx, span := tracing.Start(tracing.ExtractPSRPC(ctx), "SignalServer.RelaySignal", trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(
+		tracing.AttrRoomName.String(ss.RoomName),
+		tracing.AttrParticipantIdentity.String(ss.Identity),
+		tracing.AttrConnectionID.String(ss.ConnectionId),
+	))
 	err = r.sessionHandler.HandleSession(ctx, *pi, livekit.ConnectionID(ss.ConnectionId), reqChan, sink)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		sink.Close()
 		l.Errorw("could not handle new participant", err)
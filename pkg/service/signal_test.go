@@ -106,6 +106,45 @@ func TestSignal(t *testing.T) {
 		require.True(t, proto.Equal(resMessageIn, resMessageOut), "res message should match %s %s", protojson.Format(resMessageIn), protojson.Format(resMessageOut))
 	})
 
+	t.Run("single peer connection mode is relayed", func(t *testing.T) {
+		bus := psrpc.NewLocalMessageBus()
+
+		client, err := routing.NewSignalClient(livekit.NodeID("node0"), bus, cfg)
+		require.NoError(t, err)
+
+		received := make(chan routing.ParticipantInit, 1)
+		handler := &servicefakes.FakeSessionHandler{
+			LoggerStub: func(context.Context) logger.Logger { return logger.GetLogger() },
+			HandleSessionStub: func(
+				ctx context.Context,
+				pi routing.ParticipantInit,
+				connectionID livekit.ConnectionID,
+				requestSource routing.MessageSource,
+				responseSink routing.MessageSink,
+			) error {
+				received <- pi
+				responseSink.Close()
+				return nil
+			},
+		}
+		server, err := service.NewSignalServer(livekit.NodeID("node1"), "region", bus, cfg, handler)
+		require.NoError(t, err)
+		require.NoError(t, server.Start())
+		defer server.Stop()
+
+		_, _, _, err = client.StartParticipantSignal(
+			context.Background(),
+			livekit.RoomName("room1"),
+			routing.ParticipantInit{Identity: "p1", UseSinglePeerConnection: true},
+			livekit.NodeID("node1"),
+		)
+		require.NoError(t, err)
+
+		pi := <-received
+		require.Equal(t, livekit.ParticipantIdentity("p1"), pi.Identity)
+		require.True(t, pi.UseSinglePeerConnection)
+	})
+
 	t.Run("messages are delivered when session handler fails", func(t *testing.T) {
 		bus := psrpc.NewLocalMessageBus()
 
@@ -23,11 +23,15 @@ import (
 	"time"
 
 	"github.com/twitchtv/twirp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/livekit/livekit-server/pkg/telemetry"
 	"github.com/livekit/livekit-server/pkg/telemetry/prometheus"
+	"github.com/livekit/livekit-server/pkg/telemetry/tracing"
 	"github.com/livekit/livekit-server/pkg/utils"
 	"github.com/livekit/protocol/livekit"
 )
@@ -412,3 +416,47 @@ func telemetryErrorReceived(ctx context.Context, e twirp.Error) context.Context
 }
 
 // --------------------------------------------------------------------------
+
+type twirpTracingKey struct{}
+
+// TwirpTracing creates a span for each API request. psrpc requests made while handling
+// the request carry its span context to the node they are sent to.
+func TwirpTracing() *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		RequestRouted: tracingRequestRouted,
+		Error:         tracingErrorReceived,
+		ResponseSent:  tracingResponseSent,
+	}
+}
+
+func tracingRequestRouted(ctx context.Context) (context.Context, error) {
+	svc, _ := twirp.ServiceName(ctx)
+	meth, _ := twirp.MethodName(ctx)
+
+	ctx, span := tracing.Start(ctx, "twirp "+svc+"."+meth, trace.WithSpanKind(trace.SpanKindServer))
+	ctx = context.WithValue(ctx, twirpTracingKey{}, span)
+	return tracing.InjectPSRPC(ctx), nil
+}
+
+func tracingErrorReceived(ctx context.Context, e twirp.Error) context.Context {
+	span, ok := ctx.Value(twirpTracingKey{}).(trace.Span)
+	if !ok || span == nil {
+		return ctx
+	}
+
+	span.RecordError(e)
+	span.SetStatus(codes.Error, e.Msg())
+	return ctx
+}
+
+func tracingResponseSent(ctx context.Context) {
+	span, ok := ctx.Value(twirpTracingKey{}).(trace.Span)
+	if !ok || span == nil {
+		return
+	}
+
+	if statusCode, ok := twirp.StatusCode(ctx); ok {
+		span.SetAttributes(attribute.String("http.response.status_code", statusCode))
+	}
+	span.End()
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/pion/sdp/v3"
+
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/rtc"
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	"github.com/livekit/livekit-server/pkg/telemetry/prometheus"
+)
+
+// WHEPService lets WHEP clients (e.g. players, smart TVs, monitoring) receive the tracks of a participant without an SDK.
+// Each session is a subscriber-only participant with a single peer connection, negotiated with one offer/answer
+// exchange. Tracks are sent with the usual down tracks and stream allocator, as many of each kind as the offer
+// can receive. Requests are authenticated with a join token. Only rooms hosted on the node receiving them can be
+// played, there is no publisher to receive from in a room which is not hosted yet.
+//
+// POST /whep?participant=<identity>: body is the SDP offer, responds with the answer and the session resource in
+// the Location header. Without participant, the tracks of the active speaker at the time of the request are sent.
+// PATCH <resource>: trickles ICE candidates of the client (ICE restarts are not supported)
+// DELETE <resource>: ends the session
+type WHEPService struct {
+	rtcService  *RTCService
+	roomManager *RoomManager
+}
+
+func NewWHEPService(rtcService *RTCService, roomManager *RoomManager) *WHEPService {
+	return &WHEPService{
+		rtcService:  rtcService,
+		roomManager: roomManager,
+	}
+}
+
+func (s *WHEPService) SetupRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /whep", s.createSession)
+	mux.HandleFunc("PATCH /whep/{room}/{participant}", s.trickle)
+	mux.HandleFunc("DELETE /whep/{room}/{participant}", s.deleteSession)
+}
+
+func (s *WHEPService) createSession(w http.ResponseWriter, r *http.Request) {
+	if !hasContentType(r, sdpContentType) {
+		handleError(w, r, http.StatusUnsupportedMediaType, fmt.Errorf("content type must be %s", sdpContentType))
+		return
+	}
+
+	roomName, pi, code, err := s.rtcService.validateInternal(r)
+	if err != nil {
+		handleError(w, r, code, err)
+		return
+	}
+	if !pi.Grants.Video.GetCanSubscribe() {
+		handleError(w, r, http.StatusUnauthorized, rtc.ErrPermissionDenied)
+		return
+	}
+	// subscriber only, tracks are picked by the server
+	pi.Grants.Video.SetCanPublish(false)
+	pi.Grants.Video.SetCanPublishData(false)
+	pi.AutoSubscribe = false
+	pi.Reconnect = false
+
+	offer, err := io.ReadAll(io.LimitReader(r.Body, maxSDPSize))
+	if err != nil {
+		handleError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	parsed := &sdp.SessionDescription{}
+	if err = parsed.Unmarshal(offer); err != nil {
+		handleError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// only play rooms that are already running
+	room := s.roomManager.GetRoom(r.Context(), roomName)
+	if room == nil {
+		handleError(w, r, http.StatusNotFound, ErrRoomNotFound, "room", roomName)
+		return
+	}
+	publisherIdentity := livekit.ParticipantIdentity(r.FormValue("participant"))
+	publisher := whepPublisher(room, publisherIdentity)
+	if publisher == nil {
+		handleError(w, r, http.StatusNotFound, ErrParticipantNotFound, "room", roomName, "publisher", publisherIdentity)
+		return
+	}
+	tracks := whepTracks(publisher.GetPublishedTracks(), parsed)
+	if len(tracks) == 0 {
+		handleError(w, r, http.StatusNotFound, ErrTrackNotFound, "room", roomName, "publisher", publisher.Identity())
+		return
+	}
+
+	// the session outlives the request
+	room, lp, err := s.roomManager.StartOneShotSession(context.WithoutCancel(r.Context()), pi)
+	if err != nil {
+		prometheus.IncrementParticipantJoinFail(1)
+		handleError(w, r, oneShotSessionErrorStatus(err), err, "room", roomName, "participant", pi.Identity)
+		return
+	}
+
+	// down tracks are added before the offer is applied, so that they are matched with the offered transceivers
+	for _, track := range tracks {
+		lp.SubscribeToTrack(track.ID())
+	}
+	answer, err := negotiateOneShot(lp, string(offer))
+	if err != nil {
+		prometheus.IncrementParticipantJoinFail(1)
+		room.RemoveParticipant(lp.Identity(), lp.ID(), types.ParticipantCloseReasonNegotiateFailed)
+		handleError(w, r, http.StatusBadRequest, err, "room", roomName, "participant", pi.Identity)
+		return
+	}
+	prometheus.IncrementParticipantJoin(1)
+
+	lp.GetLogger().Infow("WHEP session started", "publisher", publisher.Identity(), "numTracks", len(tracks))
+	w.Header().Set("Content-Type", sdpContentType)
+	w.Header().Set("Location", oneShotResourcePath("/whep", roomName, lp.ID()))
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write([]byte(answer.SDP))
+}
+
+func (s *WHEPService) trickle(w http.ResponseWriter, r *http.Request) {
+	trickleOneShotSession(w, r, s.roomManager)
+}
+
+func (s *WHEPService) deleteSession(w http.ResponseWriter, r *http.Request) {
+	deleteOneShotSession(w, r, s.roomManager)
+}
+
+// whepPublisher returns the participant to play, the loudest active speaker when no identity is given,
+// or any participant publishing when no one is speaking
+func whepPublisher(room *rtc.Room, identity livekit.ParticipantIdentity) types.LocalParticipant {
+	if identity != "" {
+		return room.GetParticipant(identity)
+	}
+
+	for _, speaker := range room.GetActiveSpeakers() {
+		if p := room.GetParticipantByID(livekit.ParticipantID(speaker.Sid)); p != nil {
+			return p
+		}
+	}
+	for _, p := range room.GetParticipants() {
+		if len(p.GetPublishedTracks()) != 0 {
+			return p
+		}
+	}
+	return nil
+}
+
+// whepTracks picks the tracks to send, as many of each kind as the offer can receive.
+// Camera and microphone are preferred over screen share.
+func whepTracks(tracks []types.MediaTrack, offer *sdp.SessionDescription) []types.MediaTrack {
+	receivable := make(map[livekit.TrackType]int)
+	for _, m := range offer.MediaDescriptions {
+		if _, ok := m.Attribute(sdp.AttrKeySendOnly); ok {
+			continue
+		}
+		if _, ok := m.Attribute(sdp.AttrKeyInactive); ok {
+			continue
+		}
+		switch strings.ToLower(m.MediaName.Media) {
+		case "audio":
+			receivable[livekit.TrackType_AUDIO]++
+		case "video":
+			receivable[livekit.TrackType_VIDEO]++
+		}
+	}
+
+	isScreenShare := func(t types.MediaTrack) bool {
+		return t.Source() == livekit.TrackSource_SCREEN_SHARE || t.Source() == livekit.TrackSource_SCREEN_SHARE_AUDIO
+	}
+	tracks = slices.Clone(tracks)
+	slices.SortStableFunc(tracks, func(a, b types.MediaTrack) int {
+		switch {
+		case !isScreenShare(a) && isScreenShare(b):
+			return -1
+		case isScreenShare(a) && !isScreenShare(b):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	var selected []types.MediaTrack
+	for _, t := range tracks {
+		if receivable[t.Kind()] > 0 {
+			receivable[t.Kind()]--
+			selected = append(selected, t)
+		}
+	}
+	return selected
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/pion/sdp/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	"github.com/livekit/livekit-server/pkg/rtc/types/typesfakes"
+)
+
+func fakeMediaTrack(id livekit.TrackID, kind livekit.TrackType, source livekit.TrackSource) *typesfakes.FakeMediaTrack {
+	t := &typesfakes.FakeMediaTrack{}
+	t.IDReturns(id)
+	t.KindReturns(kind)
+	t.SourceReturns(source)
+	return t
+}
+
+func TestWHEPTracks(t *testing.T) {
+	offer := func(attrs ...string) *sdp.SessionDescription {
+		s := &sdp.SessionDescription{}
+		for i, a := range attrs {
+			media := "audio"
+			if i%2 == 1 {
+				media = "video"
+			}
+			s.MediaDescriptions = append(s.MediaDescriptions, &sdp.MediaDescription{
+				MediaName:  sdp.MediaName{Media: media},
+				Attributes: []sdp.Attribute{{Key: a}},
+			})
+		}
+		return s
+	}
+
+	screen := fakeMediaTrack("TR_screen", livekit.TrackType_VIDEO, livekit.TrackSource_SCREEN_SHARE)
+	camera := fakeMediaTrack("TR_camera", livekit.TrackType_VIDEO, livekit.TrackSource_CAMERA)
+	mic := fakeMediaTrack("TR_mic", livekit.TrackType_AUDIO, livekit.TrackSource_MICROPHONE)
+	tracks := []types.MediaTrack{screen, camera, mic}
+
+	ids := func(tracks []types.MediaTrack) []livekit.TrackID {
+		var ids []livekit.TrackID
+		for _, t := range tracks {
+			ids = append(ids, t.ID())
+		}
+		return ids
+	}
+
+	// camera is preferred over screen share
+	require.Equal(t, []livekit.TrackID{"TR_camera", "TR_mic"}, ids(whepTracks(tracks, offer(sdp.AttrKeyRecvOnly, sdp.AttrKeyRecvOnly))))
+	// two video sections receive both
+	require.Equal(t, []livekit.TrackID{"TR_camera", "TR_mic", "TR_screen"}, ids(whepTracks(tracks, offer(sdp.AttrKeyRecvOnly, sdp.AttrKeyRecvOnly, sdp.AttrKeyInactive, sdp.AttrKeySendRecv))))
+	// audio only
+	require.Equal(t, []livekit.TrackID{"TR_mic"}, ids(whepTracks(tracks, offer(sdp.AttrKeyRecvOnly, sdp.AttrKeySendOnly))))
+	require.Empty(t, whepTracks(tracks, offer()))
+}
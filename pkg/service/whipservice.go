@@ -0,0 +1,247 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pion/webrtc/v4"
+
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/routing"
+	"github.com/livekit/livekit-server/pkg/rtc"
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	"github.com/livekit/livekit-server/pkg/telemetry/prometheus"
+)
+
+const (
+	sdpContentType        = "application/sdp"
+	trickleICEContentType = "application/trickle-ice-sdpfrag"
+
+	maxSDPSize = 64 * 1024
+)
+
+// WHIPService lets WHIP clients (e.g. OBS, GStreamer) publish into a room without an SDK or the ingress service.
+// Each session is a publisher-only participant with a single peer connection, negotiated with one offer/answer
+// exchange. Requests are authenticated with a join token. A room which is not hosted yet is created on the node
+// receiving them, requests for a room hosted on another node are refused with 503.
+// Tracks are published as they are offered, without transcoding.
+//
+// POST /whip: body is the SDP offer, responds with the answer and the session resource in the Location header
+// PATCH <resource>: trickles ICE candidates of the client (ICE restarts are not supported)
+// DELETE <resource>: ends the session
+type WHIPService struct {
+	rtcService  *RTCService
+	roomManager *RoomManager
+}
+
+func NewWHIPService(rtcService *RTCService, roomManager *RoomManager) *WHIPService {
+	return &WHIPService{
+		rtcService:  rtcService,
+		roomManager: roomManager,
+	}
+}
+
+func (s *WHIPService) SetupRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /whip", s.createSession)
+	mux.HandleFunc("PATCH /whip/{room}/{participant}", s.trickle)
+	mux.HandleFunc("DELETE /whip/{room}/{participant}", s.deleteSession)
+}
+
+func (s *WHIPService) createSession(w http.ResponseWriter, r *http.Request) {
+	if !hasContentType(r, sdpContentType) {
+		handleError(w, r, http.StatusUnsupportedMediaType, fmt.Errorf("content type must be %s", sdpContentType))
+		return
+	}
+
+	roomName, pi, code, err := s.rtcService.validateInternal(r)
+	if err != nil {
+		handleError(w, r, code, err)
+		return
+	}
+	if !pi.Grants.Video.GetCanPublish() {
+		handleError(w, r, http.StatusUnauthorized, rtc.ErrPermissionDenied)
+		return
+	}
+	// publisher only, there is no way to negotiate subscriptions afterwards
+	pi.Grants.Video.SetCanSubscribe(false)
+	pi.AutoSubscribe = false
+	pi.Reconnect = false
+
+	offer, err := io.ReadAll(io.LimitReader(r.Body, maxSDPSize))
+	if err != nil {
+		handleError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// the session outlives the request
+	room, lp, err := s.roomManager.StartOneShotSession(context.WithoutCancel(r.Context()), pi)
+	if err != nil {
+		prometheus.IncrementParticipantJoinFail(1)
+		handleError(w, r, oneShotSessionErrorStatus(err), err, "room", roomName, "participant", pi.Identity)
+		return
+	}
+
+	answer, err := negotiateOneShot(lp, string(offer))
+	if err != nil {
+		prometheus.IncrementParticipantJoinFail(1)
+		room.RemoveParticipant(lp.Identity(), lp.ID(), types.ParticipantCloseReasonNegotiateFailed)
+		handleError(w, r, http.StatusBadRequest, err, "room", roomName, "participant", pi.Identity)
+		return
+	}
+	prometheus.IncrementParticipantJoin(1)
+
+	lp.GetLogger().Infow("WHIP session started")
+	w.Header().Set("Content-Type", sdpContentType)
+	w.Header().Set("Location", oneShotResourcePath("/whip", roomName, lp.ID()))
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write([]byte(answer.SDP))
+}
+
+func (s *WHIPService) trickle(w http.ResponseWriter, r *http.Request) {
+	trickleOneShotSession(w, r, s.roomManager)
+}
+
+func (s *WHIPService) deleteSession(w http.ResponseWriter, r *http.Request) {
+	deleteOneShotSession(w, r, s.roomManager)
+}
+
+// trickleOneShotSession adds the ICE candidates of a PATCH request to the peer connection of the session
+func trickleOneShotSession(w http.ResponseWriter, r *http.Request, roomManager *RoomManager) {
+	_, lp, code, err := oneShotSessionParticipant(r, roomManager)
+	if err != nil {
+		handleError(w, r, code, err)
+		return
+	}
+	if !hasContentType(r, trickleICEContentType) {
+		handleError(w, r, http.StatusUnsupportedMediaType, fmt.Errorf("content type must be %s", trickleICEContentType))
+		return
+	}
+
+	frag, err := io.ReadAll(io.LimitReader(r.Body, maxSDPSize))
+	if err != nil {
+		handleError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	// one-shot sessions only have the publisher peer connection
+	for _, c := range parseTrickleICESDPFrag(string(frag)) {
+		lp.AddICECandidate(c, livekit.SignalTarget_PUBLISHER)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func deleteOneShotSession(w http.ResponseWriter, r *http.Request, roomManager *RoomManager) {
+	room, lp, code, err := oneShotSessionParticipant(r, roomManager)
+	if err != nil {
+		handleError(w, r, code, err)
+		return
+	}
+
+	lp.GetLogger().Infow("session ended by client", "path", r.URL.Path)
+	room.RemoveParticipant(lp.Identity(), lp.ID(), types.ParticipantCloseReasonClientRequestLeave)
+	w.WriteHeader(http.StatusOK)
+}
+
+// oneShotSessionParticipant returns the participant of a session resource, it has to be accessed with a token of the participant
+func oneShotSessionParticipant(r *http.Request, roomManager *RoomManager) (*rtc.Room, types.LocalParticipant, int, error) {
+	roomName := livekit.RoomName(r.PathValue("room"))
+	pID := livekit.ParticipantID(r.PathValue("participant"))
+
+	onlyName, err := EnsureJoinPermission(r.Context())
+	if err != nil {
+		return nil, nil, http.StatusUnauthorized, err
+	}
+	if onlyName != "" && onlyName != roomName {
+		return nil, nil, http.StatusUnauthorized, ErrPermissionDenied
+	}
+
+	room := roomManager.GetRoom(r.Context(), roomName)
+	if room == nil {
+		return nil, nil, http.StatusNotFound, ErrRoomNotFound
+	}
+	lp := room.GetParticipantByID(pID)
+	if lp == nil || string(lp.Identity()) != GetGrants(r.Context()).Identity {
+		return nil, nil, http.StatusNotFound, ErrParticipantNotFound
+	}
+	return room, lp, http.StatusOK, nil
+}
+
+func oneShotSessionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, routing.ErrIncorrectRTCNode), errors.Is(err, rtc.ErrLimitExceeded):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrRoomNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// negotiateOneShot applies the offer of a participant in one-shot signalling mode and returns the answer
+func negotiateOneShot(lp types.LocalParticipant, offer string) (webrtc.SessionDescription, error) {
+	if err := lp.HandleOffer(webrtc.SessionDescription{
+		Type: webrtc.SDPTypeOffer,
+		SDP:  offer,
+	}); err != nil {
+		return webrtc.SessionDescription{}, err
+	}
+	return lp.GetAnswer()
+}
+
+func oneShotResourcePath(prefix string, roomName livekit.RoomName, pID livekit.ParticipantID) string {
+	return prefix + "/" + url.PathEscape(string(roomName)) + "/" + url.PathEscape(string(pID))
+}
+
+func hasContentType(r *http.Request, contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == contentType
+}
+
+// parseTrickleICESDPFrag returns the candidates of an SDP fragment used to trickle ICE (RFC 8840)
+func parseTrickleICESDPFrag(frag string) []webrtc.ICECandidateInit {
+	var candidates []webrtc.ICECandidateInit
+	mLineIndex := -1
+	var mid *string
+	for _, line := range strings.Split(frag, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "m="):
+			mLineIndex++
+			mid = nil
+		case strings.HasPrefix(line, "a=mid:"):
+			m := strings.TrimPrefix(line, "a=mid:")
+			mid = &m
+		case strings.HasPrefix(line, "a=candidate:"):
+			c := webrtc.ICECandidateInit{
+				Candidate: strings.TrimPrefix(line, "a="),
+				SDPMid:    mid,
+			}
+			if mLineIndex >= 0 {
+				idx := uint16(mLineIndex)
+				c.SDPMLineIndex = &idx
+			}
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates
+}
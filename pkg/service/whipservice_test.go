@@ -0,0 +1,52 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/livekit"
+)
+
+func TestParseTrickleICESDPFrag(t *testing.T) {
+	frag := "a=ice-ufrag:EsAw\r\n" +
+		"a=ice-pwd:P2uYro0UCOQ4zxjKXaWCBui1\r\n" +
+		"m=audio 9 UDP/TLS/RTP/SAVPF 111\r\n" +
+		"a=mid:0\r\n" +
+		"a=candidate:1387637174 1 udp 2122260223 192.0.2.1 61764 typ host generation 0 ufrag EsAw network-id 1\r\n" +
+		"a=candidate:3471623853 1 udp 2122194687 198.51.100.2 61765 typ host generation 0 ufrag EsAw network-id 2\r\n" +
+		"m=video 9 UDP/TLS/RTP/SAVPF 96\r\n" +
+		"a=mid:1\r\n" +
+		"a=candidate:473322822 1 tcp 1518280447 192.0.2.1 9 typ host tcptype active generation 0 ufrag EsAw network-id 1\r\n" +
+		"a=end-of-candidates\r\n"
+
+	candidates := parseTrickleICESDPFrag(frag)
+	require.Len(t, candidates, 3)
+	require.Equal(t, "candidate:1387637174 1 udp 2122260223 192.0.2.1 61764 typ host generation 0 ufrag EsAw network-id 1", candidates[0].Candidate)
+	require.Equal(t, "0", *candidates[0].SDPMid)
+	require.Equal(t, uint16(0), *candidates[0].SDPMLineIndex)
+	require.Equal(t, "0", *candidates[1].SDPMid)
+	require.Equal(t, "1", *candidates[2].SDPMid)
+	require.Equal(t, uint16(1), *candidates[2].SDPMLineIndex)
+
+	require.Empty(t, parseTrickleICESDPFrag("a=ice-ufrag:EsAw\r\na=end-of-candidates\r\n"))
+}
+
+func TestOneShotResourcePath(t *testing.T) {
+	require.Equal(t, "/whip/my%20room/PA_123", oneShotResourcePath("/whip", livekit.RoomName("my room"), "PA_123"))
+	require.Equal(t, "/whip/a%2Fb/PA_123", oneShotResourcePath("/whip", livekit.RoomName("a/b"), "PA_123"))
+}
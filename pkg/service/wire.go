@@ -78,6 +78,12 @@ func InitializeServer(conf *config.Config, currentNode routing.LocalNode) (*Live
 		NewRoomAllocator,
 		NewRoomService,
 		NewRTCService,
+		NewWHIPService,
+		NewWHEPService,
+		NewPlainRTPService,
+		NewRTSPServer,
+		NewMediaFileService,
+		NewGeoIPResolver,
 		NewAgentService,
 		NewAgentDispatchService,
 		agent.NewAgentClient,
@@ -175,11 +181,14 @@ func createRedisClient(conf *config.Config) (redis.UniversalClient, error) {
 	return redisLiveKit.GetRedisClient(&conf.Redis)
 }
 
-func createStore(rc redis.UniversalClient) ObjectStore {
+func createStore(conf *config.Config, rc redis.UniversalClient) (ObjectStore, error) {
 	if rc != nil {
-		return NewRedisStore(rc)
+		return NewRedisStore(rc), nil
 	}
-	return NewLocalStore()
+	if conf.Store.Path != "" {
+		return NewBoltStore(conf.Store.Path)
+	}
+	return NewLocalStore(), nil
 }
 
 func getMessageBus(rc redis.UniversalClient) psrpc.MessageBus {
@@ -193,6 +202,8 @@ func getEgressStore(s ObjectStore) EgressStore {
 	switch store := s.(type) {
 	case *RedisStore:
 		return store
+	case *BoltStore:
+		return store
 	default:
 		return nil
 	}
@@ -202,6 +213,8 @@ func getIngressStore(s ObjectStore) IngressStore {
 	switch store := s.(type) {
 	case *RedisStore:
 		return store
+	case *BoltStore:
+		return store
 	default:
 		return nil
 	}
@@ -211,6 +224,8 @@ func getAgentStore(s ObjectStore) AgentStore {
 	switch store := s.(type) {
 	case *RedisStore:
 		return store
+	case *BoltStore:
+		return store
 	case *LocalStore:
 		return store
 	default:
@@ -226,6 +241,8 @@ func getSIPStore(s ObjectStore) SIPStore {
 	switch store := s.(type) {
 	case *RedisStore:
 		return store
+	case *BoltStore:
+		return store
 	default:
 		return nil
 	}
@@ -61,7 +61,10 @@ func InitializeServer(conf *config.Config, currentNode routing.LocalNode) (*Live
 		return nil, err
 	}
 	router := routing.CreateRouter(universalClient, currentNode, signalClient, roomManagerClient, keepalivePubSub)
-	objectStore := createStore(universalClient)
+	objectStore, err := createStore(conf, universalClient)
+	if err != nil {
+		return nil, err
+	}
 	roomAllocator, err := NewRoomAllocator(conf, router, objectStore)
 	if err != nil {
 		return nil, err
@@ -119,7 +122,11 @@ func InitializeServer(conf *config.Config, currentNode routing.LocalNode) (*Live
 		return nil, err
 	}
 	sipService := NewSIPService(sipConfig, nodeID, messageBus, sipClient, sipStore, roomService, telemetryService)
-	rtcService := NewRTCService(conf, roomAllocator, objectStore, router, currentNode, telemetryService)
+	geoIPResolver, err := NewGeoIPResolver(conf)
+	if err != nil {
+		return nil, err
+	}
+	rtcService := NewRTCService(conf, roomAllocator, objectStore, router, currentNode, telemetryService, geoIPResolver)
 	agentService, err := NewAgentService(conf, currentNode, messageBus, keyProvider)
 	if err != nil {
 		return nil, err
@@ -133,7 +140,7 @@ func InitializeServer(conf *config.Config, currentNode routing.LocalNode) (*Live
 	timedVersionGenerator := utils.NewDefaultTimedVersionGenerator()
 	turnAuthHandler := NewTURNAuthHandler(keyProvider)
 	forwardStats := createForwardStats(conf)
-	roomManager, err := NewLocalRoomManager(conf, objectStore, currentNode, router, roomAllocator, telemetryService, clientConfigurationManager, client, agentStore, rtcEgressLauncher, timedVersionGenerator, turnAuthHandler, messageBus, forwardStats)
+	roomManager, err := NewLocalRoomManager(conf, objectStore, currentNode, router, roomAllocator, telemetryService, clientConfigurationManager, client, agentStore, rtcEgressLauncher, timedVersionGenerator, turnAuthHandler, messageBus, forwardStats, geoIPResolver)
 	if err != nil {
 		return nil, err
 	}
@@ -141,12 +148,17 @@ func InitializeServer(conf *config.Config, currentNode routing.LocalNode) (*Live
 	if err != nil {
 		return nil, err
 	}
+	whipService := NewWHIPService(rtcService, roomManager)
+	whepService := NewWHEPService(rtcService, roomManager)
+	plainRTPService := NewPlainRTPService(conf, rtcService, roomManager)
+	rtspServer := NewRTSPServer(conf, roomManager, keyProvider)
+	mediaFileService := NewMediaFileService(conf, roomManager)
 	authHandler := getTURNAuthHandlerFunc(turnAuthHandler)
 	server, err := newInProcessTurnServer(conf, authHandler)
 	if err != nil {
 		return nil, err
 	}
-	livekitServer, err := NewLivekitServer(conf, roomService, agentDispatchService, egressService, ingressService, sipService, ioInfoService, rtcService, agentService, keyProvider, router, roomManager, signalServer, server, currentNode)
+	livekitServer, err := NewLivekitServer(conf, roomService, agentDispatchService, egressService, ingressService, sipService, ioInfoService, rtcService, whipService, whepService, plainRTPService, rtspServer, mediaFileService, agentService, telemetryService, keyProvider, router, roomManager, signalServer, server, currentNode, objectStore, geoIPResolver)
 	if err != nil {
 		return nil, err
 	}
@@ -235,11 +247,14 @@ func createRedisClient(conf *config.Config) (redis.UniversalClient, error) {
 	return redis2.GetRedisClient(&conf.Redis)
 }
 
-func createStore(rc redis.UniversalClient) ObjectStore {
+func createStore(conf *config.Config, rc redis.UniversalClient) (ObjectStore, error) {
 	if rc != nil {
-		return NewRedisStore(rc)
+		return NewRedisStore(rc), nil
 	}
-	return NewLocalStore()
+	if conf.Store.Path != "" {
+		return NewBoltStore(conf.Store.Path)
+	}
+	return NewLocalStore(), nil
 }
 
 func getMessageBus(rc redis.UniversalClient) psrpc.MessageBus {
@@ -253,6 +268,8 @@ func getEgressStore(s ObjectStore) EgressStore {
 	switch store := s.(type) {
 	case *RedisStore:
 		return store
+	case *BoltStore:
+		return store
 	default:
 		return nil
 	}
@@ -262,6 +279,8 @@ func getIngressStore(s ObjectStore) IngressStore {
 	switch store := s.(type) {
 	case *RedisStore:
 		return store
+	case *BoltStore:
+		return store
 	default:
 		return nil
 	}
@@ -271,6 +290,8 @@ func getAgentStore(s ObjectStore) AgentStore {
 	switch store := s.(type) {
 	case *RedisStore:
 		return store
+	case *BoltStore:
+		return store
 	case *LocalStore:
 		return store
 	default:
@@ -286,6 +307,8 @@ func getSIPStore(s ObjectStore) SIPStore {
 	switch store := s.(type) {
 	case *RedisStore:
 		return store
+	case *BoltStore:
+		return store
 	default:
 		return nil
 	}
@@ -17,12 +17,15 @@ package service
 import (
 	"errors"
 	"io"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/klauspost/compress/zstd"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/livekit/protocol/livekit"
@@ -34,12 +37,50 @@ import (
 const (
 	pingFrequency = 10 * time.Second
 	pingTimeout   = 2 * time.Second
+
+	maxSignalBatchSize = 64
 )
 
+type SignalCompression string
+
+const (
+	// permessage-deflate extension of the WebSocket, when the client offers it
+	SignalCompressionNone SignalCompression = ""
+	// each binary frame is a zstd frame
+	SignalCompressionZstd SignalCompression = "zstd"
+)
+
+// SignalEncoding is how protobuf responses are framed on a WebSocket, negotiated with the signal_batch
+// and signal_compression parameters of the connection request. Requests and JSON messages are not affected.
+type SignalEncoding struct {
+	// binary frames hold one or more responses, each prefixed with its varint encoded size
+	Batch       bool
+	Compression SignalCompression
+}
+
+func ParseSignalEncoding(r *http.Request) SignalEncoding {
+	enc := SignalEncoding{
+		Batch: boolValue(r.FormValue("signal_batch")),
+	}
+	switch c := SignalCompression(r.FormValue("signal_compression")); c {
+	case SignalCompressionZstd:
+		enc.Compression = c
+	}
+	return enc
+}
+
+// EncodeAll of an encoder can be used concurrently, share one across connections
+var zstdEncoder = sync.OnceValue(func() *zstd.Encoder {
+	enc, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1))
+	return enc
+})
+
 type WSSignalConnection struct {
-	conn    types.WebsocketClient
-	mu      sync.Mutex
-	useJSON bool
+	conn     types.WebsocketClient
+	mu       sync.Mutex
+	useJSON  bool
+	encoding SignalEncoding
+	onFrame  func(size int)
 }
 
 func NewWSSignalConnection(conn types.WebsocketClient) *WSSignalConnection {
@@ -52,6 +93,25 @@ func NewWSSignalConnection(conn types.WebsocketClient) *WSSignalConnection {
 	return wsc
 }
 
+func (c *WSSignalConnection) SetSignalEncoding(encoding SignalEncoding) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.encoding = encoding
+}
+
+func (c *WSSignalConnection) SignalEncoding() SignalEncoding {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.encoding
+}
+
+// OnFrame sets a callback for frames of responses written, with their size before permessage-deflate compression
+func (c *WSSignalConnection) OnFrame(f func(size int)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onFrame = f
+}
+
 func (c *WSSignalConnection) Close() error {
 	return c.conn.Close()
 }
@@ -129,25 +189,69 @@ func (c *WSSignalConnection) ReadWorkerMessage() (*livekit.WorkerMessage, int, e
 }
 
 func (c *WSSignalConnection) WriteResponse(msg *livekit.SignalResponse) (int, error) {
-	var msgType int
-	var payload []byte
-	var err error
+	return c.WriteResponses([]*livekit.SignalResponse{msg})
+}
 
+// WriteResponses writes responses, in a single frame when batching, and returns their encoded size
+func (c *WSSignalConnection) WriteResponses(msgs []*livekit.SignalResponse) (int, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	if c.useJSON {
-		msgType = websocket.TextMessage
-		payload, err = protojson.Marshal(msg)
-	} else {
-		msgType = websocket.BinaryMessage
-		payload, err = proto.Marshal(msg)
+		var size int
+		for _, msg := range msgs {
+			payload, err := protojson.Marshal(msg)
+			if err != nil {
+				return size, err
+			}
+			if err = c.writeMessageLocked(websocket.TextMessage, payload); err != nil {
+				return size, err
+			}
+			size += len(payload)
+		}
+		return size, nil
 	}
-	if err != nil {
-		return 0, err
+
+	if !c.encoding.Batch {
+		var size int
+		for _, msg := range msgs {
+			payload, err := proto.Marshal(msg)
+			if err != nil {
+				return size, err
+			}
+			if err = c.writeMessageLocked(websocket.BinaryMessage, payload); err != nil {
+				return size, err
+			}
+			size += len(payload)
+		}
+		return size, nil
 	}
 
-	return len(payload), c.conn.WriteMessage(msgType, payload)
+	var frame []byte
+	var size int
+	for _, msg := range msgs {
+		payload, err := proto.Marshal(msg)
+		if err != nil {
+			return 0, err
+		}
+		frame = protowire.AppendBytes(frame, payload)
+		size += len(payload)
+	}
+	return size, c.writeMessageLocked(websocket.BinaryMessage, frame)
+}
+
+func (c *WSSignalConnection) writeMessageLocked(msgType int, payload []byte) error {
+	if msgType == websocket.BinaryMessage && c.encoding.Compression == SignalCompressionZstd {
+		payload = zstdEncoder().EncodeAll(payload, nil)
+	}
+	if err := c.conn.WriteMessage(msgType, payload); err != nil {
+		return err
+	}
+
+	if c.onFrame != nil {
+		c.onFrame(len(payload))
+	}
+	return nil
 }
 
 func (c *WSSignalConnection) WriteServerMessage(msg *livekit.ServerMessage) (int, error) {
@@ -0,0 +1,116 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/rtc/types/typesfakes"
+	"github.com/livekit/livekit-server/pkg/service"
+)
+
+func TestParseSignalEncoding(t *testing.T) {
+	enc := service.ParseSignalEncoding(httptest.NewRequest("GET", "/rtc?signal_batch=1&signal_compression=zstd", nil))
+	require.Equal(t, service.SignalEncoding{Batch: true, Compression: service.SignalCompressionZstd}, enc)
+
+	enc = service.ParseSignalEncoding(httptest.NewRequest("GET", "/rtc?signal_compression=deflate", nil))
+	require.Equal(t, service.SignalCompressionNone, enc.Compression)
+
+	enc = service.ParseSignalEncoding(httptest.NewRequest("GET", "/rtc?signal_compression=brotli", nil))
+	require.Equal(t, service.SignalEncoding{}, enc)
+}
+
+func TestWSSignalConnectionBatching(t *testing.T) {
+	msgs := []*livekit.SignalResponse{
+		{Message: &livekit.SignalResponse_Pong{Pong: 1}},
+		{Message: &livekit.SignalResponse_Update{Update: &livekit.ParticipantUpdate{
+			Participants: []*livekit.ParticipantInfo{{Sid: "PA_1", Identity: "p1"}},
+		}}},
+		{Message: &livekit.SignalResponse_Pong{Pong: 2}},
+	}
+
+	decodeBatch := func(t *testing.T, frame []byte) []*livekit.SignalResponse {
+		var decoded []*livekit.SignalResponse
+		for len(frame) > 0 {
+			payload, n := protowire.ConsumeBytes(frame)
+			require.Greater(t, n, 0)
+			frame = frame[n:]
+
+			res := &livekit.SignalResponse{}
+			require.NoError(t, proto.Unmarshal(payload, res))
+			decoded = append(decoded, res)
+		}
+		return decoded
+	}
+
+	t.Run("unbatched", func(t *testing.T) {
+		client := &typesfakes.FakeWebsocketClient{}
+		c := service.NewWSSignalConnection(client)
+		_, err := c.WriteResponses(msgs)
+		require.NoError(t, err)
+		require.Equal(t, len(msgs), client.WriteMessageCallCount())
+	})
+
+	t.Run("batched", func(t *testing.T) {
+		client := &typesfakes.FakeWebsocketClient{}
+		c := service.NewWSSignalConnection(client)
+		c.SetSignalEncoding(service.SignalEncoding{Batch: true})
+		var frameSizes []int
+		c.OnFrame(func(size int) {
+			frameSizes = append(frameSizes, size)
+		})
+
+		size, err := c.WriteResponses(msgs)
+		require.NoError(t, err)
+		require.Equal(t, 1, client.WriteMessageCallCount())
+		msgType, frame := client.WriteMessageArgsForCall(0)
+		require.Equal(t, websocket.BinaryMessage, msgType)
+		require.Equal(t, []int{len(frame)}, frameSizes)
+		require.Less(t, size, len(frame))
+
+		decoded := decodeBatch(t, frame)
+		require.Len(t, decoded, len(msgs))
+		for i := range msgs {
+			require.True(t, proto.Equal(msgs[i], decoded[i]))
+		}
+	})
+
+	t.Run("batched zstd", func(t *testing.T) {
+		client := &typesfakes.FakeWebsocketClient{}
+		c := service.NewWSSignalConnection(client)
+		c.SetSignalEncoding(service.SignalEncoding{Batch: true, Compression: service.SignalCompressionZstd})
+
+		_, err := c.WriteResponses(msgs)
+		require.NoError(t, err)
+		require.Equal(t, 1, client.WriteMessageCallCount())
+		_, compressed := client.WriteMessageArgsForCall(0)
+
+		dec, err := zstd.NewReader(nil)
+		require.NoError(t, err)
+		defer dec.Close()
+		frame, err := dec.DecodeAll(compressed, nil)
+		require.NoError(t, err)
+		require.Len(t, decodeBatch(t, frame), len(msgs))
+	})
+}
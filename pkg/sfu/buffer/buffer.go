@@ -32,6 +32,7 @@ import (
 	"go.uber.org/atomic"
 
 	"github.com/livekit/livekit-server/pkg/sfu/audio"
+	"github.com/livekit/livekit-server/pkg/sfu/capture"
 	"github.com/livekit/livekit-server/pkg/sfu/mime"
 	act "github.com/livekit/livekit-server/pkg/sfu/rtpextension/abscapturetime"
 	dd "github.com/livekit/livekit-server/pkg/sfu/rtpextension/dependencydescriptor"
@@ -145,6 +146,8 @@ type Buffer struct {
 	rtxPktBuf           []byte
 
 	absCaptureTimeExtID uint8
+
+	capture atomic.Pointer[capture.Capture]
 }
 
 // NewBuffer constructs a new Buffer
@@ -371,6 +374,10 @@ func (b *Buffer) Write(pkt []byte) (n int, err error) {
 		return
 	}
 
+	if c := b.getCaptureLocked(); c != nil {
+		c.WriteRTP(capture.DirectionIn, pkt)
+	}
+
 	// handle RTX packet
 	if pb := b.primaryBufferForRTX; pb != nil {
 		b.Unlock()
@@ -402,6 +409,22 @@ func (b *Buffer) Write(pkt []byte) (n int, err error) {
 	return
 }
 
+// SetCapture starts copying packets of this stream, and its RTX stream, to c. Capturing stops with a nil c.
+func (b *Buffer) SetCapture(c *capture.Capture) {
+	b.capture.Store(c)
+}
+
+func (b *Buffer) GetCapture() *capture.Capture {
+	return b.capture.Load()
+}
+
+func (b *Buffer) getCaptureLocked() *capture.Capture {
+	if pb := b.primaryBufferForRTX; pb != nil {
+		return pb.GetCapture()
+	}
+	return b.capture.Load()
+}
+
 func (b *Buffer) SetPrimaryBufferForRTX(primaryBuffer *Buffer) {
 	b.Lock()
 	b.primaryBufferForRTX = primaryBuffer
@@ -0,0 +1,253 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"encoding/binary"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/frostbyte73/core"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"go.uber.org/atomic"
+
+	"github.com/livekit/protocol/logger"
+)
+
+// Direction of a captured packet, relative to the SFU
+type Direction int
+
+const (
+	// DirectionIn is from the client to the SFU
+	DirectionIn Direction = iota
+	// DirectionOut is from the SFU to the client
+	DirectionOut
+)
+
+// Captured packets are written as UDP over IPv4 between fixed addresses, so that the client and SFU
+// ends are easy to tell apart. Use "Decode As" RTP/RTCP on the ports below to dissect them in Wireshark.
+const (
+	PortRTP  = 5004
+	PortRTCP = 5005
+
+	ipv4HeaderSize = 20
+	udpHeaderSize  = 8
+
+	queueSize = 4096
+)
+
+var (
+	clientAddr = [4]byte{10, 0, 0, 1}
+	sfuAddr    = [4]byte{10, 0, 0, 2}
+)
+
+type Params struct {
+	// Duration after which the capture stops by itself
+	Duration time.Duration
+	// IncludePayload keeps RTP payloads, only headers are captured otherwise
+	IncludePayload bool
+	Logger         logger.Logger
+}
+
+type packet struct {
+	at      time.Time
+	data    []byte
+	origLen int
+}
+
+// Capture writes decrypted RTP/RTCP to a pcapng stream. Packets are handed to a writer goroutine
+// so that a slow destination never blocks forwarding, packets are dropped when it falls behind.
+type Capture struct {
+	params Params
+	writer *pcapngWriter
+
+	lock    sync.RWMutex
+	queue   chan packet
+	stopped bool
+
+	done core.Fuse
+
+	packets atomic.Uint64
+	dropped atomic.Uint64
+}
+
+func New(w io.Writer, params Params) (*Capture, error) {
+	if params.Logger == nil {
+		params.Logger = logger.GetLogger()
+	}
+
+	writer, err := newPCAPNGWriter(w)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Capture{
+		params: params,
+		writer: writer,
+		queue:  make(chan packet, queueSize),
+	}
+	go c.worker()
+	return c, nil
+}
+
+// WriteRTP captures a marshalled RTP packet
+func (c *Capture) WriteRTP(dir Direction, pkt []byte) {
+	captureLen := len(pkt)
+	if !c.params.IncludePayload {
+		var hdr rtp.Header
+		n, err := hdr.Unmarshal(pkt)
+		if err != nil {
+			return
+		}
+		captureLen = n
+	}
+	c.enqueue(dir, PortRTP, pkt[:captureLen], len(pkt))
+}
+
+// WriteRTPHeader captures an RTP packet which has not been marshalled yet
+func (c *Capture) WriteRTPHeader(dir Direction, hdr *rtp.Header, payload []byte) {
+	headerSize := hdr.MarshalSize()
+	origLen := headerSize + len(payload)
+	captureLen := headerSize
+	if c.params.IncludePayload {
+		captureLen = origLen
+	}
+
+	buf := make([]byte, captureLen)
+	if _, err := hdr.MarshalTo(buf); err != nil {
+		return
+	}
+	copy(buf[headerSize:], payload)
+	c.enqueue(dir, PortRTP, buf, origLen)
+}
+
+// WriteRTCP captures a marshalled RTCP compound packet
+func (c *Capture) WriteRTCP(dir Direction, pkt []byte) {
+	c.enqueue(dir, PortRTCP, pkt, len(pkt))
+}
+
+func (c *Capture) WriteRTCPPackets(dir Direction, pkts ...rtcp.Packet) {
+	pkt, err := rtcp.Marshal(pkts)
+	if err != nil {
+		return
+	}
+	c.WriteRTCP(dir, pkt)
+}
+
+// Stop ends the capture, packets already queued are still written
+func (c *Capture) Stop() {
+	c.lock.Lock()
+	if !c.stopped {
+		c.stopped = true
+		close(c.queue)
+	}
+	c.lock.Unlock()
+}
+
+// Done is closed once the capture has stopped and all packets have been written
+func (c *Capture) Done() <-chan struct{} {
+	return c.done.Watch()
+}
+
+// Stats returns the number of packets written and dropped
+func (c *Capture) Stats() (uint64, uint64) {
+	return c.packets.Load(), c.dropped.Load()
+}
+
+func (c *Capture) enqueue(dir Direction, port uint16, data []byte, origLen int) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.stopped {
+		return
+	}
+
+	select {
+	case c.queue <- packet{
+		at:      time.Now(),
+		data:    encapsulate(dir, port, data, origLen),
+		origLen: ipv4HeaderSize + udpHeaderSize + origLen,
+	}:
+	default:
+		c.dropped.Inc()
+	}
+}
+
+func (c *Capture) worker() {
+	defer c.done.Break()
+
+	if c.params.Duration > 0 {
+		timer := time.AfterFunc(c.params.Duration, c.Stop)
+		defer timer.Stop()
+	}
+
+	var err error
+	for p := range c.queue {
+		if err != nil {
+			// drain after a write error so that producers are not blocked
+			continue
+		}
+		if err = c.writer.writePacket(p.at, p.data, p.origLen); err != nil {
+			c.params.Logger.Warnw("stopping capture, write failed", err)
+			go c.Stop()
+			continue
+		}
+		c.packets.Inc()
+	}
+
+	packets, dropped := c.Stats()
+	c.params.Logger.Debugw("capture stopped", "packets", packets, "dropped", dropped)
+}
+
+// encapsulate prepends IPv4 and UDP headers with lengths of the original packet
+func encapsulate(dir Direction, port uint16, data []byte, origLen int) []byte {
+	src, dst := clientAddr, sfuAddr
+	if dir == DirectionOut {
+		src, dst = sfuAddr, clientAddr
+	}
+
+	b := make([]byte, ipv4HeaderSize+udpHeaderSize+len(data))
+	ip := b[:ipv4HeaderSize]
+	ip[0] = 0x45 // version 4, 5 words
+	binary.BigEndian.PutUint16(ip[2:], uint16(ipv4HeaderSize+udpHeaderSize+origLen))
+	binary.BigEndian.PutUint16(ip[6:], 0x4000) // don't fragment
+	ip[8] = 64
+	ip[9] = 17 // UDP
+	copy(ip[12:16], src[:])
+	copy(ip[16:20], dst[:])
+	binary.BigEndian.PutUint16(ip[10:], ipv4Checksum(ip))
+
+	udp := b[ipv4HeaderSize : ipv4HeaderSize+udpHeaderSize]
+	binary.BigEndian.PutUint16(udp[0:], port)
+	binary.BigEndian.PutUint16(udp[2:], port)
+	binary.BigEndian.PutUint16(udp[4:], uint16(udpHeaderSize+origLen))
+	// UDP checksum is optional over IPv4, left as zero
+
+	copy(b[ipv4HeaderSize+udpHeaderSize:], data)
+	return b
+}
+
+func ipv4Checksum(hdr []byte) uint16 {
+	var sum uint32
+	for i := 0; i < len(hdr); i += 2 {
+		sum += uint32(binary.BigEndian.Uint16(hdr[i:]))
+	}
+	for sum>>16 != 0 {
+		sum = (sum & 0xffff) + (sum >> 16)
+	}
+	return ^uint16(sum)
+}
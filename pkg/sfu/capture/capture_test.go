@@ -0,0 +1,131 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+type capturedPacket struct {
+	ts      time.Time
+	data    []byte
+	origLen int
+}
+
+// parses the blocks written by pcapngWriter
+func readPCAPNG(t *testing.T, b []byte) []capturedPacket {
+	require.Equal(t, uint32(blockTypeSectionHeader), binary.LittleEndian.Uint32(b))
+	require.Equal(t, uint32(byteOrderMagic), binary.LittleEndian.Uint32(b[8:]))
+
+	var packets []capturedPacket
+	for len(b) > 0 {
+		blockType := binary.LittleEndian.Uint32(b)
+		blockLen := binary.LittleEndian.Uint32(b[4:])
+		require.Equal(t, blockLen, binary.LittleEndian.Uint32(b[blockLen-4:]))
+
+		switch blockType {
+		case blockTypeInterfaceDesc:
+			require.Equal(t, uint16(linkTypeRaw), binary.LittleEndian.Uint16(b[8:]))
+		case blockTypeEnhancedPacket:
+			ts := uint64(binary.LittleEndian.Uint32(b[12:]))<<32 | uint64(binary.LittleEndian.Uint32(b[16:]))
+			captureLen := binary.LittleEndian.Uint32(b[20:])
+			packets = append(packets, capturedPacket{
+				ts:      time.Unix(0, int64(ts)),
+				data:    b[28 : 28+captureLen],
+				origLen: int(binary.LittleEndian.Uint32(b[24:])),
+			})
+		}
+		b = b[blockLen:]
+	}
+	return packets
+}
+
+func TestCapture(t *testing.T) {
+	var out bytes.Buffer
+	c, err := New(&out, Params{})
+	require.NoError(t, err)
+
+	pkt, err := (&rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			PayloadType:    96,
+			SequenceNumber: 100,
+			Timestamp:      9000,
+			SSRC:           1234,
+		},
+		Payload: make([]byte, 500),
+	}).Marshal()
+	require.NoError(t, err)
+
+	start := time.Now()
+	c.WriteRTP(DirectionIn, pkt)
+	c.WriteRTCPPackets(DirectionOut, &rtcp.PictureLossIndication{MediaSSRC: 1234})
+	c.Stop()
+	<-c.Done()
+
+	// packets after stop are ignored
+	c.WriteRTP(DirectionIn, pkt)
+
+	written, dropped := c.Stats()
+	require.Equal(t, uint64(2), written)
+	require.Zero(t, dropped)
+
+	packets := readPCAPNG(t, out.Bytes())
+	require.Len(t, packets, 2)
+
+	// RTP is truncated to the header, lengths reflect the original packet
+	rtpPacket := packets[0]
+	require.False(t, rtpPacket.ts.Before(start))
+	require.Equal(t, ipv4HeaderSize+udpHeaderSize+12, len(rtpPacket.data))
+	require.Equal(t, ipv4HeaderSize+udpHeaderSize+len(pkt), rtpPacket.origLen)
+	ip := rtpPacket.data[:ipv4HeaderSize]
+	require.Equal(t, uint16(rtpPacket.origLen), binary.BigEndian.Uint16(ip[2:]))
+	require.Equal(t, clientAddr[:], ip[12:16])
+	require.Equal(t, sfuAddr[:], ip[16:20])
+	require.Zero(t, ipv4Checksum(ip))
+	require.Equal(t, uint16(PortRTP), binary.BigEndian.Uint16(rtpPacket.data[ipv4HeaderSize+2:]))
+
+	var hdr rtp.Header
+	_, err = hdr.Unmarshal(rtpPacket.data[ipv4HeaderSize+udpHeaderSize:])
+	require.NoError(t, err)
+	require.Equal(t, uint32(1234), hdr.SSRC)
+	require.Equal(t, uint16(100), hdr.SequenceNumber)
+
+	rtcpPacket := packets[1]
+	require.Equal(t, sfuAddr[:], rtcpPacket.data[12:16])
+	require.Equal(t, uint16(PortRTCP), binary.BigEndian.Uint16(rtcpPacket.data[ipv4HeaderSize+2:]))
+	rtcpPkts, err := rtcp.Unmarshal(rtcpPacket.data[ipv4HeaderSize+udpHeaderSize:])
+	require.NoError(t, err)
+	require.IsType(t, &rtcp.PictureLossIndication{}, rtcpPkts[0])
+}
+
+func TestCaptureDuration(t *testing.T) {
+	var out bytes.Buffer
+	c, err := New(&out, Params{Duration: 50 * time.Millisecond, IncludePayload: true})
+	require.NoError(t, err)
+
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("capture did not stop")
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+// minimal pcapng writer, https://www.ietf.org/archive/id/draft-ietf-opsawg-pcapng-02.html
+const (
+	blockTypeSectionHeader  = 0x0A0D0D0A
+	blockTypeInterfaceDesc  = 0x00000001
+	blockTypeEnhancedPacket = 0x00000006
+
+	byteOrderMagic = 0x1A2B3C4D
+
+	// packets start with an IPv4 header
+	linkTypeRaw = 101
+
+	optionEndOfOpt = 0
+	optionTSResol  = 9
+	// timestamps in nanoseconds
+	tsResolNanoseconds = 9
+)
+
+type pcapngWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+func newPCAPNGWriter(w io.Writer) (*pcapngWriter, error) {
+	p := &pcapngWriter{w: w}
+
+	// section header, length of section is not known
+	shb := make([]byte, 28)
+	binary.LittleEndian.PutUint32(shb[0:], blockTypeSectionHeader)
+	binary.LittleEndian.PutUint32(shb[4:], uint32(len(shb)))
+	binary.LittleEndian.PutUint32(shb[8:], byteOrderMagic)
+	binary.LittleEndian.PutUint16(shb[12:], 1)
+	binary.LittleEndian.PutUint16(shb[14:], 0)
+	binary.LittleEndian.PutUint64(shb[16:], 0xFFFFFFFFFFFFFFFF)
+	binary.LittleEndian.PutUint32(shb[24:], uint32(len(shb)))
+	if _, err := w.Write(shb); err != nil {
+		return nil, err
+	}
+
+	// single interface, with nanosecond timestamps
+	idb := make([]byte, 32)
+	binary.LittleEndian.PutUint32(idb[0:], blockTypeInterfaceDesc)
+	binary.LittleEndian.PutUint32(idb[4:], uint32(len(idb)))
+	binary.LittleEndian.PutUint16(idb[8:], linkTypeRaw)
+	binary.LittleEndian.PutUint32(idb[12:], 0) // no snap length limit
+	binary.LittleEndian.PutUint16(idb[16:], optionTSResol)
+	binary.LittleEndian.PutUint16(idb[18:], 1)
+	idb[20] = tsResolNanoseconds
+	binary.LittleEndian.PutUint16(idb[24:], optionEndOfOpt)
+	binary.LittleEndian.PutUint16(idb[26:], 0)
+	binary.LittleEndian.PutUint32(idb[28:], uint32(len(idb)))
+	if _, err := w.Write(idb); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// writePacket writes data, which may have been truncated from a packet of origLen bytes
+func (p *pcapngWriter) writePacket(at time.Time, data []byte, origLen int) error {
+	padded := (len(data) + 3) &^ 3
+	blockLen := 32 + padded
+
+	if cap(p.buf) < blockLen {
+		p.buf = make([]byte, blockLen)
+	}
+	b := p.buf[:blockLen]
+	clear(b)
+
+	ts := uint64(at.UnixNano())
+	binary.LittleEndian.PutUint32(b[0:], blockTypeEnhancedPacket)
+	binary.LittleEndian.PutUint32(b[4:], uint32(blockLen))
+	binary.LittleEndian.PutUint32(b[8:], 0) // interface id
+	binary.LittleEndian.PutUint32(b[12:], uint32(ts>>32))
+	binary.LittleEndian.PutUint32(b[16:], uint32(ts))
+	binary.LittleEndian.PutUint32(b[20:], uint32(len(data)))
+	binary.LittleEndian.PutUint32(b[24:], uint32(origLen))
+	copy(b[28:], data)
+	binary.LittleEndian.PutUint32(b[blockLen-4:], uint32(blockLen))
+
+	_, err := p.w.Write(b)
+	return err
+}
@@ -56,6 +56,22 @@ type ConnectionStatsParams struct {
 	Logger             logger.Logger
 }
 
+// QualityEvents are impairments seen on a stream since it started. They are cumulative,
+// so the latest report is the running total.
+type QualityEvents struct {
+	// number of times video stopped while not muted, either paused by the stream allocator or
+	// a stall in forwarding, stalls are detected at update interval granularity
+	Freezes uint32
+	// total time paused by the stream allocator
+	PausedDuration time.Duration
+	// time spent forwarding each spatial layer, filled in by the forwarding side
+	LayerDurations map[int32]time.Duration
+	// start of the stream, filled in by the forwarding side, tells apart successive streams of a track
+	StartedAt time.Time
+}
+
+// ------------------------------------------
+
 type ConnectionStats struct {
 	params ConnectionStatsParams
 
@@ -69,6 +85,7 @@ type ConnectionStats struct {
 	lock               sync.RWMutex
 	packetsSent        uint64
 	streamingStartedAt time.Time
+	numStalls          uint32
 
 	scorer *qualityScorer
 
@@ -201,6 +218,19 @@ func (cs *ConnectionStats) GetScoreAndQuality() (float32, livekit.ConnectionQual
 	return cs.scorer.GetMOSAndQuality()
 }
 
+func (cs *ConnectionStats) GetQualityEvents() QualityEvents {
+	numPauses, pausedDuration := cs.scorer.GetPauseStatsAt(time.Now())
+
+	cs.lock.RLock()
+	numStalls := cs.numStalls
+	cs.lock.RUnlock()
+
+	return QualityEvents{
+		Freezes:        numPauses + numStalls,
+		PausedDuration: pausedDuration,
+	}
+}
+
 func (cs *ConnectionStats) updateScoreWithAggregate(agg *rtpstats.RTPDeltaInfo, lastRTCPAt time.Time, at time.Time) float32 {
 	var stat windowStat
 	if agg != nil {
@@ -316,6 +346,11 @@ func (cs *ConnectionStats) updateStreamingStart(at time.Time) time.Time {
 			}
 		}
 	} else {
+		if !cs.streamingStartedAt.IsZero() && !cs.scorer.IsMutedOrPaused() && cs.isVideo.Load() {
+			// stopped sending without a mute or pause accounting for it,
+			// audio is not counted as it stops with DTX in silence
+			cs.numStalls++
+		}
 		cs.streamingStartedAt = time.Time{}
 	}
 	cs.packetsSent = packetsSent
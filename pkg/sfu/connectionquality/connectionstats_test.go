@@ -901,3 +901,56 @@ func TestConnectionQuality(t *testing.T) {
 		}
 	})
 }
+
+func TestPauseStats(t *testing.T) {
+	cs := NewConnectionStats(ConnectionStatsParams{Logger: logger.GetLogger()})
+
+	now := time.Now()
+	cs.UpdatePauseAt(true, now)
+	// repeated pause does not restart the pause
+	cs.UpdatePauseAt(true, now.Add(time.Second))
+	cs.UpdatePauseAt(false, now.Add(2*time.Second))
+	cs.UpdatePauseAt(true, now.Add(3*time.Second))
+
+	numPauses, pausedDuration := cs.scorer.GetPauseStatsAt(now.Add(4 * time.Second))
+	require.Equal(t, uint32(2), numPauses)
+	require.Equal(t, 3*time.Second, pausedDuration)
+}
+
+func TestMOSToConnectionQuality(t *testing.T) {
+	require.Equal(t, livekit.ConnectionQuality_EXCELLENT, MOSToConnectionQuality(MaxMOS))
+	require.Equal(t, livekit.ConnectionQuality_GOOD, MOSToConnectionQuality(scoreToMOS(60)))
+	require.Equal(t, livekit.ConnectionQuality_POOR, MOSToConnectionQuality(scoreToMOS(30)))
+	require.Equal(t, livekit.ConnectionQuality_LOST, MOSToConnectionQuality(MinMOS))
+}
+
+type testSenderProvider struct {
+	packetsSent uint64
+}
+
+func (p *testSenderProvider) GetDeltaStatsSender() map[uint32]*buffer.StreamStatsWithLayers {
+	return nil
+}
+
+func (p *testSenderProvider) GetPrimaryStreamLastReceiverReportTime() time.Time { return time.Time{} }
+
+func (p *testSenderProvider) GetPrimaryStreamPacketsSent() uint64 { return p.packetsSent }
+
+func TestStallsCountVideoOnly(t *testing.T) {
+	stalls := func(mimeType mime.MimeType) uint32 {
+		sender := &testSenderProvider{}
+		cs := NewConnectionStats(ConnectionStatsParams{SenderProvider: sender, Logger: logger.GetLogger()})
+		cs.UpdateCodec(mimeType, false)
+
+		now := time.Now()
+		sender.packetsSent = 10
+		cs.updateStreamingStart(now)
+		// nothing sent in the next interval while not muted or paused
+		cs.updateStreamingStart(now.Add(UpdateInterval))
+		return cs.GetQualityEvents().Freezes
+	}
+
+	require.Equal(t, uint32(1), stalls(mime.MimeTypeVP8))
+	// audio stops sending in silence with DTX
+	require.Equal(t, uint32(0), stalls(mime.MimeTypeOpus))
+}
@@ -213,8 +213,10 @@ type qualityScorer struct {
 	layerMutedAt   time.Time
 	layerUnmutedAt time.Time
 
-	pausedAt  time.Time
-	resumedAt time.Time
+	pausedAt       time.Time
+	resumedAt      time.Time
+	numPauses      uint32
+	pausedDuration time.Duration
 
 	ppsHistogram     [250]int
 	numPPSReadings   int
@@ -345,10 +347,12 @@ func (q *qualityScorer) updatePauseAtLocked(isPaused bool, at time.Time) {
 			q.layerDistance.Reset()
 			q.pausedAt = at
 			q.score = cMinScore
+			q.numPauses++
 		}
 	} else {
 		if q.isPaused() {
 			q.resumedAt = at
+			q.pausedDuration += at.Sub(q.pausedAt)
 		}
 	}
 }
@@ -367,6 +371,25 @@ func (q *qualityScorer) UpdatePause(isPaused bool) {
 	q.updatePauseAtLocked(isPaused, time.Now())
 }
 
+// GetPauseStatsAt returns the number of pauses and the total time spent paused, including an ongoing pause
+func (q *qualityScorer) GetPauseStatsAt(at time.Time) (uint32, time.Duration) {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+
+	pausedDuration := q.pausedDuration
+	if q.isPaused() {
+		pausedDuration += at.Sub(q.pausedAt)
+	}
+	return q.numPauses, pausedDuration
+}
+
+func (q *qualityScorer) IsMutedOrPaused() bool {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+
+	return q.isMuted() || q.isPaused()
+}
+
 func (q *qualityScorer) addLayerTransitionAtLocked(distance float64, at time.Time) {
 	q.layerDistance.AddSampleAt(distance, at)
 }
@@ -627,6 +650,23 @@ func scoreToConnectionQuality(score float64) livekit.ConnectionQuality {
 	return livekit.ConnectionQuality_LOST
 }
 
+// MOSToConnectionQuality maps a MOS, as reported in analytics stats, to the connection quality scale
+func MOSToConnectionQuality(mos float32) livekit.ConnectionQuality {
+	switch {
+	case mos > scoreToMOS(qualityTransitionScore[livekit.ConnectionQuality_GOOD]):
+		return livekit.ConnectionQuality_EXCELLENT
+
+	case mos > scoreToMOS(qualityTransitionScore[livekit.ConnectionQuality_POOR]):
+		return livekit.ConnectionQuality_GOOD
+
+	case mos > scoreToMOS(qualityTransitionScore[livekit.ConnectionQuality_LOST]):
+		return livekit.ConnectionQuality_POOR
+
+	default:
+		return livekit.ConnectionQuality_LOST
+	}
+}
+
 // ------------------------------------------
 
 func scoreToMOS(score float64) float32 {
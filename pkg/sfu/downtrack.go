@@ -38,6 +38,7 @@ import (
 	"github.com/livekit/protocol/utils/mono"
 
 	"github.com/livekit/livekit-server/pkg/sfu/buffer"
+	"github.com/livekit/livekit-server/pkg/sfu/capture"
 	"github.com/livekit/livekit-server/pkg/sfu/ccutils"
 	"github.com/livekit/livekit-server/pkg/sfu/connectionquality"
 	"github.com/livekit/livekit-server/pkg/sfu/mime"
@@ -307,12 +308,20 @@ type DownTrack struct {
 	rtpStatsRTX                   *rtpstats.RTPStatsSender
 	deltaStatsRTXSenderSnapshotId uint32
 
+	// allocated on first use, deltas in ForwardingDebugInfo are since the previous call
+	debugSenderSnapshotId atomic.Uint32
+
 	totalRepeatedNACKs atomic.Uint32
 
 	blankFramesGeneration atomic.Uint32
 
 	connectionStats *connectionquality.ConnectionStats
 
+	layerDurationsLock sync.Mutex
+	layerDurations     map[int32]time.Duration
+
+	capture atomic.Pointer[capture.Capture]
+
 	isNACKThrottled atomic.Bool
 
 	activePaddingOnMuteUpTrack atomic.Bool
@@ -410,6 +419,7 @@ func NewDownTrack(params DowntrackParams) (*DownTrack, error) {
 		Logger:         d.params.Logger.WithValues("direction", "down"),
 	})
 	d.connectionStats.OnStatsUpdate(func(_cs *connectionquality.ConnectionStats, stat *livekit.AnalyticsStat) {
+		d.updateLayerDurations(stat)
 		if onStatsUpdate := d.getOnStatsUpdate(); onStatsUpdate != nil {
 			onStatsUpdate(d, stat)
 		}
@@ -594,8 +604,8 @@ func (d *DownTrack) Bind(t webrtc.TrackLocalContext) (webrtc.RTPCodecParameters,
 	if !isReceiverReady {
 		d.params.Logger.Debugw("downtrack bound: receiver not ready", "codec", codec)
 		d.bindOnReceiverReady = doBind
-		d.setBindStateLocked(bindStateWaitForReceiverReady)
 	}
+	d.setBindStateLocked(bindStateWaitForReceiverReady)
 
 	onCodecNegotiated := d.onCodecNegotiated
 	d.bindLock.Unlock()
@@ -1055,6 +1065,10 @@ func (d *DownTrack) WriteRTP(extPkt *buffer.ExtPacket, layer int32) error {
 		)
 	}
 
+	if c := d.capture.Load(); c != nil {
+		c.WriteRTPHeader(capture.DirectionOut, hdr, payload)
+	}
+
 	headerSize := hdr.MarshalSize()
 	d.rtpStats.Update(
 		extPkt.Arrival,
@@ -1413,6 +1427,7 @@ func (d *DownTrack) SeedState(state DownTrackState) {
 	if state.RTPStats != nil {
 		d.rtpStats.Seed(state.RTPStats)
 		d.deltaStatsSenderSnapshotId = state.DeltaStatsSenderSnapshotId
+		d.debugSenderSnapshotId.Store(0)
 		if d.playoutDelay != nil {
 			d.playoutDelay.SeedState(state.PlayoutDelayControllerState)
 		}
@@ -1863,6 +1878,10 @@ func (d *DownTrack) getH264BlankFrame(_frameEndNeeded bool) ([]byte, error) {
 }
 
 func (d *DownTrack) handleRTCP(bytes []byte) {
+	if c := d.capture.Load(); c != nil {
+		c.WriteRTCP(capture.DirectionIn, bytes)
+	}
+
 	pkts, err := rtcp.Unmarshal(bytes)
 	if err != nil {
 		d.params.Logger.Errorw("could not unmarshal rtcp receiver packet", err)
@@ -2119,6 +2138,10 @@ func (d *DownTrack) retransmitPacket(epm *extPacketMeta, sourcePkt []byte, isPro
 		payload = payload[:rtxOffset+int(epm.numCodecBytesOut)+len(pkt.Payload)-int(epm.numCodecBytesIn)]
 	}
 
+	if c := d.capture.Load(); c != nil {
+		c.WriteRTPHeader(capture.DirectionOut, hdr, payload)
+	}
+
 	headerSize := hdr.MarshalSize()
 	var (
 		payloadSize, paddingSize int
@@ -2362,6 +2385,35 @@ func (d *DownTrack) DebugInfo() map[string]interface{} {
 	}
 }
 
+// ForwardingDebugInfo returns the forwarding decisions for this subscriber, i. e. layers,
+// last allocation, playout delay and stats since the previous call
+func (d *DownTrack) ForwardingDebugInfo() map[string]interface{} {
+	info := d.DebugInfo()
+	info["Forwarder"] = d.forwarder.DebugInfo()
+	info["Connected"] = d.connected.Load()
+	info["Writable"] = d.writable.Load()
+	info["TotalRepeatedNACKs"] = d.totalRepeatedNACKs.Load()
+
+	if d.playoutDelay != nil {
+		info["PlayoutDelay"] = d.playoutDelay.DebugInfo()
+	}
+
+	snapshotId := d.debugSenderSnapshotId.Load()
+	if snapshotId == 0 {
+		snapshotId = d.rtpStats.NewSenderSnapshotId()
+		if !d.debugSenderSnapshotId.CompareAndSwap(0, snapshotId) {
+			snapshotId = d.debugSenderSnapshotId.Load()
+		}
+	}
+	senderView, receiverView := d.rtpStats.DeltaInfoSender(snapshotId)
+	info["DeltaStats"] = map[string]interface{}{
+		"Sender":   senderView,
+		"Receiver": receiverView,
+	}
+
+	return info
+}
+
 func (d *DownTrack) GetConnectionScoreAndQuality() (float32, livekit.ConnectionQuality) {
 	return d.connectionStats.GetScoreAndQuality()
 }
@@ -2370,6 +2422,65 @@ func (d *DownTrack) GetTrackStats() *livekit.RTPStats {
 	return rtpstats.ReconcileRTPStatsWithRTX(d.rtpStats.ToProto(), d.rtpStatsRTX.ToProto())
 }
 
+// SetCapture starts copying forwarded packets and RTCP from the subscriber to c. Capturing stops with a nil c.
+func (d *DownTrack) SetCapture(c *capture.Capture) {
+	d.capture.Store(c)
+}
+
+func (d *DownTrack) GetCapture() *capture.Capture {
+	return d.capture.Load()
+}
+
+// GetQualityEvents returns impairments seen by the subscriber since the down track started
+func (d *DownTrack) GetQualityEvents() connectionquality.QualityEvents {
+	events := d.connectionStats.GetQualityEvents()
+	events.StartedAt = time.Unix(0, d.createdAt)
+
+	d.layerDurationsLock.Lock()
+	if len(d.layerDurations) != 0 {
+		events.LayerDurations = make(map[int32]time.Duration, len(d.layerDurations))
+		for layer, duration := range d.layerDurations {
+			events.LayerDurations[layer] = duration
+		}
+	}
+	d.layerDurationsLock.Unlock()
+
+	return events
+}
+
+// attributes the stats window to the spatial layer being forwarded at the end of it
+func (d *DownTrack) updateLayerDurations(stat *livekit.AnalyticsStat) {
+	if d.kind != webrtc.RTPCodecTypeVideo {
+		return
+	}
+
+	layer := d.forwarder.CurrentLayer().Spatial
+	if layer == buffer.InvalidLayerSpatial {
+		return
+	}
+
+	var startTime, endTime time.Time
+	for _, stream := range stat.Streams {
+		start, end := stream.StartTime.AsTime(), stream.EndTime.AsTime()
+		if startTime.IsZero() || start.Before(startTime) {
+			startTime = start
+		}
+		if end.After(endTime) {
+			endTime = end
+		}
+	}
+	if !endTime.After(startTime) {
+		return
+	}
+
+	d.layerDurationsLock.Lock()
+	if d.layerDurations == nil {
+		d.layerDurations = make(map[int32]time.Duration)
+	}
+	d.layerDurations[layer] += endTime.Sub(startTime)
+	d.layerDurationsLock.Unlock()
+}
+
 func (d *DownTrack) deltaStats(ds *rtpstats.RTPDeltaInfo, dsrv *rtpstats.RTPDeltaInfo) map[uint32]*buffer.StreamStatsWithLayers {
 	if ds == nil && dsrv == nil {
 		return nil
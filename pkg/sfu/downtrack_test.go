@@ -0,0 +1,124 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sfu
+
+import (
+	"testing"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/webrtc/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/livekit-server/pkg/sfu/buffer"
+	"github.com/livekit/livekit-server/pkg/sfu/pacer"
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+)
+
+var opusCodec = webrtc.RTPCodecParameters{
+	RTPCodecCapability: webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus, ClockRate: 48000, Channels: 2},
+	PayloadType:        111,
+}
+
+type testTrackReceiver struct {
+	TrackReceiver
+	onReady []func()
+	ready   bool
+}
+
+func (r *testTrackReceiver) TrackID() livekit.TrackID { return "TR_test" }
+func (r *testTrackReceiver) HeaderExtensions() []webrtc.RTPHeaderExtensionParameter {
+	return nil
+}
+func (r *testTrackReceiver) DeleteDownTrack(_ livekit.ParticipantID) {}
+
+func (r *testTrackReceiver) AddOnReady(fn func()) {
+	if r.ready {
+		fn()
+		return
+	}
+	r.onReady = append(r.onReady, fn)
+}
+
+func (r *testTrackReceiver) setReady() {
+	r.ready = true
+	for _, fn := range r.onReady {
+		fn()
+	}
+	r.onReady = nil
+}
+
+type testTrackLocalContext struct{}
+
+func (c *testTrackLocalContext) CodecParameters() []webrtc.RTPCodecParameters {
+	return []webrtc.RTPCodecParameters{opusCodec}
+}
+
+func (c *testTrackLocalContext) HeaderExtensions() []webrtc.RTPHeaderExtensionParameter { return nil }
+func (c *testTrackLocalContext) SSRC() webrtc.SSRC                                      { return 1234 }
+func (c *testTrackLocalContext) SSRCRetransmission() webrtc.SSRC                        { return 0 }
+func (c *testTrackLocalContext) SSRCForwardErrorCorrection() webrtc.SSRC                { return 0 }
+func (c *testTrackLocalContext) WriteStream() webrtc.TrackLocalWriter                   { return nil }
+func (c *testTrackLocalContext) ID() string                                             { return "test" }
+func (c *testTrackLocalContext) RTCPReader() interceptor.RTCPReader                     { return nil }
+
+func newTestDownTrack(t *testing.T, receiver TrackReceiver) *DownTrack {
+	dt, err := NewDownTrack(DowntrackParams{
+		Codecs:        []webrtc.RTPCodecParameters{opusCodec},
+		Receiver:      receiver,
+		BufferFactory: buffer.NewFactoryOfBufferFactory(500, 200).CreateBufferFactory(),
+		SubID:         "PA_test",
+		StreamID:      "stream",
+		MaxTrack:      200,
+		Pacer:         pacer.NewPassThrough(logger.GetLogger(), nil),
+		Logger:        logger.GetLogger(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(dt.Close)
+	return dt
+}
+
+func TestDownTrackBind(t *testing.T) {
+	t.Run("receiver ready before bind", func(t *testing.T) {
+		// a WebRTCReceiver is ready as soon as it is created
+		dt := newTestDownTrack(t, &testTrackReceiver{ready: true})
+
+		bound := false
+		dt.OnBinding(func(err error) {
+			require.NoError(t, err)
+			bound = true
+		})
+		codec, err := dt.Bind(&testTrackLocalContext{})
+		require.NoError(t, err)
+		require.Equal(t, webrtc.MimeTypeOpus, codec.MimeType)
+		require.True(t, bound)
+		require.Equal(t, bindStateBound, dt.bindState.Load())
+
+		_, err = dt.Bind(&testTrackLocalContext{})
+		require.ErrorIs(t, err, ErrDownTrackAlreadyBound)
+	})
+
+	t.Run("receiver ready after bind", func(t *testing.T) {
+		receiver := &testTrackReceiver{}
+		dt := newTestDownTrack(t, receiver)
+
+		_, err := dt.Bind(&testTrackLocalContext{})
+		require.NoError(t, err)
+		require.Equal(t, bindStateWaitForReceiverReady, dt.bindState.Load())
+
+		receiver.setReady()
+		require.Equal(t, bindStateBound, dt.bindState.Load())
+	})
+}
@@ -2167,6 +2167,37 @@ func (f *Forwarder) GetPadding(frameEndNeeded bool) ([]byte, error) {
 	return f.codecMunger.UpdateAndGetPadding(!frameEndNeeded)
 }
 
+func (f *Forwarder) DebugInfo() map[string]interface{} {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return map[string]interface{}{
+		"Mime":                  f.mime.String(),
+		"Muted":                 f.muted,
+		"PubMuted":              f.pubMuted,
+		"Started":               f.started,
+		"ReferenceLayerSpatial": f.referenceLayerSpatial,
+		"CurrentLayer":          f.vls.GetCurrent().String(),
+		"TargetLayer":           f.vls.GetTarget().String(),
+		"MaxLayer":              f.vls.GetMax().String(),
+		"MaxSeenLayer":          f.vls.GetMaxSeen().String(),
+		"RequestSpatial":        f.vls.GetRequestSpatial(),
+		"LastAllocation": map[string]interface{}{
+			"PauseReason":         f.lastAllocation.PauseReason.String(),
+			"IsDeficient":         f.lastAllocation.IsDeficient,
+			"BandwidthRequested":  f.lastAllocation.BandwidthRequested,
+			"BandwidthDelta":      f.lastAllocation.BandwidthDelta,
+			"BandwidthNeeded":     f.lastAllocation.BandwidthNeeded,
+			"Bitrates":            f.lastAllocation.Bitrates,
+			"TargetLayer":         f.lastAllocation.TargetLayer.String(),
+			"RequestLayerSpatial": f.lastAllocation.RequestLayerSpatial,
+			"MaxLayer":            f.lastAllocation.MaxLayer.String(),
+			"DistanceToDesired":   f.lastAllocation.DistanceToDesired,
+		},
+		"RTPMunger": f.rtpMunger.DebugInfo(),
+	}
+}
+
 func (f *Forwarder) RTPMungerDebugInfo() map[string]interface{} {
 	f.lock.RLock()
 	defer f.lock.RUnlock()
@@ -15,6 +15,7 @@
 package sfu
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/pion/webrtc/v4"
@@ -123,6 +124,21 @@ func TestForwarderLayersVideo(t *testing.T) {
 	require.Equal(t, expectedLayers, f.MaxLayer())
 }
 
+func TestForwarderDebugInfo(t *testing.T) {
+	f := newForwarder(testutils.TestVP8Codec, webrtc.RTPCodecTypeVideo)
+	f.SetMaxSpatialLayer(1)
+	f.Mute(true, true)
+
+	info := f.DebugInfo()
+	require.Equal(t, true, info["Muted"])
+	require.Equal(t, f.MaxLayer().String(), info["MaxLayer"])
+	require.Equal(t, buffer.InvalidLayer.String(), info["CurrentLayer"])
+
+	// served as JSON by the debug API
+	_, err := json.Marshal(info)
+	require.NoError(t, err)
+}
+
 func TestForwarderAllocateOptimal(t *testing.T) {
 	f := newForwarder(testutils.TestVP8Codec, webrtc.RTPCodecTypeVideo)
 
@@ -54,6 +54,11 @@ func (b *Base) TimeSinceLastSentPacket() time.Duration {
 	return time.Duration(mono.UnixNano() - b.lastPacketSentAt.Load())
 }
 
+// QueueDepth is the number of packets waiting to be sent, pacers which queue override it
+func (b *Base) QueueDepth() int {
+	return 0
+}
+
 func (b *Base) SendPacket(p *Packet) (int, error) {
 	defer func() {
 		if p.Pool != nil && p.PoolEntity != nil {
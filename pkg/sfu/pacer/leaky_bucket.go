@@ -71,6 +71,13 @@ func (l *LeakyBucket) Stop() {
 	l.stop.Break()
 }
 
+func (l *LeakyBucket) QueueDepth() int {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return l.packets.Len()
+}
+
 func (l *LeakyBucket) Enqueue(p *Packet) {
 	l.lock.Lock()
 	l.packets.PushBack(p)
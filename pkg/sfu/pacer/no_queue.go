@@ -55,6 +55,13 @@ func (n *NoQueue) Stop() {
 	}
 }
 
+func (n *NoQueue) QueueDepth() int {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return n.packets.Len()
+}
+
 func (n *NoQueue) Enqueue(p *Packet) {
 	n.lock.Lock()
 	n.packets.PushBack(p)
@@ -45,6 +45,7 @@ type Pacer interface {
 	SetBitrate(bitrate int)
 
 	TimeSinceLastSentPacket() time.Duration
+	QueueDepth() int
 
 	SetPacerProbeObserverListener(listener PacerProbeObserverListener)
 	StartProbeCluster(pci ccutils.ProbeClusterInfo)
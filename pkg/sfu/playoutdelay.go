@@ -117,6 +117,21 @@ func (c *PlayoutDelayController) SeedState(pdcs PlayoutDelayControllerState) {
 	c.senderSnapshotID = pdcs.SenderSnapshotID
 }
 
+func (c *PlayoutDelayController) DebugInfo() map[string]interface{} {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return map[string]interface{}{
+		"State":          PlayoutDelayState(c.state.Load()).String(),
+		"MinDelay":       c.minDelay,
+		"MaxDelay":       c.maxDelay,
+		"CurrentDelay":   c.currentDelay,
+		"SendingAtSeq":   c.sendingAtSeq,
+		"SendingAtTime":  c.sendingAtTime,
+		"HighDelayCount": c.highDelayCount.Load(),
+	}
+}
+
 func (c *PlayoutDelayController) SetJitter(jitter uint32) {
 	c.lock.Lock()
 	deltaInfoSender, _ := c.rtpStats.DeltaInfoSender(c.senderSnapshotID)
@@ -32,6 +32,7 @@ import (
 
 	"github.com/livekit/livekit-server/pkg/sfu/audio"
 	"github.com/livekit/livekit-server/pkg/sfu/buffer"
+	"github.com/livekit/livekit-server/pkg/sfu/capture"
 	"github.com/livekit/livekit-server/pkg/sfu/connectionquality"
 	"github.com/livekit/livekit-server/pkg/sfu/mime"
 	dd "github.com/livekit/livekit-server/pkg/sfu/rtpextension/dependencydescriptor"
@@ -173,6 +174,7 @@ type WebRTCReceiver struct {
 	buffers  [buffer.DefaultMaxLayerSpatial + 1]*buffer.Buffer
 	upTracks [buffer.DefaultMaxLayerSpatial + 1]TrackRemote
 	rtt      uint32
+	capture  *capture.Capture
 
 	lbThreshold int
 
@@ -354,6 +356,29 @@ func (w *WebRTCReceiver) SetRTT(rtt uint32) {
 	}
 }
 
+// SetCapture copies packets of all layers, including layers published later, to c. Capturing stops with a nil c.
+func (w *WebRTCReceiver) SetCapture(c *capture.Capture) {
+	w.bufferMu.Lock()
+	w.capture = c
+	buffers := w.buffers
+	w.bufferMu.Unlock()
+
+	for _, buff := range buffers {
+		if buff == nil {
+			continue
+		}
+
+		buff.SetCapture(c)
+	}
+}
+
+func (w *WebRTCReceiver) getCapture() *capture.Capture {
+	w.bufferMu.RLock()
+	defer w.bufferMu.RUnlock()
+
+	return w.capture
+}
+
 func (w *WebRTCReceiver) StreamID() string {
 	return w.streamID
 }
@@ -434,9 +459,11 @@ func (w *WebRTCReceiver) AddUpTrack(track TrackRemote, buff *buffer.Buffer) erro
 	w.upTracks[layer] = track
 	w.buffers[layer] = buff
 	rtt := w.rtt
+	c := w.capture
 	w.bufferMu.Unlock()
 
 	buff.SetRTT(rtt)
+	buff.SetCapture(c)
 	buff.SetPaused(w.streamTrackerManager.IsPaused())
 
 	if w.Kind() == webrtc.RTPCodecTypeVideo && w.useTrackers {
@@ -596,6 +623,10 @@ func (w *WebRTCReceiver) sendRTCP(packets []rtcp.Packet) {
 		return
 	}
 
+	if c := w.getCapture(); c != nil {
+		c.WriteRTCPPackets(capture.DirectionOut, packets...)
+	}
+
 	if w.onRTCP != nil {
 		w.onRTCP(packets)
 	}
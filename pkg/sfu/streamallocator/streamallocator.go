@@ -52,6 +52,8 @@ const (
 	FlagAllowOvershootInBoost                   = true
 
 	cRTTPullInterval = 30 * time.Second
+
+	cDebugInfoTimeout = time.Second
 )
 
 // ---------------------------------------------------------------------------
@@ -92,6 +94,7 @@ const (
 	streamAllocatorSignalSetAllowPause
 	streamAllocatorSignalSetChannelCapacity
 	streamAllocatorSignalCongestionStateChange
+	streamAllocatorSignalDebugInfo
 )
 
 func (s streamAllocatorSignal) String() string {
@@ -122,6 +125,8 @@ func (s streamAllocatorSignal) String() string {
 		return "SET_CHANNEL_CAPACITY"
 	case streamAllocatorSignalCongestionStateChange:
 		return "CONGESTION_STATE_CHANGE"
+	case streamAllocatorSignalDebugInfo:
+		return "DEBUG_INFO"
 	default:
 		return fmt.Sprintf("%d", int(s))
 	}
@@ -585,6 +590,27 @@ func (s *StreamAllocator) maybePostEventAllocateTrack(downTrack *sfu.DownTrack)
 	}
 }
 
+// DebugInfo returns the allocator state and the allocation of each managed track.
+// It is gathered on the event loop, so it is consistent with allocations in progress.
+func (s *StreamAllocator) DebugInfo() map[string]interface{} {
+	if s.isStopped.Load() {
+		return nil
+	}
+
+	infoCh := make(chan map[string]interface{}, 1)
+	s.postEvent(Event{
+		Signal: streamAllocatorSignalDebugInfo,
+		Data:   infoCh,
+	})
+
+	select {
+	case info := <-infoCh:
+		return info
+	case <-time.After(cDebugInfoTimeout):
+		return nil
+	}
+}
+
 func (s *StreamAllocator) ping() {
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
@@ -631,6 +657,8 @@ func (s *StreamAllocator) postEvent(event Event) {
 			event.handleSignalSetChannelCapacity(event)
 		case streamAllocatorSignalCongestionStateChange:
 			s.handleSignalCongestionStateChange(event)
+		case streamAllocatorSignalDebugInfo:
+			s.handleSignalDebugInfo(event)
 		}
 	}, event)
 }
@@ -860,6 +888,37 @@ func (s *StreamAllocator) handleSignalCongestionStateChange(event Event) {
 	}
 }
 
+func (s *StreamAllocator) handleSignalDebugInfo(event Event) {
+	tracks := make(map[livekit.TrackID]interface{})
+	for _, track := range s.getTracks() {
+		tracks[track.ID()] = map[string]interface{}{
+			"Source":             track.source.String(),
+			"IsSimulcast":        track.isSimulcast,
+			"IsManaged":          track.IsManaged(),
+			"Priority":           track.Priority(),
+			"MaxLayer":           track.maxLayer.String(),
+			"StreamState":        track.streamState.String(),
+			"IsDeficient":        track.IsDeficient(),
+			"BandwidthRequested": track.BandwidthRequested(),
+			"DistanceToDesired":  track.DistanceToDesired(),
+		}
+	}
+
+	event.Data.(chan map[string]interface{}) <- map[string]interface{}{
+		"Enabled":                   s.enabled,
+		"AllowPause":                s.allowPause,
+		"State":                     s.state.String(),
+		"CommittedChannelCapacity":  s.committedChannelCapacity,
+		"OverriddenChannelCapacity": s.overriddenChannelCapacity,
+		"ExpectedBandwidthUsage":    s.getExpectedBandwidthUsage(),
+		"CongestionState":           s.params.BWE.CongestionState().String(),
+		"ActiveProbeClusterId":      s.activeProbeClusterId,
+		"PacerQueueDepth":           s.params.Pacer.QueueDepth(),
+		"TimeSinceLastSentPacket":   s.params.Pacer.TimeSinceLastSentPacket().String(),
+		"Tracks":                    tracks,
+	}
+}
+
 func (s *StreamAllocator) setState(state streamAllocatorState) {
 	if s.state == state {
 		return
@@ -0,0 +1,166 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/livekit/protocol/logger"
+
+	"github.com/livekit/livekit-server/pkg/config"
+)
+
+const (
+	analyticsFileTimeFormat = "20060102T150405.000"
+	// rotations within the same millisecond are told apart by a sequence number
+	analyticsFileMaxSequence = 1000
+)
+
+// AnalyticsFileWriter appends records as JSON lines to a file, rotating it once it exceeds the configured size
+type AnalyticsFileWriter struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	size int64
+}
+
+func NewAnalyticsFileWriter(conf config.AnalyticsFileConfig) (*AnalyticsFileWriter, error) {
+	w := &AnalyticsFileWriter{
+		path:       conf.Path,
+		maxSize:    int64(conf.MaxSizeMB) * 1024 * 1024,
+		maxBackups: conf.MaxBackups,
+	}
+	if err := os.MkdirAll(filepath.Dir(w.path), 0o755); err != nil {
+		return nil, err
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *AnalyticsFileWriter) Name() string {
+	return "file"
+}
+
+func (w *AnalyticsFileWriter) Write(records []*AnalyticsRecord) error {
+	if w.file == nil {
+		// a failed rotation could not reopen the file
+		if err := w.open(); err != nil {
+			return err
+		}
+	}
+
+	bw := bufio.NewWriter(w.file)
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		line = append(line, '\n')
+
+		if w.maxSize > 0 && w.size > 0 && w.size+int64(len(line)) > w.maxSize {
+			if err := bw.Flush(); err != nil {
+				return err
+			}
+			if err := w.rotate(); err != nil {
+				return err
+			}
+			bw.Reset(w.file)
+		}
+
+		n, err := bw.Write(line)
+		w.size += int64(n)
+		if err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func (w *AnalyticsFileWriter) Close() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *AnalyticsFileWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	w.file = f
+	w.size = info.Size()
+	return nil
+}
+
+// rotate renames the current file and opens a new one. When renaming fails, writing goes on to the current file.
+func (w *AnalyticsFileWriter) rotate() error {
+	if err := w.Close(); err != nil {
+		logger.Warnw("could not close analytics file", err, "path", w.path)
+	}
+
+	if err := os.Rename(w.path, w.rotatedPath(time.Now())); err != nil {
+		logger.Warnw("could not rotate analytics file", err, "path", w.path)
+	} else {
+		w.removeOldBackups()
+	}
+
+	return w.open()
+}
+
+// rotatedPath returns a name for the file rotated at, which does not exist yet
+func (w *AnalyticsFileWriter) rotatedPath(at time.Time) string {
+	prefix := fmt.Sprintf("%s.%s", w.path, at.UTC().Format(analyticsFileTimeFormat))
+	var rotated string
+	for seq := 0; seq < analyticsFileMaxSequence; seq++ {
+		rotated = fmt.Sprintf("%s.%03d", prefix, seq)
+		if _, err := os.Stat(rotated); os.IsNotExist(err) {
+			break
+		}
+	}
+	return rotated
+}
+
+func (w *AnalyticsFileWriter) removeOldBackups() {
+	if w.maxBackups <= 0 {
+		return
+	}
+	backups, err := filepath.Glob(w.path + ".*")
+	if err != nil || len(backups) <= w.maxBackups {
+		return
+	}
+	// timestamp and sequence suffix sorts chronologically
+	sort.Strings(backups)
+	for _, backup := range backups[:len(backups)-w.maxBackups] {
+		_ = os.Remove(backup)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/livekit/livekit-server/pkg/config"
+)
+
+type analyticsHTTPBatch struct {
+	NodeID  string             `json:"node_id"`
+	Records []*AnalyticsRecord `json:"records"`
+}
+
+// AnalyticsHTTPWriter posts each batch of records as a JSON document to a configured URL
+type AnalyticsHTTPWriter struct {
+	url     string
+	headers map[string]string
+	nodeID  string
+	client  *http.Client
+}
+
+func NewAnalyticsHTTPWriter(conf config.AnalyticsHTTPConfig, nodeID string) *AnalyticsHTTPWriter {
+	return &AnalyticsHTTPWriter{
+		url:     conf.URL,
+		headers: conf.Headers,
+		nodeID:  nodeID,
+		client:  &http.Client{Timeout: conf.Timeout},
+	}
+}
+
+func (w *AnalyticsHTTPWriter) Name() string {
+	return "http"
+}
+
+func (w *AnalyticsHTTPWriter) Write(records []*AnalyticsRecord) error {
+	body, err := json.Marshal(&analyticsHTTPBatch{
+		NodeID:  w.nodeID,
+		Records: records,
+	})
+	if err != nil {
+		return err
+	}
+	return postJSON(w.client, w.url, w.headers, body)
+}
+
+func (w *AnalyticsHTTPWriter) Close() error {
+	w.client.CloseIdleConnections()
+	return nil
+}
+
+func postJSON(client *http.Client, url string, headers map[string]string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	start := time.Now()
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d from %s after %s", res.StatusCode, url, time.Since(start))
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/livekit/livekit-server/pkg/config"
+	"github.com/livekit/livekit-server/version"
+)
+
+const (
+	otlpLogsPath         = "/v1/logs"
+	otlpScopeName        = "github.com/livekit/livekit-server/pkg/telemetry"
+	otlpSeverityInfo     = 9
+	otlpRecordTypeAttr   = "livekit.analytics.type"
+	otlpServiceName      = "livekit-server"
+	otlpServiceNameAttr  = "service.name"
+	otlpServiceVerAttr   = "service.version"
+	otlpServiceNodeAttr  = "service.instance.id"
+	otlpSeverityInfoText = "INFO"
+)
+
+// OTLP/HTTP JSON encoding of ExportLogsServiceRequest, only the fields used here are modeled
+type otlpLogsRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         string         `json:"timeUnixNano"`
+	ObservedTimeUnixNano string         `json:"observedTimeUnixNano"`
+	SeverityNumber       int            `json:"severityNumber"`
+	SeverityText         string         `json:"severityText"`
+	Body                 otlpAnyValue   `json:"body"`
+	Attributes           []otlpKeyValue `json:"attributes"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+// AnalyticsOTLPWriter exports records as OpenTelemetry log records using the OTLP/HTTP JSON protocol.
+// The record payload is carried as the log body, with the record type as an attribute.
+type AnalyticsOTLPWriter struct {
+	url      string
+	headers  map[string]string
+	resource otlpResource
+	client   *http.Client
+}
+
+func NewAnalyticsOTLPWriter(conf config.AnalyticsOTLPConfig, nodeID string) *AnalyticsOTLPWriter {
+	url := strings.TrimSuffix(conf.Endpoint, "/")
+	if !strings.HasSuffix(url, otlpLogsPath) {
+		url += otlpLogsPath
+	}
+	return &AnalyticsOTLPWriter{
+		url:     url,
+		headers: conf.Headers,
+		resource: otlpResource{
+			Attributes: []otlpKeyValue{
+				{Key: otlpServiceNameAttr, Value: otlpAnyValue{StringValue: otlpServiceName}},
+				{Key: otlpServiceVerAttr, Value: otlpAnyValue{StringValue: version.Version}},
+				{Key: otlpServiceNodeAttr, Value: otlpAnyValue{StringValue: nodeID}},
+			},
+		},
+		client: &http.Client{Timeout: conf.Timeout},
+	}
+}
+
+func (w *AnalyticsOTLPWriter) Name() string {
+	return "otlp"
+}
+
+func (w *AnalyticsOTLPWriter) Write(records []*AnalyticsRecord) error {
+	logRecords := make([]otlpLogRecord, 0, len(records))
+	for _, record := range records {
+		ts := strconv.FormatInt(record.Timestamp.UnixNano(), 10)
+		logRecords = append(logRecords, otlpLogRecord{
+			TimeUnixNano:         ts,
+			ObservedTimeUnixNano: ts,
+			SeverityNumber:       otlpSeverityInfo,
+			SeverityText:         otlpSeverityInfoText,
+			Body:                 otlpAnyValue{StringValue: string(record.Data)},
+			Attributes: []otlpKeyValue{
+				{Key: otlpRecordTypeAttr, Value: otlpAnyValue{StringValue: string(record.Type)}},
+			},
+		})
+	}
+
+	body, err := json.Marshal(&otlpLogsRequest{
+		ResourceLogs: []otlpResourceLogs{{
+			Resource: w.resource,
+			ScopeLogs: []otlpScopeLogs{{
+				Scope:      otlpScope{Name: otlpScopeName, Version: version.Version},
+				LogRecords: logRecords,
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	return postJSON(w.client, w.url, w.headers, body)
+}
+
+func (w *AnalyticsOTLPWriter) Close() error {
+	w.client.CloseIdleConnections()
+	return nil
+}
@@ -16,8 +16,11 @@ package telemetry
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"go.uber.org/atomic"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/livekit/protocol/livekit"
@@ -34,6 +37,9 @@ type AnalyticsService interface {
 	SendStats(ctx context.Context, stats []*livekit.AnalyticsStat)
 	SendEvent(ctx context.Context, events *livekit.AnalyticsEvent)
 	SendNodeRoomStates(ctx context.Context, nodeRooms *livekit.AnalyticsNodeRooms)
+	SendQualitySummary(ctx context.Context, summary *QualitySummary)
+	// Close writes out and closes local sinks
+	Close()
 }
 
 type analyticsService struct {
@@ -44,17 +50,22 @@ type analyticsService struct {
 	events    rpc.AnalyticsRecorderService_IngestEventsClient
 	stats     rpc.AnalyticsRecorderService_IngestStatsClient
 	nodeRooms rpc.AnalyticsRecorderService_IngestNodeRoomStatesClient
+
+	// local sinks, used when analytics are retained without LiveKit Cloud
+	sinks []*AnalyticsSink
 }
 
-func NewAnalyticsService(_ *config.Config, currentNode routing.LocalNode) AnalyticsService {
+func NewAnalyticsService(conf *config.Config, currentNode routing.LocalNode) AnalyticsService {
+	nodeID := string(currentNode.NodeID())
 	return &analyticsService{
 		analyticsKey: "", // TODO: conf.AnalyticsKey
-		nodeID:       string(currentNode.NodeID()),
+		nodeID:       nodeID,
+		sinks:        createAnalyticsSinks(&conf.Analytics, nodeID),
 	}
 }
 
 func (a *analyticsService) SendStats(_ context.Context, stats []*livekit.AnalyticsStat) {
-	if a.stats == nil {
+	if a.stats == nil && len(a.sinks) == 0 {
 		return
 	}
 
@@ -63,35 +74,104 @@ func (a *analyticsService) SendStats(_ context.Context, stats []*livekit.Analyti
 		stat.AnalyticsKey = a.analyticsKey
 		stat.Node = a.nodeID
 	}
-	if err := a.stats.Send(&livekit.AnalyticsStats{Stats: stats}); err != nil {
-		logger.Errorw("failed to send stats", err)
+	if a.stats != nil {
+		if err := a.stats.Send(&livekit.AnalyticsStats{Stats: stats}); err != nil {
+			logger.Errorw("failed to send stats", err)
+		}
+	}
+
+	if len(a.sinks) != 0 {
+		records := make([]*AnalyticsRecord, 0, len(stats))
+		for _, stat := range stats {
+			if record := a.newRecord(AnalyticsRecordTypeStat, stat); record != nil {
+				records = append(records, record)
+			}
+		}
+		a.addToSinks(records...)
 	}
 }
 
 func (a *analyticsService) SendEvent(_ context.Context, event *livekit.AnalyticsEvent) {
-	if a.events == nil {
+	if a.events == nil && len(a.sinks) == 0 {
 		return
 	}
 
 	event.Id = guid.New("AE_")
 	event.NodeId = a.nodeID
 	event.AnalyticsKey = a.analyticsKey
-	if err := a.events.Send(&livekit.AnalyticsEvents{
-		Events: []*livekit.AnalyticsEvent{event},
-	}); err != nil {
-		logger.Errorw("failed to send event", err, "eventType", event.Type.String())
+	if a.events != nil {
+		if err := a.events.Send(&livekit.AnalyticsEvents{
+			Events: []*livekit.AnalyticsEvent{event},
+		}); err != nil {
+			logger.Errorw("failed to send event", err, "eventType", event.Type.String())
+		}
+	}
+
+	if record := a.newRecord(AnalyticsRecordTypeEvent, event); record != nil {
+		a.addToSinks(record)
 	}
 }
 
 func (a *analyticsService) SendNodeRoomStates(_ context.Context, nodeRooms *livekit.AnalyticsNodeRooms) {
-	if a.nodeRooms == nil {
+	if a.nodeRooms == nil && len(a.sinks) == 0 {
 		return
 	}
 
 	nodeRooms.NodeId = a.nodeID
 	nodeRooms.SequenceNumber = a.sequenceNumber.Add(1)
 	nodeRooms.Timestamp = timestamppb.Now()
-	if err := a.nodeRooms.Send(nodeRooms); err != nil {
-		logger.Errorw("failed to send node room states", err)
+	if a.nodeRooms != nil {
+		if err := a.nodeRooms.Send(nodeRooms); err != nil {
+			logger.Errorw("failed to send node room states", err)
+		}
+	}
+
+	if record := a.newRecord(AnalyticsRecordTypeNodeRooms, nodeRooms); record != nil {
+		a.addToSinks(record)
+	}
+}
+
+func (a *analyticsService) SendQualitySummary(_ context.Context, summary *QualitySummary) {
+	if len(a.sinks) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		logger.Errorw("failed to marshal quality summary", err)
+		return
+	}
+	a.addToSinks(&AnalyticsRecord{
+		Type:      AnalyticsRecordTypeQualitySummary,
+		Timestamp: time.Now(),
+		Data:      data,
+	})
+}
+
+func (a *analyticsService) Close() {
+	for _, sink := range a.sinks {
+		sink.Close()
+	}
+}
+
+func (a *analyticsService) newRecord(recordType AnalyticsRecordType, msg proto.Message) *AnalyticsRecord {
+	if len(a.sinks) == 0 {
+		return nil
+	}
+
+	record, err := newAnalyticsRecord(recordType, msg)
+	if err != nil {
+		logger.Errorw("failed to marshal analytics record", err, "type", recordType)
+		return nil
+	}
+	return record
+}
+
+func (a *analyticsService) addToSinks(records ...*AnalyticsRecord) {
+	if len(records) == 0 {
+		return
+	}
+	for _, sink := range a.sinks {
+		sink.Add(records...)
 	}
 }
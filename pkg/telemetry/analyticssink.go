@@ -0,0 +1,226 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/frostbyte73/core"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/livekit/protocol/logger"
+
+	"github.com/livekit/livekit-server/pkg/config"
+)
+
+type AnalyticsRecordType string
+
+const (
+	AnalyticsRecordTypeStat           AnalyticsRecordType = "stat"
+	AnalyticsRecordTypeEvent          AnalyticsRecordType = "event"
+	AnalyticsRecordTypeNodeRooms      AnalyticsRecordType = "node_rooms"
+	AnalyticsRecordTypeQualitySummary AnalyticsRecordType = "quality_summary"
+)
+
+// AnalyticsRecord is a single stat, event, node room state or quality summary, serialized at the time it was recorded
+type AnalyticsRecord struct {
+	Type      AnalyticsRecordType `json:"type"`
+	Timestamp time.Time           `json:"timestamp"`
+	Data      json.RawMessage     `json:"data"`
+}
+
+func newAnalyticsRecord(recordType AnalyticsRecordType, msg proto.Message) (*AnalyticsRecord, error) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &AnalyticsRecord{
+		Type:      recordType,
+		Timestamp: time.Now(),
+		Data:      data,
+	}, nil
+}
+
+// AnalyticsWriter delivers a batch of records to a destination
+type AnalyticsWriter interface {
+	Name() string
+	Write(records []*AnalyticsRecord) error
+	Close() error
+}
+
+// ------------------------------------------------
+
+// AnalyticsSink buffers records and hands them to an AnalyticsWriter in batches,
+// either when a batch is full or when the flush interval expires.
+type AnalyticsSink struct {
+	writer AnalyticsWriter
+	params config.AnalyticsBatchConfig
+	logger logger.Logger
+
+	lock    sync.Mutex
+	pending []*AnalyticsRecord
+	dropped int
+
+	flush   chan struct{}
+	flushed chan struct{}
+	done    core.Fuse
+}
+
+func NewAnalyticsSink(writer AnalyticsWriter, params config.AnalyticsBatchConfig) *AnalyticsSink {
+	if params.BatchSize <= 0 {
+		params.BatchSize = 100
+	}
+	if params.FlushInterval <= 0 {
+		params.FlushInterval = time.Second
+	}
+	if params.MaxPending < params.BatchSize {
+		params.MaxPending = params.BatchSize
+	}
+
+	s := &AnalyticsSink{
+		writer:  writer,
+		params:  params,
+		logger:  logger.GetLogger().WithValues("sink", writer.Name()),
+		flush:   make(chan struct{}, 1),
+		flushed: make(chan struct{}),
+	}
+	go s.worker()
+	return s
+}
+
+func (s *AnalyticsSink) Add(records ...*AnalyticsRecord) {
+	if s.done.IsBroken() {
+		return
+	}
+
+	s.lock.Lock()
+	s.pending = append(s.pending, records...)
+	if over := len(s.pending) - s.params.MaxPending; over > 0 {
+		s.dropped += over
+		s.pending = s.pending[over:]
+	}
+	full := len(s.pending) >= s.params.BatchSize
+	s.lock.Unlock()
+
+	if full {
+		select {
+		case s.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Close flushes pending records and closes the underlying writer
+func (s *AnalyticsSink) Close() {
+	if s.done.IsBroken() {
+		return
+	}
+	s.done.Break()
+	<-s.flushed
+}
+
+func (s *AnalyticsSink) worker() {
+	ticker := time.NewTicker(s.params.FlushInterval)
+	defer func() {
+		ticker.Stop()
+		if !s.writePending() {
+			s.lock.Lock()
+			lost := len(s.pending)
+			s.lock.Unlock()
+			s.logger.Warnw("analytics sink closed with unwritten records", nil, "count", lost)
+		}
+		if err := s.writer.Close(); err != nil {
+			s.logger.Warnw("failed to close analytics sink", err)
+		}
+		close(s.flushed)
+	}()
+
+	// while the writer fails, it is retried on the interval only, not for every full batch
+	available := true
+	for {
+		select {
+		case <-s.done.Watch():
+			return
+		case <-ticker.C:
+			available = s.writePending()
+		case <-s.flush:
+			if available {
+				available = s.writePending()
+			}
+		}
+	}
+}
+
+// writePending writes pending records in batches, a batch that cannot be written is kept ahead of newer records
+// to be retried. Returns whether all pending records were written.
+func (s *AnalyticsSink) writePending() bool {
+	for {
+		s.lock.Lock()
+		if dropped := s.dropped; dropped > 0 {
+			s.dropped = 0
+			s.logger.Warnw("analytics sink falling behind, dropped records", nil, "count", dropped)
+		}
+		n := min(len(s.pending), s.params.BatchSize)
+		if n == 0 {
+			s.lock.Unlock()
+			return true
+		}
+		batch := s.pending[:n:n]
+		s.pending = s.pending[n:]
+		s.lock.Unlock()
+
+		if err := s.writer.Write(batch); err != nil {
+			s.logger.Warnw("failed to write analytics records", err, "count", len(batch))
+			s.requeue(batch)
+			return false
+		}
+	}
+}
+
+func (s *AnalyticsSink) requeue(batch []*AnalyticsRecord) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// batch is capped at its length, appending to it does not overwrite pending records
+	s.pending = append(batch, s.pending...)
+	if over := len(s.pending) - s.params.MaxPending; over > 0 {
+		s.dropped += over
+		s.pending = s.pending[over:]
+	}
+}
+
+// ------------------------------------------------
+
+func createAnalyticsSinks(conf *config.AnalyticsConfig, nodeID string) []*AnalyticsSink {
+	var sinks []*AnalyticsSink
+	if conf.File.Path != "" {
+		w, err := NewAnalyticsFileWriter(conf.File)
+		if err != nil {
+			logger.Errorw("could not create analytics file sink", err, "path", conf.File.Path)
+		} else {
+			sinks = append(sinks, NewAnalyticsSink(w, conf.File.AnalyticsBatchConfig))
+		}
+	}
+	if conf.HTTP.URL != "" {
+		sinks = append(sinks, NewAnalyticsSink(NewAnalyticsHTTPWriter(conf.HTTP, nodeID), conf.HTTP.AnalyticsBatchConfig))
+	}
+	if conf.OTLP.Endpoint != "" {
+		sinks = append(sinks, NewAnalyticsSink(NewAnalyticsOTLPWriter(conf.OTLP, nodeID), conf.OTLP.AnalyticsBatchConfig))
+	}
+	return sinks
+}
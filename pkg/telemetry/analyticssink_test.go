@@ -0,0 +1,262 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/config"
+)
+
+func testRecords(t *testing.T, n int) []*AnalyticsRecord {
+	records := make([]*AnalyticsRecord, 0, n)
+	for i := 0; i < n; i++ {
+		record, err := newAnalyticsRecord(AnalyticsRecordTypeEvent, &livekit.AnalyticsEvent{
+			Type:   livekit.AnalyticsEventType_PARTICIPANT_JOINED,
+			RoomId: "RM_test",
+		})
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestAnalyticsFileWriter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "analytics.jsonl")
+
+	w, err := NewAnalyticsFileWriter(config.AnalyticsFileConfig{
+		Path:       path,
+		MaxBackups: 2,
+	})
+	require.NoError(t, err)
+	// rotate after roughly two records
+	w.maxSize = 300
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, w.Write(testRecords(t, 2)))
+		time.Sleep(2 * time.Millisecond)
+	}
+	require.NoError(t, w.Close())
+
+	backups, err := filepath.Glob(path + ".*")
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	lines := 0
+	for scanner.Scan() {
+		var record AnalyticsRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		require.Equal(t, AnalyticsRecordTypeEvent, record.Type)
+
+		var event map[string]interface{}
+		require.NoError(t, json.Unmarshal(record.Data, &event))
+		require.Equal(t, "RM_test", event["roomId"])
+		lines++
+	}
+	require.Greater(t, lines, 0)
+}
+
+func TestAnalyticsFileWriterRotation(t *testing.T) {
+	countLines := func(t *testing.T, paths ...string) int {
+		lines := 0
+		for _, p := range paths {
+			f, err := os.Open(p)
+			require.NoError(t, err)
+			scanner := bufio.NewScanner(f)
+			for scanner.Scan() {
+				lines++
+			}
+			_ = f.Close()
+		}
+		return lines
+	}
+
+	t.Run("within a millisecond", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "analytics.jsonl")
+		w, err := NewAnalyticsFileWriter(config.AnalyticsFileConfig{Path: path})
+		require.NoError(t, err)
+		w.maxSize = 300
+
+		// rotates after every couple of records, nothing is overwritten
+		require.NoError(t, w.Write(testRecords(t, 20)))
+		require.NoError(t, w.Close())
+
+		backups, err := filepath.Glob(path + ".*")
+		require.NoError(t, err)
+		require.Greater(t, len(backups), 1)
+		require.Equal(t, 20, countLines(t, append(backups, path)...))
+	})
+
+	t.Run("rename fails", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "analytics.jsonl")
+		w, err := NewAnalyticsFileWriter(config.AnalyticsFileConfig{Path: path})
+		require.NoError(t, err)
+		w.maxSize = 300
+		require.NoError(t, w.Write(testRecords(t, 1)))
+
+		// removed from under the writer, it cannot be renamed
+		require.NoError(t, os.Remove(path))
+		require.NoError(t, w.Write(testRecords(t, 2)))
+		require.NoError(t, w.Write(testRecords(t, 1)))
+		require.NoError(t, w.Close())
+
+		backups, err := filepath.Glob(path + ".*")
+		require.NoError(t, err)
+		require.Empty(t, backups)
+		require.Equal(t, 2, countLines(t, path))
+	})
+}
+
+func TestAnalyticsSinkBatching(t *testing.T) {
+	var lock sync.Mutex
+	var batches []analyticsHTTPBatch
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+
+		var batch analyticsHTTPBatch
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+		lock.Lock()
+		batches = append(batches, batch)
+		lock.Unlock()
+	}))
+	defer server.Close()
+
+	sink := NewAnalyticsSink(
+		NewAnalyticsHTTPWriter(config.AnalyticsHTTPConfig{
+			URL:     server.URL,
+			Headers: map[string]string{"X-Api-Key": "secret"},
+			Timeout: time.Second,
+		}, "ND_test"),
+		config.AnalyticsBatchConfig{
+			BatchSize:     4,
+			FlushInterval: time.Minute,
+		},
+	)
+
+	// a full batch is flushed without waiting for the interval
+	sink.Add(testRecords(t, 4)...)
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(batches) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// remainder is flushed on close
+	sink.Add(testRecords(t, 3)...)
+	sink.Close()
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(t, batches, 2)
+	require.Len(t, batches[0].Records, 4)
+	require.Len(t, batches[1].Records, 3)
+	require.Equal(t, "ND_test", batches[1].NodeID)
+}
+
+func TestAnalyticsOTLPWriter(t *testing.T) {
+	var req otlpLogsRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, otlpLogsPath, r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+	}))
+	defer server.Close()
+
+	w := NewAnalyticsOTLPWriter(config.AnalyticsOTLPConfig{
+		Endpoint: server.URL,
+		Timeout:  time.Second,
+	}, "ND_test")
+	require.NoError(t, w.Write(testRecords(t, 2)))
+
+	require.Len(t, req.ResourceLogs, 1)
+	require.Len(t, req.ResourceLogs[0].ScopeLogs, 1)
+	logRecords := req.ResourceLogs[0].ScopeLogs[0].LogRecords
+	require.Len(t, logRecords, 2)
+	require.Equal(t, string(AnalyticsRecordTypeEvent), logRecords[0].Attributes[0].Value.StringValue)
+	require.Contains(t, logRecords[0].Body.StringValue, "RM_test")
+}
+
+type failingAnalyticsWriter struct {
+	lock    sync.Mutex
+	fail    bool
+	written int
+}
+
+func (w *failingAnalyticsWriter) Name() string { return "failing" }
+
+func (w *failingAnalyticsWriter) Write(records []*AnalyticsRecord) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.fail {
+		return errors.New("unavailable")
+	}
+	w.written += len(records)
+	return nil
+}
+
+func (w *failingAnalyticsWriter) Close() error { return nil }
+
+func (w *failingAnalyticsWriter) setFail(fail bool) {
+	w.lock.Lock()
+	w.fail = fail
+	w.lock.Unlock()
+}
+
+func (w *failingAnalyticsWriter) getWritten() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.written
+}
+
+func TestAnalyticsSinkRetry(t *testing.T) {
+	w := &failingAnalyticsWriter{fail: true}
+	sink := NewAnalyticsSink(w, config.AnalyticsBatchConfig{
+		BatchSize:     4,
+		FlushInterval: 20 * time.Millisecond,
+		MaxPending:    10,
+	})
+
+	// failed batches are held up to MaxPending, oldest records are dropped
+	sink.Add(testRecords(t, 8)...)
+	sink.Add(testRecords(t, 4)...)
+	time.Sleep(50 * time.Millisecond)
+	require.Zero(t, w.getWritten())
+
+	w.setFail(false)
+	require.Eventually(t, func() bool { return w.getWritten() == 10 }, time.Second, 10*time.Millisecond)
+
+	// pending records are written on close
+	sink.Add(testRecords(t, 2)...)
+	sink.Close()
+	require.Equal(t, 12, w.getWritten())
+}
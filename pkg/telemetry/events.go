@@ -24,10 +24,19 @@ import (
 	"github.com/livekit/livekit-server/pkg/telemetry/prometheus"
 	"github.com/livekit/protocol/livekit"
 	"github.com/livekit/protocol/logger"
+	"github.com/livekit/protocol/utils"
 	"github.com/livekit/protocol/utils/guid"
 	"github.com/livekit/protocol/webhook"
 )
 
+// NodeFailureReason is reported with events of rooms which ended because their node failed
+const NodeFailureReason = "node failure"
+
+// NodeFailureAttribute is the participant attribute set in participant_left webhooks of participants
+// which were left behind by a failed node, their disconnect reason is SERVER_SHUTDOWN as there is
+// no reason specific to node failures
+const NodeFailureAttribute = "lk.node_failure"
+
 func (t *telemetryService) NotifyEvent(ctx context.Context, event *livekit.WebhookEvent) {
 	if t.notifier == nil {
 		return
@@ -72,6 +81,45 @@ func (t *telemetryService) RoomEnded(ctx context.Context, room *livekit.Room) {
 	})
 }
 
+func (t *telemetryService) RoomEndedOnNodeFailure(ctx context.Context, room *livekit.Room, participants []*livekit.ParticipantInfo) {
+	t.enqueue(func() {
+		for _, participant := range participants {
+			// there is no disconnect reason specific to node failures, the node is gone like it would be on shutdown
+			participant.DisconnectReason = livekit.DisconnectReason_SERVER_SHUTDOWN
+			t.NotifyEvent(ctx, &livekit.WebhookEvent{
+				Event:       webhook.EventParticipantLeft,
+				Room:        room,
+				Participant: withNodeFailure(participant),
+			})
+
+			ev := newParticipantEvent(livekit.AnalyticsEventType_PARTICIPANT_LEFT, room, participant)
+			ev.Error = NodeFailureReason
+			t.SendEvent(ctx, ev)
+		}
+
+		// room_finished has no field for the reason and room metadata belongs to the application,
+		// webhook receivers can tell from the participant_left events sent before it
+		t.NotifyEvent(ctx, &livekit.WebhookEvent{
+			Event: webhook.EventRoomFinished,
+			Room:  room,
+		})
+
+		ev := newRoomEvent(livekit.AnalyticsEventType_ROOM_ENDED, room)
+		ev.Error = NodeFailureReason
+		t.SendEvent(ctx, ev)
+	})
+}
+
+// withNodeFailure returns a copy of participant marked as left behind by a failed node
+func withNodeFailure(participant *livekit.ParticipantInfo) *livekit.ParticipantInfo {
+	pi := utils.CloneProto(participant)
+	if pi.Attributes == nil {
+		pi.Attributes = make(map[string]string, 1)
+	}
+	pi.Attributes[NodeFailureAttribute] = "true"
+	return pi
+}
+
 func (t *telemetryService) ParticipantJoined(
 	ctx context.Context,
 	room *livekit.Room,
@@ -182,21 +230,26 @@ func (t *telemetryService) ParticipantLeft(ctx context.Context,
 ) {
 	t.enqueue(func() {
 		isConnected := false
+		var summary *QualitySummary
 		if worker, ok := t.getWorker(livekit.ParticipantID(participant.Sid)); ok {
 			isConnected = worker.IsConnected()
 			if worker.Close() {
 				prometheus.SubParticipant()
 			}
+			summary = worker.QualitySummary(time.Now())
 		}
 
 		if isConnected && shouldSendEvent {
 			t.NotifyEvent(ctx, &livekit.WebhookEvent{
 				Event:       webhook.EventParticipantLeft,
 				Room:        room,
-				Participant: participant,
+				Participant: withQualitySummary(participant, summary),
 			})
 
 			t.SendEvent(ctx, newParticipantEvent(livekit.AnalyticsEventType_PARTICIPANT_LEFT, room, participant))
+			if summary != nil {
+				t.SendQualitySummary(ctx, summary)
+			}
 		}
 	})
 }
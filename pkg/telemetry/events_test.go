@@ -22,6 +22,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/telemetry"
 )
 
 func Test_OnParticipantJoin_EventIsSent(t *testing.T) {
@@ -96,6 +98,33 @@ func Test_OnParticipantLeft_EventIsSent(t *testing.T) {
 	require.Equal(t, room, event.Room)
 }
 
+func Test_OnRoomEndedOnNodeFailure_ParticipantsAreMarked(t *testing.T) {
+	fixture := createFixture()
+	notifier := &testNotifier{}
+	fixture.sut = telemetry.NewTelemetryService(notifier, fixture.analytics)
+
+	// prepare
+	room := &livekit.Room{Sid: "RoomSid", Name: "RoomName"}
+	participantInfo := &livekit.ParticipantInfo{Sid: "part1", Identity: "alice"}
+
+	// do
+	fixture.sut.RoomEndedOnNodeFailure(context.Background(), room, []*livekit.ParticipantInfo{participantInfo})
+
+	// test
+	require.Eventually(t, func() bool { return fixture.analytics.SendEventCallCount() == 2 }, time.Second, 10*time.Millisecond)
+	_, event := fixture.analytics.SendEventArgsForCall(0)
+	require.Equal(t, livekit.AnalyticsEventType_PARTICIPANT_LEFT, event.Type)
+	require.Equal(t, telemetry.NodeFailureReason, event.Error)
+	_, event = fixture.analytics.SendEventArgsForCall(1)
+	require.Equal(t, livekit.AnalyticsEventType_ROOM_ENDED, event.Type)
+	require.Equal(t, telemetry.NodeFailureReason, event.Error)
+
+	left := notifier.participantLeft()
+	require.NotNil(t, left)
+	require.Equal(t, livekit.DisconnectReason_SERVER_SHUTDOWN, left.DisconnectReason)
+	require.Equal(t, "true", left.Attributes[telemetry.NodeFailureAttribute])
+}
+
 func Test_OnTrackUpdate_EventIsSent(t *testing.T) {
 	fixture := createFixture()
 
@@ -0,0 +1,250 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+	"github.com/livekit/protocol/utils"
+
+	"github.com/livekit/livekit-server/pkg/sfu/connectionquality"
+)
+
+// QualitySummaryAttribute is the participant attribute carrying the JSON encoded QualitySummary
+// in participant_left webhooks
+const QualitySummaryAttribute = "lk.quality_summary"
+
+// QualitySummary is a compact quality of experience report for a participant session,
+// aggregated from the periodic track stats as they are reported. When the participant leaves, it is attached
+// to the participant_left webhook and sent to analytics as a record of its own.
+type QualitySummary struct {
+	RoomID              string                `json:"room_id"`
+	RoomName            string                `json:"room_name"`
+	ParticipantID       string                `json:"participant_id"`
+	ParticipantIdentity string                `json:"participant_identity"`
+	StartedAt           time.Time             `json:"started_at"`
+	EndedAt             time.Time             `json:"ended_at"`
+	Publish             *StreamQualitySummary `json:"publish,omitempty"`
+	Subscribe           *StreamQualitySummary `json:"subscribe,omitempty"`
+}
+
+// StreamQualitySummary covers all tracks in one direction. Times are summed over tracks,
+// so two tracks subscribed for a minute account for two minutes.
+type StreamQualitySummary struct {
+	PacketLossPct    MetricSummary    `json:"packet_loss_pct"`
+	JitterMs         MetricSummary    `json:"jitter_ms"`
+	RTTMs            MetricSummary    `json:"rtt_ms"`
+	VideoLayerTimeMs map[int32]int64  `json:"video_layer_time_ms,omitempty"`
+	QualityTimeMs    map[string]int64 `json:"quality_time_ms,omitempty"`
+	Freezes          uint32           `json:"freezes"`
+	PausedTimeMs     int64            `json:"paused_time_ms"`
+}
+
+// MetricSummary holds the time weighted average of per interval values and the worst interval
+type MetricSummary struct {
+	Avg  float64 `json:"avg"`
+	Peak float64 `json:"peak"`
+}
+
+// ------------------------------------------------
+
+type metricAggregator struct {
+	weightedSum float64
+	weight      float64
+	peak        float64
+}
+
+func (m *metricAggregator) add(value float64, weight float64) {
+	m.weightedSum += value * weight
+	m.weight += weight
+	if value > m.peak {
+		m.peak = value
+	}
+}
+
+func (m *metricAggregator) summary() MetricSummary {
+	s := MetricSummary{Peak: m.peak}
+	if m.weight > 0 {
+		s.Avg = m.weightedSum / m.weight
+	}
+	return s
+}
+
+// ------------------------------------------------
+
+type streamQualityAggregator struct {
+	hasStats bool
+
+	packets     uint64
+	packetsLost uint64
+	peakLoss    float64
+	jitter      metricAggregator
+	rtt         metricAggregator
+
+	layerTime   map[int32]time.Duration
+	qualityTime map[livekit.ConnectionQuality]time.Duration
+
+	// latest cumulative report per stream, a track subscribed again is a new stream
+	events map[qualityEventsKey]connectionquality.QualityEvents
+}
+
+type qualityEventsKey struct {
+	trackID   livekit.TrackID
+	startedAt int64
+}
+
+func newStreamQualityAggregator() *streamQualityAggregator {
+	return &streamQualityAggregator{
+		layerTime:   make(map[int32]time.Duration),
+		qualityTime: make(map[livekit.ConnectionQuality]time.Duration),
+		events:      make(map[qualityEventsKey]connectionquality.QualityEvents),
+	}
+}
+
+func (a *streamQualityAggregator) addStat(stat *livekit.AnalyticsStat) {
+	if len(stat.Streams) == 0 {
+		return
+	}
+	a.hasStats = true
+
+	var startTime, endTime time.Time
+	var packets, packetsLost uint32
+	var maxJitter, maxRtt uint32
+	maxLayer := int32(-1)
+	for _, stream := range stat.Streams {
+		start, end := stream.StartTime.AsTime(), stream.EndTime.AsTime()
+		if startTime.IsZero() || start.Before(startTime) {
+			startTime = start
+		}
+		if end.After(endTime) {
+			endTime = end
+		}
+
+		packets += stream.PrimaryPackets
+		packetsLost += stream.PacketsLost
+		if stream.Jitter > maxJitter {
+			maxJitter = stream.Jitter
+		}
+		if stream.Rtt > maxRtt {
+			maxRtt = stream.Rtt
+		}
+		for _, layer := range stream.VideoLayers {
+			if layer.Layer > maxLayer {
+				maxLayer = layer.Layer
+			}
+		}
+	}
+
+	a.packets += uint64(packets)
+	a.packetsLost += uint64(packetsLost)
+	if packets+packetsLost > 0 {
+		if loss := float64(packetsLost) * 100 / float64(packets+packetsLost); loss > a.peakLoss {
+			a.peakLoss = loss
+		}
+	}
+
+	duration := endTime.Sub(startTime)
+	if duration <= 0 {
+		return
+	}
+	weight := duration.Seconds()
+
+	// jitter is reported in microseconds
+	a.jitter.add(float64(maxJitter)/1000, weight)
+	// RTT is not measured in all cases, do not let missing values pull the average down
+	if maxRtt > 0 {
+		a.rtt.add(float64(maxRtt), weight)
+	}
+	if maxLayer >= 0 {
+		a.layerTime[maxLayer] += duration
+	}
+	if stat.Score > 0 {
+		a.qualityTime[connectionquality.MOSToConnectionQuality(stat.Score)] += duration
+	}
+}
+
+func (a *streamQualityAggregator) setQualityEvents(trackID livekit.TrackID, events connectionquality.QualityEvents) {
+	a.events[qualityEventsKey{trackID: trackID, startedAt: events.StartedAt.UnixNano()}] = events
+}
+
+func (a *streamQualityAggregator) summary() *StreamQualitySummary {
+	if !a.hasStats && len(a.events) == 0 {
+		return nil
+	}
+
+	s := &StreamQualitySummary{
+		JitterMs: a.jitter.summary(),
+		RTTMs:    a.rtt.summary(),
+	}
+	if a.packets+a.packetsLost > 0 {
+		s.PacketLossPct = MetricSummary{
+			Avg:  float64(a.packetsLost) * 100 / float64(a.packets+a.packetsLost),
+			Peak: a.peakLoss,
+		}
+	}
+
+	layerTime := make(map[int32]time.Duration, len(a.layerTime))
+	for layer, d := range a.layerTime {
+		layerTime[layer] += d
+	}
+	var pausedTime time.Duration
+	for _, events := range a.events {
+		s.Freezes += events.Freezes
+		pausedTime += events.PausedDuration
+		for layer, d := range events.LayerDurations {
+			layerTime[layer] += d
+		}
+	}
+	s.PausedTimeMs = pausedTime.Milliseconds()
+
+	if len(layerTime) != 0 {
+		s.VideoLayerTimeMs = make(map[int32]int64, len(layerTime))
+		for layer, d := range layerTime {
+			s.VideoLayerTimeMs[layer] = d.Milliseconds()
+		}
+	}
+	if len(a.qualityTime) != 0 {
+		s.QualityTimeMs = make(map[string]int64, len(a.qualityTime))
+		for quality, d := range a.qualityTime {
+			s.QualityTimeMs[quality.String()] = d.Milliseconds()
+		}
+	}
+	return s
+}
+
+// ------------------------------------------------
+
+// withQualitySummary returns a copy of participant carrying the summary as an attribute
+func withQualitySummary(participant *livekit.ParticipantInfo, summary *QualitySummary) *livekit.ParticipantInfo {
+	if summary == nil {
+		return participant
+	}
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		logger.Errorw("failed to marshal quality summary", err, "participant", participant.Identity)
+		return participant
+	}
+
+	pi := utils.CloneProto(participant)
+	if pi.Attributes == nil {
+		pi.Attributes = make(map[string]string, 1)
+	}
+	pi.Attributes[QualitySummaryAttribute] = string(data)
+	return pi
+}
@@ -69,16 +69,21 @@ func NewBytesTrackStats(trackID livekit.TrackID, pID livekit.ParticipantID, tele
 }
 
 func (s *BytesTrackStats) AddBytes(bytes uint64, isSend bool) {
+	s.AddMessages(1, bytes, isSend)
+}
+
+// AddMessages records several messages with a total size of bytes
+func (s *BytesTrackStats) AddMessages(messages uint32, bytes uint64, isSend bool) {
 	if isSend {
 		s.send.Add(bytes)
-		s.sendMessages.Inc()
+		s.sendMessages.Add(messages)
 		s.totalSendBytes.Add(bytes)
-		s.totalSendMessages.Inc()
+		s.totalSendMessages.Add(messages)
 	} else {
 		s.recv.Add(bytes)
-		s.recvMessages.Inc()
+		s.recvMessages.Add(messages)
 		s.totalRecvBytes.Add(bytes)
-		s.totalRecvMessages.Inc()
+		s.totalRecvMessages.Add(messages)
 	}
 }
 
@@ -143,6 +148,10 @@ type BytesSignalStats struct {
 	BytesTrackStats
 	ctx context.Context
 
+	// responses as written on the connection, several can be batched and compressed in a frame
+	sendFrames     atomic.Uint32
+	sendFrameBytes atomic.Uint64
+
 	mu sync.Mutex
 	ri *livekit.Room
 	pi *livekit.ParticipantInfo
@@ -157,6 +166,17 @@ func NewBytesSignalStats(ctx context.Context, telemetry TelemetryService) *Bytes
 	}
 }
 
+// AddSendFrame records a frame written to the signal connection
+func (s *BytesSignalStats) AddSendFrame(bytes uint64) {
+	s.sendFrames.Inc()
+	s.sendFrameBytes.Add(bytes)
+}
+
+// GetSendFrameTotals returns the number of frames written to the signal connection and their size
+func (s *BytesSignalStats) GetSendFrameTotals() (uint32, uint64) {
+	return s.sendFrames.Load(), s.sendFrameBytes.Load()
+}
+
 func (s *BytesSignalStats) ResolveRoom(ri *livekit.Room) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
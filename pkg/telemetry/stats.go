@@ -15,6 +15,7 @@
 package telemetry
 
 import (
+	"github.com/livekit/livekit-server/pkg/sfu/connectionquality"
 	"github.com/livekit/livekit-server/pkg/telemetry/prometheus"
 	"github.com/livekit/protocol/livekit"
 )
@@ -94,6 +95,17 @@ func (t *telemetryService) TrackStats(key StatsKey, stat *livekit.AnalyticsStat)
 
 		if worker, ok := t.getWorker(key.participantID); ok {
 			worker.OnTrackStat(key.trackID, key.streamType, stat)
+			if key.track {
+				worker.OnTrackQualityStat(key.streamType, stat)
+			}
+		}
+	})
+}
+
+func (t *telemetryService) TrackQualityEvents(key StatsKey, events connectionquality.QualityEvents) {
+	t.enqueue(func() {
+		if worker, ok := t.getWorker(key.participantID); ok {
+			worker.OnTrackQualityEvents(key.trackID, key.streamType, events)
 		}
 	})
 }
@@ -16,13 +16,18 @@ package telemetry_test
 
 import (
 	"context"
+	"encoding/json"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/livekit/livekit-server/pkg/sfu/connectionquality"
 	"github.com/livekit/livekit-server/pkg/telemetry"
 	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/webhook"
 
 	"github.com/livekit/livekit-server/pkg/telemetry/prometheus"
 	"github.com/livekit/livekit-server/pkg/telemetry/telemetryfakes"
@@ -618,3 +623,114 @@ func (f *telemetryServiceFixture) flush() {
 	time.Sleep(time.Millisecond * 500)
 	f.sut.FlushStats()
 }
+
+type testNotifier struct {
+	lock   sync.Mutex
+	events []*livekit.WebhookEvent
+}
+
+func (n *testNotifier) RegisterProcessedHook(_ func(ctx context.Context, whi *livekit.WebhookInfo)) {}
+
+func (n *testNotifier) QueueNotify(_ context.Context, event *livekit.WebhookEvent) error {
+	n.lock.Lock()
+	n.events = append(n.events, event)
+	n.lock.Unlock()
+	return nil
+}
+
+func (n *testNotifier) participantLeft() *livekit.ParticipantInfo {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	for _, event := range n.events {
+		if event.Event == webhook.EventParticipantLeft {
+			return event.Participant
+		}
+	}
+	return nil
+}
+
+func Test_QualitySummaryIsSentOnParticipantLeft(t *testing.T) {
+	fixture := createFixture()
+	notifier := &testNotifier{}
+	fixture.sut = telemetry.NewTelemetryService(notifier, fixture.analytics)
+
+	// prepare
+	room := &livekit.Room{Sid: "RoomSid", Name: "RoomName"}
+	partSID := livekit.ParticipantID("part1")
+	participantInfo := &livekit.ParticipantInfo{Sid: string(partSID), Identity: "alice"}
+	fixture.sut.ParticipantJoined(context.Background(), room, participantInfo, nil, nil, true)
+	fixture.sut.ParticipantActive(context.Background(), room, participantInfo, &livekit.AnalyticsClientMeta{}, false)
+
+	// do
+	now := time.Now()
+	newStat := func(start time.Time, lost uint32, jitter uint32, rtt uint32, score float32) *livekit.AnalyticsStat {
+		return &livekit.AnalyticsStat{
+			Score: score,
+			Streams: []*livekit.AnalyticsStream{{
+				StartTime:      timestamppb.New(start),
+				EndTime:        timestamppb.New(start.Add(5 * time.Second)),
+				PrimaryPackets: 100 - lost,
+				PacketsLost:    lost,
+				Jitter:         jitter,
+				Rtt:            rtt,
+			}},
+		}
+	}
+	key := telemetry.StatsKeyForTrack(livekit.StreamType_DOWNSTREAM, partSID, "TR_video", livekit.TrackSource_CAMERA, livekit.TrackType_VIDEO)
+	fixture.sut.TrackStats(key, newStat(now, 0, 10000, 50, 4.5))
+	fixture.sut.TrackStats(key, newStat(now.Add(5*time.Second), 10, 30000, 150, 2.0))
+	fixture.sut.TrackQualityEvents(key, connectionquality.QualityEvents{
+		Freezes:        2,
+		PausedDuration: 3 * time.Second,
+		LayerDurations: map[int32]time.Duration{0: 4 * time.Second, 2: 6 * time.Second},
+		StartedAt:      now,
+	})
+	// subscribed again, reports of the new down track add to the earlier ones
+	fixture.sut.TrackQualityEvents(key, connectionquality.QualityEvents{
+		PausedDuration: time.Second,
+		StartedAt:      now.Add(10 * time.Second),
+	})
+	fixture.sut.TrackQualityEvents(key, connectionquality.QualityEvents{
+		Freezes:        1,
+		PausedDuration: 2 * time.Second,
+		LayerDurations: map[int32]time.Duration{2: time.Second},
+		StartedAt:      now.Add(10 * time.Second),
+	})
+	// data channel stats do not count towards media quality
+	fixture.sut.TrackStats(telemetry.StatsKeyForData(livekit.StreamType_DOWNSTREAM, partSID, ""), &livekit.AnalyticsStat{
+		Streams: []*livekit.AnalyticsStream{{PrimaryPackets: 1000}},
+	})
+	fixture.sut.ParticipantLeft(context.Background(), room, participantInfo, true)
+	fixture.sut.FlushStats()
+
+	// test
+	require.Eventually(t, func() bool { return fixture.analytics.SendQualitySummaryCallCount() == 1 }, time.Second, 10*time.Millisecond)
+	_, summary := fixture.analytics.SendQualitySummaryArgsForCall(0)
+	require.Equal(t, string(partSID), summary.ParticipantID)
+	require.Equal(t, "alice", summary.ParticipantIdentity)
+	require.Nil(t, summary.Publish)
+
+	sub := summary.Subscribe
+	require.NotNil(t, sub)
+	require.InDelta(t, 5.0, sub.PacketLossPct.Avg, 0.001)
+	require.InDelta(t, 10.0, sub.PacketLossPct.Peak, 0.001)
+	require.InDelta(t, 20.0, sub.JitterMs.Avg, 0.001)
+	require.InDelta(t, 30.0, sub.JitterMs.Peak, 0.001)
+	require.InDelta(t, 100.0, sub.RTTMs.Avg, 0.001)
+	require.InDelta(t, 150.0, sub.RTTMs.Peak, 0.001)
+	require.Equal(t, uint32(3), sub.Freezes)
+	require.Equal(t, int64(5000), sub.PausedTimeMs)
+	require.Equal(t, map[int32]int64{0: 4000, 2: 7000}, sub.VideoLayerTimeMs)
+	require.Equal(t, map[string]int64{
+		livekit.ConnectionQuality_EXCELLENT.String(): 5000,
+		livekit.ConnectionQuality_POOR.String():      5000,
+	}, sub.QualityTimeMs)
+
+	// attached to the participant_left webhook
+	require.Eventually(t, func() bool { return notifier.participantLeft() != nil }, time.Second, 10*time.Millisecond)
+	var attached telemetry.QualitySummary
+	require.NoError(t, json.Unmarshal([]byte(notifier.participantLeft().Attributes[telemetry.QualitySummaryAttribute]), &attached))
+	require.Equal(t, summary.ParticipantID, attached.ParticipantID)
+	require.Equal(t, uint32(3), attached.Subscribe.Freezes)
+	require.Empty(t, participantInfo.Attributes)
+}
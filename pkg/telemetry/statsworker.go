@@ -21,6 +21,7 @@ import (
 
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/livekit/livekit-server/pkg/sfu/connectionquality"
 	"github.com/livekit/livekit-server/pkg/utils"
 	"github.com/livekit/protocol/livekit"
 	"github.com/livekit/protocol/logger"
@@ -43,6 +44,10 @@ type StatsWorker struct {
 	outgoingPerTrack map[livekit.TrackID][]*livekit.AnalyticsStat
 	incomingPerTrack map[livekit.TrackID][]*livekit.AnalyticsStat
 	closedAt         time.Time
+
+	startedAt        time.Time
+	publishQuality   *streamQualityAggregator
+	subscribeQuality *streamQualityAggregator
 }
 
 func newStatsWorker(
@@ -62,6 +67,9 @@ func newStatsWorker(
 		participantIdentity: identity,
 		outgoingPerTrack:    make(map[livekit.TrackID][]*livekit.AnalyticsStat),
 		incomingPerTrack:    make(map[livekit.TrackID][]*livekit.AnalyticsStat),
+		startedAt:           time.Now(),
+		publishQuality:      newStreamQualityAggregator(),
+		subscribeQuality:    newStreamQualityAggregator(),
 	}
 	return s
 }
@@ -76,6 +84,42 @@ func (s *StatsWorker) OnTrackStat(trackID livekit.TrackID, direction livekit.Str
 	s.lock.Unlock()
 }
 
+// OnTrackQualityStat adds a media track stat to the session quality summary
+func (s *StatsWorker) OnTrackQualityStat(direction livekit.StreamType, stat *livekit.AnalyticsStat) {
+	s.lock.Lock()
+	s.qualityAggregator(direction).addStat(stat)
+	s.lock.Unlock()
+}
+
+func (s *StatsWorker) OnTrackQualityEvents(trackID livekit.TrackID, direction livekit.StreamType, events connectionquality.QualityEvents) {
+	s.lock.Lock()
+	s.qualityAggregator(direction).setQualityEvents(trackID, events)
+	s.lock.Unlock()
+}
+
+func (s *StatsWorker) QualitySummary(now time.Time) *QualitySummary {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return &QualitySummary{
+		RoomID:              string(s.roomID),
+		RoomName:            string(s.roomName),
+		ParticipantID:       string(s.participantID),
+		ParticipantIdentity: string(s.participantIdentity),
+		StartedAt:           s.startedAt,
+		EndedAt:             now,
+		Publish:             s.publishQuality.summary(),
+		Subscribe:           s.subscribeQuality.summary(),
+	}
+}
+
+func (s *StatsWorker) qualityAggregator(direction livekit.StreamType) *streamQualityAggregator {
+	if direction == livekit.StreamType_DOWNSTREAM {
+		return s.subscribeQuality
+	}
+	return s.publishQuality
+}
+
 func (s *StatsWorker) ParticipantID() livekit.ParticipantID {
 	return s.participantID
 }
@@ -10,6 +10,10 @@ import (
 )
 
 type FakeAnalyticsService struct {
+	CloseStub        func()
+	closeMutex       sync.RWMutex
+	closeArgsForCall []struct {
+	}
 	SendEventStub        func(context.Context, *livekit.AnalyticsEvent)
 	sendEventMutex       sync.RWMutex
 	sendEventArgsForCall []struct {
@@ -22,6 +26,12 @@ type FakeAnalyticsService struct {
 		arg1 context.Context
 		arg2 *livekit.AnalyticsNodeRooms
 	}
+	SendQualitySummaryStub        func(context.Context, *telemetry.QualitySummary)
+	sendQualitySummaryMutex       sync.RWMutex
+	sendQualitySummaryArgsForCall []struct {
+		arg1 context.Context
+		arg2 *telemetry.QualitySummary
+	}
 	SendStatsStub        func(context.Context, []*livekit.AnalyticsStat)
 	sendStatsMutex       sync.RWMutex
 	sendStatsArgsForCall []struct {
@@ -32,6 +42,30 @@ type FakeAnalyticsService struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeAnalyticsService) Close() {
+	fake.closeMutex.Lock()
+	fake.closeArgsForCall = append(fake.closeArgsForCall, struct {
+	}{})
+	stub := fake.CloseStub
+	fake.recordInvocation("Close", []interface{}{})
+	fake.closeMutex.Unlock()
+	if stub != nil {
+		fake.CloseStub()
+	}
+}
+
+func (fake *FakeAnalyticsService) CloseCallCount() int {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	return len(fake.closeArgsForCall)
+}
+
+func (fake *FakeAnalyticsService) CloseCalls(stub func()) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = stub
+}
+
 func (fake *FakeAnalyticsService) SendEvent(arg1 context.Context, arg2 *livekit.AnalyticsEvent) {
 	fake.sendEventMutex.Lock()
 	fake.sendEventArgsForCall = append(fake.sendEventArgsForCall, struct {
@@ -98,6 +132,39 @@ func (fake *FakeAnalyticsService) SendNodeRoomStatesArgsForCall(i int) (context.
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeAnalyticsService) SendQualitySummary(arg1 context.Context, arg2 *telemetry.QualitySummary) {
+	fake.sendQualitySummaryMutex.Lock()
+	fake.sendQualitySummaryArgsForCall = append(fake.sendQualitySummaryArgsForCall, struct {
+		arg1 context.Context
+		arg2 *telemetry.QualitySummary
+	}{arg1, arg2})
+	stub := fake.SendQualitySummaryStub
+	fake.recordInvocation("SendQualitySummary", []interface{}{arg1, arg2})
+	fake.sendQualitySummaryMutex.Unlock()
+	if stub != nil {
+		fake.SendQualitySummaryStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeAnalyticsService) SendQualitySummaryCallCount() int {
+	fake.sendQualitySummaryMutex.RLock()
+	defer fake.sendQualitySummaryMutex.RUnlock()
+	return len(fake.sendQualitySummaryArgsForCall)
+}
+
+func (fake *FakeAnalyticsService) SendQualitySummaryCalls(stub func(context.Context, *telemetry.QualitySummary)) {
+	fake.sendQualitySummaryMutex.Lock()
+	defer fake.sendQualitySummaryMutex.Unlock()
+	fake.SendQualitySummaryStub = stub
+}
+
+func (fake *FakeAnalyticsService) SendQualitySummaryArgsForCall(i int) (context.Context, *telemetry.QualitySummary) {
+	fake.sendQualitySummaryMutex.RLock()
+	defer fake.sendQualitySummaryMutex.RUnlock()
+	argsForCall := fake.sendQualitySummaryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeAnalyticsService) SendStats(arg1 context.Context, arg2 []*livekit.AnalyticsStat) {
 	var arg2Copy []*livekit.AnalyticsStat
 	if arg2 != nil {
@@ -139,10 +206,14 @@ func (fake *FakeAnalyticsService) SendStatsArgsForCall(i int) (context.Context,
 func (fake *FakeAnalyticsService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
 	fake.sendEventMutex.RLock()
 	defer fake.sendEventMutex.RUnlock()
 	fake.sendNodeRoomStatesMutex.RLock()
 	defer fake.sendNodeRoomStatesMutex.RUnlock()
+	fake.sendQualitySummaryMutex.RLock()
+	defer fake.sendQualitySummaryMutex.RUnlock()
 	fake.sendStatsMutex.RLock()
 	defer fake.sendStatsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/livekit/livekit-server/pkg/sfu/connectionquality"
 	"github.com/livekit/livekit-server/pkg/sfu/mime"
 	"github.com/livekit/livekit-server/pkg/telemetry"
 	"github.com/livekit/protocol/livekit"
@@ -17,6 +18,10 @@ type FakeTelemetryService struct {
 		arg1 context.Context
 		arg2 *livekit.APICallInfo
 	}
+	CloseStub        func()
+	closeMutex       sync.RWMutex
+	closeArgsForCall []struct {
+	}
 	EgressEndedStub        func(context.Context, *livekit.EgressInfo)
 	egressEndedMutex       sync.RWMutex
 	egressEndedArgsForCall []struct {
@@ -129,6 +134,13 @@ type FakeTelemetryService struct {
 		arg1 context.Context
 		arg2 *livekit.Room
 	}
+	RoomEndedOnNodeFailureStub        func(context.Context, *livekit.Room, []*livekit.ParticipantInfo)
+	roomEndedOnNodeFailureMutex       sync.RWMutex
+	roomEndedOnNodeFailureArgsForCall []struct {
+		arg1 context.Context
+		arg2 *livekit.Room
+		arg3 []*livekit.ParticipantInfo
+	}
 	RoomStartedStub        func(context.Context, *livekit.Room)
 	roomStartedMutex       sync.RWMutex
 	roomStartedArgsForCall []struct {
@@ -147,6 +159,12 @@ type FakeTelemetryService struct {
 		arg1 context.Context
 		arg2 *livekit.AnalyticsNodeRooms
 	}
+	SendQualitySummaryStub        func(context.Context, *telemetry.QualitySummary)
+	sendQualitySummaryMutex       sync.RWMutex
+	sendQualitySummaryArgsForCall []struct {
+		arg1 context.Context
+		arg2 *telemetry.QualitySummary
+	}
 	SendStatsStub        func(context.Context, []*livekit.AnalyticsStat)
 	sendStatsMutex       sync.RWMutex
 	sendStatsArgsForCall []struct {
@@ -202,6 +220,12 @@ type FakeTelemetryService struct {
 		arg2 livekit.ParticipantID
 		arg3 *livekit.TrackInfo
 	}
+	TrackQualityEventsStub        func(telemetry.StatsKey, connectionquality.QualityEvents)
+	trackQualityEventsMutex       sync.RWMutex
+	trackQualityEventsArgsForCall []struct {
+		arg1 telemetry.StatsKey
+		arg2 connectionquality.QualityEvents
+	}
 	TrackStatsStub        func(telemetry.StatsKey, *livekit.AnalyticsStat)
 	trackStatsMutex       sync.RWMutex
 	trackStatsArgsForCall []struct {
@@ -309,6 +333,30 @@ func (fake *FakeTelemetryService) APICallArgsForCall(i int) (context.Context, *l
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeTelemetryService) Close() {
+	fake.closeMutex.Lock()
+	fake.closeArgsForCall = append(fake.closeArgsForCall, struct {
+	}{})
+	stub := fake.CloseStub
+	fake.recordInvocation("Close", []interface{}{})
+	fake.closeMutex.Unlock()
+	if stub != nil {
+		fake.CloseStub()
+	}
+}
+
+func (fake *FakeTelemetryService) CloseCallCount() int {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	return len(fake.closeArgsForCall)
+}
+
+func (fake *FakeTelemetryService) CloseCalls(stub func()) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = stub
+}
+
 func (fake *FakeTelemetryService) EgressEnded(arg1 context.Context, arg2 *livekit.EgressInfo) {
 	fake.egressEndedMutex.Lock()
 	fake.egressEndedArgsForCall = append(fake.egressEndedArgsForCall, struct {
@@ -873,6 +921,45 @@ func (fake *FakeTelemetryService) RoomEndedArgsForCall(i int) (context.Context,
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeTelemetryService) RoomEndedOnNodeFailure(arg1 context.Context, arg2 *livekit.Room, arg3 []*livekit.ParticipantInfo) {
+	var arg3Copy []*livekit.ParticipantInfo
+	if arg3 != nil {
+		arg3Copy = make([]*livekit.ParticipantInfo, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.roomEndedOnNodeFailureMutex.Lock()
+	fake.roomEndedOnNodeFailureArgsForCall = append(fake.roomEndedOnNodeFailureArgsForCall, struct {
+		arg1 context.Context
+		arg2 *livekit.Room
+		arg3 []*livekit.ParticipantInfo
+	}{arg1, arg2, arg3Copy})
+	stub := fake.RoomEndedOnNodeFailureStub
+	fake.recordInvocation("RoomEndedOnNodeFailure", []interface{}{arg1, arg2, arg3Copy})
+	fake.roomEndedOnNodeFailureMutex.Unlock()
+	if stub != nil {
+		fake.RoomEndedOnNodeFailureStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeTelemetryService) RoomEndedOnNodeFailureCallCount() int {
+	fake.roomEndedOnNodeFailureMutex.RLock()
+	defer fake.roomEndedOnNodeFailureMutex.RUnlock()
+	return len(fake.roomEndedOnNodeFailureArgsForCall)
+}
+
+func (fake *FakeTelemetryService) RoomEndedOnNodeFailureCalls(stub func(context.Context, *livekit.Room, []*livekit.ParticipantInfo)) {
+	fake.roomEndedOnNodeFailureMutex.Lock()
+	defer fake.roomEndedOnNodeFailureMutex.Unlock()
+	fake.RoomEndedOnNodeFailureStub = stub
+}
+
+func (fake *FakeTelemetryService) RoomEndedOnNodeFailureArgsForCall(i int) (context.Context, *livekit.Room, []*livekit.ParticipantInfo) {
+	fake.roomEndedOnNodeFailureMutex.RLock()
+	defer fake.roomEndedOnNodeFailureMutex.RUnlock()
+	argsForCall := fake.roomEndedOnNodeFailureArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *FakeTelemetryService) RoomStarted(arg1 context.Context, arg2 *livekit.Room) {
 	fake.roomStartedMutex.Lock()
 	fake.roomStartedArgsForCall = append(fake.roomStartedArgsForCall, struct {
@@ -972,6 +1059,39 @@ func (fake *FakeTelemetryService) SendNodeRoomStatesArgsForCall(i int) (context.
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeTelemetryService) SendQualitySummary(arg1 context.Context, arg2 *telemetry.QualitySummary) {
+	fake.sendQualitySummaryMutex.Lock()
+	fake.sendQualitySummaryArgsForCall = append(fake.sendQualitySummaryArgsForCall, struct {
+		arg1 context.Context
+		arg2 *telemetry.QualitySummary
+	}{arg1, arg2})
+	stub := fake.SendQualitySummaryStub
+	fake.recordInvocation("SendQualitySummary", []interface{}{arg1, arg2})
+	fake.sendQualitySummaryMutex.Unlock()
+	if stub != nil {
+		fake.SendQualitySummaryStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTelemetryService) SendQualitySummaryCallCount() int {
+	fake.sendQualitySummaryMutex.RLock()
+	defer fake.sendQualitySummaryMutex.RUnlock()
+	return len(fake.sendQualitySummaryArgsForCall)
+}
+
+func (fake *FakeTelemetryService) SendQualitySummaryCalls(stub func(context.Context, *telemetry.QualitySummary)) {
+	fake.sendQualitySummaryMutex.Lock()
+	defer fake.sendQualitySummaryMutex.Unlock()
+	fake.SendQualitySummaryStub = stub
+}
+
+func (fake *FakeTelemetryService) SendQualitySummaryArgsForCall(i int) (context.Context, *telemetry.QualitySummary) {
+	fake.sendQualitySummaryMutex.RLock()
+	defer fake.sendQualitySummaryMutex.RUnlock()
+	argsForCall := fake.sendQualitySummaryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeTelemetryService) SendStats(arg1 context.Context, arg2 []*livekit.AnalyticsStat) {
 	var arg2Copy []*livekit.AnalyticsStat
 	if arg2 != nil {
@@ -1221,6 +1341,39 @@ func (fake *FakeTelemetryService) TrackPublishedUpdateArgsForCall(i int) (contex
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *FakeTelemetryService) TrackQualityEvents(arg1 telemetry.StatsKey, arg2 connectionquality.QualityEvents) {
+	fake.trackQualityEventsMutex.Lock()
+	fake.trackQualityEventsArgsForCall = append(fake.trackQualityEventsArgsForCall, struct {
+		arg1 telemetry.StatsKey
+		arg2 connectionquality.QualityEvents
+	}{arg1, arg2})
+	stub := fake.TrackQualityEventsStub
+	fake.recordInvocation("TrackQualityEvents", []interface{}{arg1, arg2})
+	fake.trackQualityEventsMutex.Unlock()
+	if stub != nil {
+		fake.TrackQualityEventsStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTelemetryService) TrackQualityEventsCallCount() int {
+	fake.trackQualityEventsMutex.RLock()
+	defer fake.trackQualityEventsMutex.RUnlock()
+	return len(fake.trackQualityEventsArgsForCall)
+}
+
+func (fake *FakeTelemetryService) TrackQualityEventsCalls(stub func(telemetry.StatsKey, connectionquality.QualityEvents)) {
+	fake.trackQualityEventsMutex.Lock()
+	defer fake.trackQualityEventsMutex.Unlock()
+	fake.TrackQualityEventsStub = stub
+}
+
+func (fake *FakeTelemetryService) TrackQualityEventsArgsForCall(i int) (telemetry.StatsKey, connectionquality.QualityEvents) {
+	fake.trackQualityEventsMutex.RLock()
+	defer fake.trackQualityEventsMutex.RUnlock()
+	argsForCall := fake.trackQualityEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeTelemetryService) TrackStats(arg1 telemetry.StatsKey, arg2 *livekit.AnalyticsStat) {
 	fake.trackStatsMutex.Lock()
 	fake.trackStatsArgsForCall = append(fake.trackStatsArgsForCall, struct {
@@ -1539,6 +1692,8 @@ func (fake *FakeTelemetryService) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.aPICallMutex.RLock()
 	defer fake.aPICallMutex.RUnlock()
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
 	fake.egressEndedMutex.RLock()
 	defer fake.egressEndedMutex.RUnlock()
 	fake.egressStartedMutex.RLock()
@@ -1573,12 +1728,16 @@ func (fake *FakeTelemetryService) Invocations() map[string][][]interface{} {
 	defer fake.reportMutex.RUnlock()
 	fake.roomEndedMutex.RLock()
 	defer fake.roomEndedMutex.RUnlock()
+	fake.roomEndedOnNodeFailureMutex.RLock()
+	defer fake.roomEndedOnNodeFailureMutex.RUnlock()
 	fake.roomStartedMutex.RLock()
 	defer fake.roomStartedMutex.RUnlock()
 	fake.sendEventMutex.RLock()
 	defer fake.sendEventMutex.RUnlock()
 	fake.sendNodeRoomStatesMutex.RLock()
 	defer fake.sendNodeRoomStatesMutex.RUnlock()
+	fake.sendQualitySummaryMutex.RLock()
+	defer fake.sendQualitySummaryMutex.RUnlock()
 	fake.sendStatsMutex.RLock()
 	defer fake.sendStatsMutex.RUnlock()
 	fake.trackMaxSubscribedVideoQualityMutex.RLock()
@@ -1593,6 +1752,8 @@ func (fake *FakeTelemetryService) Invocations() map[string][][]interface{} {
 	defer fake.trackPublishedMutex.RUnlock()
 	fake.trackPublishedUpdateMutex.RLock()
 	defer fake.trackPublishedUpdateMutex.RUnlock()
+	fake.trackQualityEventsMutex.RLock()
+	defer fake.trackQualityEventsMutex.RUnlock()
 	fake.trackStatsMutex.RLock()
 	defer fake.trackStatsMutex.RUnlock()
 	fake.trackSubscribeFailedMutex.RLock()
@@ -19,6 +19,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/livekit/livekit-server/pkg/sfu/connectionquality"
 	"github.com/livekit/livekit-server/pkg/sfu/mime"
 	"github.com/livekit/livekit-server/pkg/utils"
 	"github.com/livekit/protocol/livekit"
@@ -32,10 +33,14 @@ import (
 type TelemetryService interface {
 	// TrackStats is called periodically for each track in both directions (published/subscribed)
 	TrackStats(key StatsKey, stat *livekit.AnalyticsStat)
+	// TrackQualityEvents is called with cumulative impairments of a subscribed track, for the session quality summary
+	TrackQualityEvents(key StatsKey, events connectionquality.QualityEvents)
 
 	// events
 	RoomStarted(ctx context.Context, room *livekit.Room)
 	RoomEnded(ctx context.Context, room *livekit.Room)
+	// RoomEndedOnNodeFailure - the node hosting the room went away without closing it, participants were left behind
+	RoomEndedOnNodeFailure(ctx context.Context, room *livekit.Room, participants []*livekit.ParticipantInfo)
 	// ParticipantJoined - a participant establishes signal connection to a room
 	ParticipantJoined(ctx context.Context, room *livekit.Room, participant *livekit.ParticipantInfo, clientInfo *livekit.ClientInfo, clientMeta *livekit.AnalyticsClientMeta, shouldSendEvent bool)
 	// ParticipantActive - a participant establishes media connection
@@ -85,6 +90,8 @@ type TelemetryService interface {
 	AnalyticsService
 	NotifyEvent(ctx context.Context, event *livekit.WebhookEvent)
 	FlushStats()
+	// Close sends queued events and stats, then closes analytics
+	Close()
 }
 
 const (
@@ -181,6 +188,12 @@ func (t *telemetryService) FlushStats() {
 	}
 }
 
+func (t *telemetryService) Close() {
+	t.FlushStats()
+	<-t.jobsQueue.Stop()
+	t.AnalyticsService.Close()
+}
+
 func (t *telemetryService) run() {
 	for range time.Tick(telemetryStatsUpdateInterval) {
 		t.FlushStats()
@@ -0,0 +1,91 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/livekit/psrpc"
+	"github.com/livekit/psrpc/pkg/metadata"
+)
+
+// InjectPSRPC adds the span context in ctx to the metadata sent with outgoing psrpc requests
+func InjectPSRPC(ctx context.Context) context.Context {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return ctx
+	}
+
+	kv := make([]string, 0, 2*len(carrier))
+	for k, v := range carrier {
+		kv = append(kv, k, v)
+	}
+	return metadata.AppendMetadataToOutgoingContext(ctx, kv...)
+}
+
+// ExtractPSRPC returns ctx with the remote span context carried by an incoming psrpc request
+func ExtractPSRPC(ctx context.Context) context.Context {
+	head := metadata.IncomingHeader(ctx)
+	if head == nil || len(head.Metadata) == 0 {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier(head.Metadata))
+}
+
+// ExtractHTTP returns ctx with the remote span context carried by the request headers
+func ExtractHTTP(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// HTTPMiddleware makes span context sent by callers available to handlers through the request context
+func HTTPMiddleware(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	next(w, r.WithContext(ExtractHTTP(r.Context(), r.Header)))
+}
+
+// WithClientTracing creates a client span for each psrpc request and propagates it to the server
+func WithClientTracing() psrpc.ClientOption {
+	return psrpc.WithClientRPCInterceptors(clientRPCInterceptor)
+}
+
+// WithServerTracing creates a server span for each psrpc request, parented to the caller's span
+func WithServerTracing() psrpc.ServerOption {
+	return psrpc.WithServerRPCInterceptors(serverRPCInterceptor)
+}
+
+func clientRPCInterceptor(info psrpc.RPCInfo, next psrpc.ClientRPCHandler) psrpc.ClientRPCHandler {
+	return func(ctx context.Context, req proto.Message, opts ...psrpc.RequestOption) (proto.Message, error) {
+		ctx, span := Start(ctx, rpcSpanName(info), trace.WithSpanKind(trace.SpanKindClient))
+		res, err := next(InjectPSRPC(ctx), req, opts...)
+		EndSpan(span, err)
+		return res, err
+	}
+}
+
+func serverRPCInterceptor(ctx context.Context, req proto.Message, info psrpc.RPCInfo, handler psrpc.ServerRPCHandler) (proto.Message, error) {
+	ctx, span := Start(ExtractPSRPC(ctx), rpcSpanName(info), trace.WithSpanKind(trace.SpanKindServer))
+	res, err := handler(ctx, req)
+	EndSpan(span, err)
+	return res, err
+}
+
+func rpcSpanName(info psrpc.RPCInfo) string {
+	return "psrpc " + info.Service + "." + info.Method
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/livekit/psrpc"
+	"github.com/livekit/psrpc/pkg/metadata"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prev)
+	})
+	return recorder
+}
+
+// simulates psrpc delivering outgoing metadata to the server as an incoming header
+func deliver(ctx context.Context) context.Context {
+	return metadata.NewContextWithIncomingHeader(context.Background(), &metadata.Header{
+		Metadata: metadata.OutgoingContextMetadata(ctx),
+	})
+}
+
+func TestPSRPCPropagation(t *testing.T) {
+	setupRecorder(t)
+
+	ctx, span := Start(context.Background(), "client")
+	defer span.End()
+
+	serverCtx := ExtractPSRPC(deliver(InjectPSRPC(ctx)))
+	remote := trace.SpanContextFromContext(serverCtx)
+	require.True(t, remote.IsRemote())
+	require.Equal(t, span.SpanContext().TraceID(), remote.TraceID())
+	require.Equal(t, span.SpanContext().SpanID(), remote.SpanID())
+}
+
+func TestPSRPCInterceptors(t *testing.T) {
+	recorder := setupRecorder(t)
+	info := psrpc.RPCInfo{Service: "RoomManager", Method: "CreateRoom"}
+
+	var serverSpan trace.SpanContext
+	server := func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		return serverRPCInterceptor(ctx, req, info, func(ctx context.Context, req proto.Message) (proto.Message, error) {
+			serverSpan = trace.SpanContextFromContext(ctx)
+			return &emptypb.Empty{}, nil
+		})
+	}
+	client := clientRPCInterceptor(info, func(ctx context.Context, req proto.Message, opts ...psrpc.RequestOption) (proto.Message, error) {
+		return server(deliver(ctx), req)
+	})
+
+	_, err := client(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	// server span ends first, and is a child of the client span
+	require.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+	require.Equal(t, trace.SpanKindClient, spans[1].SpanKind())
+	require.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.Equal(t, spans[1].SpanContext().TraceID(), serverSpan.TraceID())
+}
+
+func TestHTTPExtract(t *testing.T) {
+	setupRecorder(t)
+
+	ctx, span := Start(context.Background(), "caller")
+	defer span.End()
+
+	header := http.Header{}
+	header.Set("traceparent", "00-"+span.SpanContext().TraceID().String()+"-"+span.SpanContext().SpanID().String()+"-01")
+
+	remote := trace.SpanContextFromContext(ExtractHTTP(context.Background(), header))
+	require.Equal(t, trace.SpanContextFromContext(ctx).TraceID(), remote.TraceID())
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+	"github.com/livekit/protocol/tracer"
+
+	"github.com/livekit/livekit-server/pkg/config"
+	"github.com/livekit/livekit-server/version"
+)
+
+const (
+	instrumentationName = "github.com/livekit/livekit-server"
+
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// attribute keys shared by spans across packages
+const (
+	AttrRoomName            = attribute.Key("livekit.room.name")
+	AttrRoomID              = attribute.Key("livekit.room.id")
+	AttrParticipantIdentity = attribute.Key("livekit.participant.identity")
+	AttrParticipantID       = attribute.Key("livekit.participant.id")
+	AttrNodeID              = attribute.Key("livekit.node.id")
+	AttrConnectionID        = attribute.Key("livekit.connection.id")
+	AttrTrackID             = attribute.Key("livekit.track.id")
+	AttrTransport           = attribute.Key("livekit.transport")
+)
+
+var propagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+// Init configures the global tracer provider according to config. The returned function flushes and
+// stops exporting, it is safe to call when tracing is disabled.
+func Init(conf *config.TracingConfig, nodeID livekit.NodeID, region string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	var exporter sdktrace.SpanExporter
+	switch conf.Exporter {
+	case "":
+		return noop, nil
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{}
+		if conf.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(conf.Endpoint))
+		}
+		if len(conf.Headers) != 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(conf.Headers))
+		}
+		if conf.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		e, err := otlptracehttp.New(context.Background(), opts...)
+		if err != nil {
+			return noop, err
+		}
+		exporter = e
+	case ExporterStdout:
+		e, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return noop, err
+		}
+		exporter = e
+	default:
+		return noop, fmt.Errorf("unknown tracing exporter %q", conf.Exporter)
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.ServiceName("livekit-server"),
+		semconv.ServiceVersion(version.Version),
+		semconv.ServiceInstanceID(string(nodeID)),
+	}
+	if region != "" {
+		attrs = append(attrs, semconv.CloudRegion(region))
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attrs...)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+	// route spans started through the protocol tracer into the same provider
+	tracer.SetTracer(protocolTracer{})
+
+	logger.Infow("tracing enabled", "exporter", conf.Exporter, "endpoint", conf.Endpoint, "sampleRatio", conf.SampleRatio)
+	return provider.Shutdown, nil
+}
+
+// Start creates a span as a child of any span in ctx
+func Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, spanName, opts...)
+}
+
+// EndSpan records err, if any, on the span and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ------------------------------------------------
+
+type protocolTracer struct{}
+
+func (protocolTracer) Start(ctx context.Context, spanName string, opts ...interface{}) (context.Context, tracer.Span) {
+	startOpts := make([]trace.SpanStartOption, 0, len(opts))
+	for _, opt := range opts {
+		if o, ok := opt.(trace.SpanStartOption); ok {
+			startOpts = append(startOpts, o)
+		}
+	}
+	ctx, span := Start(ctx, spanName, startOpts...)
+	return ctx, protocolSpan{span}
+}
+
+type protocolSpan struct {
+	span trace.Span
+}
+
+func (s protocolSpan) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s protocolSpan) End() {
+	s.span.End()
+}
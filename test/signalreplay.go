@@ -0,0 +1,344 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+	"github.com/livekit/protocol/logger"
+	"github.com/livekit/protocol/utils"
+
+	"github.com/livekit/livekit-server/pkg/config"
+	"github.com/livekit/livekit-server/pkg/routing/routingfakes"
+	"github.com/livekit/livekit-server/pkg/rtc"
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+	"github.com/livekit/livekit-server/pkg/rtc/types/typesfakes"
+	"github.com/livekit/livekit-server/pkg/sfu/buffer"
+	"github.com/livekit/livekit-server/pkg/telemetry/telemetryfakes"
+)
+
+const (
+	signalReplayTimeout = 5 * time.Second
+	// the session is considered settled when nothing was recorded for this long
+	signalReplaySettleTime = 500 * time.Millisecond
+)
+
+var errNoSignalSession = errors.New("recording does not start with a session record")
+
+// signalReplay drives a participant with the client side of a signal recording (see rtc.SignalRecorder), so that
+// the negotiation of its transports can be reproduced offline, e. g. in a debugger.
+//
+// Requests are sent in the recorded order. Before each request, the replay waits for the server to send as many
+// offers and answers as it had sent before the request in the recording, timing of the recording is not used.
+// There is no peer at the other end, ICE does not connect and trickled candidates are not applied, so that a
+// replay does not reach out to the recorded client.
+type signalReplay struct {
+	records []*rtc.SignalRecord
+
+	lock       sync.Mutex
+	negotiated map[string]int
+	changed    chan struct{}
+
+	// records of the replayed session, in the format of the recording
+	recording *signalReplayRecording
+}
+
+type signalReplayRecording struct {
+	lock        sync.Mutex
+	buf         bytes.Buffer
+	lastWriteAt time.Time
+	closed      chan struct{}
+}
+
+func (r *signalReplayRecording) Write(p []byte) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.lastWriteAt = time.Now()
+	return r.buf.Write(p)
+}
+
+// waitForSettled waits until the replayed session stops recording, i. e. the server is done negotiating
+func (r *signalReplayRecording) waitForSettled() error {
+	deadline := time.Now().Add(signalReplayTimeout)
+	for time.Now().Before(deadline) {
+		r.lock.Lock()
+		idle := time.Since(r.lastWriteAt)
+		r.lock.Unlock()
+		if idle >= signalReplaySettleTime {
+			return nil
+		}
+		time.Sleep(signalReplaySettleTime - idle)
+	}
+	return errors.New("replayed session did not settle")
+}
+
+func (r *signalReplayRecording) Close() error {
+	close(r.closed)
+	return nil
+}
+
+func newSignalReplay(records []*rtc.SignalRecord) (*signalReplay, error) {
+	if len(records) == 0 || records[0].Type != rtc.SignalRecordTypeSession || records[0].Session == nil {
+		return nil, errNoSignalSession
+	}
+	return &signalReplay{
+		records:    records,
+		negotiated: make(map[string]int),
+		changed:    make(chan struct{}, 1),
+		recording:  &signalReplayRecording{closed: make(chan struct{})},
+	}, nil
+}
+
+// Run replays the recording and returns the records of the replay, divergences from the recording are returned
+// as errors along with the records
+func (r *signalReplay) Run() ([]*rtc.SignalRecord, error) {
+	session := r.records[0].Session
+	p, err := r.newParticipant(session)
+	if err != nil {
+		return nil, err
+	}
+
+	var divergences []error
+	expected := make(map[string]int)
+	room := &typesfakes.FakeRoom{}
+	for _, record := range r.records[1:] {
+		switch record.Type {
+		case rtc.SignalRecordTypeResponse:
+			res, err := record.SignalResponse()
+			if err != nil {
+				return nil, err
+			}
+			if kind := negotiationMessageKind(res.GetOffer(), res.GetAnswer()); kind != "" {
+				expected[kind]++
+			}
+
+		case rtc.SignalRecordTypeRequest:
+			req, err := record.SignalRequest()
+			if err != nil {
+				return nil, err
+			}
+			if req.GetTrickle() != nil {
+				continue
+			}
+			if err := r.waitForNegotiated(expected); err != nil {
+				divergences = append(divergences, fmt.Errorf("before request %T: %w", req.Message, err))
+			}
+			if err := rtc.HandleParticipantSignal(room, p, req, p.GetLogger()); err != nil {
+				divergences = append(divergences, fmt.Errorf("request %T: %w", req.Message, err))
+			}
+		}
+	}
+	if err := r.waitForNegotiated(expected); err != nil {
+		divergences = append(divergences, fmt.Errorf("end of recording: %w", err))
+	}
+	if err := r.recording.waitForSettled(); err != nil {
+		divergences = append(divergences, err)
+	}
+
+	_ = p.Close(false, types.ParticipantCloseReasonClientRequestLeave, false)
+	select {
+	case <-r.recording.closed:
+	case <-time.After(signalReplayTimeout):
+		divergences = append(divergences, errors.New("replayed participant did not close"))
+	}
+
+	r.recording.lock.Lock()
+	defer r.recording.lock.Unlock()
+	records, err := rtc.ReadSignalRecording(&r.recording.buf)
+	if err != nil {
+		return nil, err
+	}
+	return records, errors.Join(divergences...)
+}
+
+func (r *signalReplay) newParticipant(session *rtc.SignalRecordSession) (*rtc.ParticipantImpl, error) {
+	conf, err := config.NewConfig("", true, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	// no TCP mux, the replay does not connect
+	conf.RTC.TCPPort = 0
+	rtcConf, err := rtc.NewWebRTCConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	ff := buffer.NewFactoryOfBufferFactory(conf.RTC.PacketBufferSizeVideo, conf.RTC.PacketBufferSizeAudio)
+	rtcConf.SetBufferFactory(ff.CreateBufferFactory())
+
+	enabledCodecs := make([]*livekit.Codec, 0, len(conf.Room.EnabledCodecs))
+	for _, c := range conf.Room.EnabledCodecs {
+		enabledCodecs = append(enabledCodecs, &livekit.Codec{
+			Mime:     c.Mime,
+			FmtpLine: c.FmtpLine,
+		})
+	}
+	grants := session.Grants
+	if grants == nil || grants.Video == nil {
+		grants = &auth.ClaimGrants{Identity: string(session.Identity), Video: &auth.VideoGrant{}}
+	}
+
+	pLogger := rtc.LoggerWithParticipant(
+		rtc.LoggerWithRoom(logger.GetLogger(), session.Room, ""),
+		session.Identity,
+		session.SID,
+		false,
+	)
+	sink := &routingfakes.FakeMessageSink{}
+	sink.WriteMessageCalls(func(msg proto.Message) error {
+		if res, ok := msg.(*livekit.SignalResponse); ok {
+			r.onResponse(res)
+		}
+		return nil
+	})
+	p, err := rtc.NewParticipant(rtc.ParticipantParams{
+		Identity:                 session.Identity,
+		SID:                      session.SID,
+		Config:                   rtcConf,
+		Sink:                     sink,
+		AudioConfig:              conf.Audio,
+		VideoConfig:              conf.Video,
+		LimitConfig:              conf.Limit,
+		ProtocolVersion:          session.ProtocolVersion,
+		SessionStartTime:         time.Now(),
+		Telemetry:                &telemetryfakes.FakeTelemetryService{},
+		PLIThrottleConfig:        conf.RTC.PLIThrottle,
+		CongestionControlConfig:  conf.RTC.CongestionControl,
+		PublishEnabledCodecs:     enabledCodecs,
+		SubscribeEnabledCodecs:   enabledCodecs,
+		Grants:                   grants,
+		Reconnect:                session.Reconnect,
+		Logger:                   pLogger,
+		ClientInfo:               rtc.ClientInfo{ClientInfo: session.Client},
+		VersionGenerator:         utils.NewDefaultTimedVersionGenerator(),
+		UseOneShotSignallingMode: session.UseOneShotSignallingMode,
+		UseSinglePeerConnection:  session.UseSinglePeerConnection,
+		FireOnTrackBySdp:         true,
+		SignalRecorder:           rtc.NewSignalRecorder(r.recording, *session, pLogger),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// same as joining a room, the replayed join response is not compared
+	if err := p.SendJoinResponse(r.joinResponse(p)); err != nil {
+		return nil, err
+	}
+	p.SetMigrateState(types.MigrateStateComplete)
+	return p, nil
+}
+
+func (r *signalReplay) joinResponse(p *rtc.ParticipantImpl) *livekit.JoinResponse {
+	for _, record := range r.records {
+		if record.Type != rtc.SignalRecordTypeResponse {
+			continue
+		}
+		if res, err := record.SignalResponse(); err == nil && res.GetJoin() != nil {
+			return res.GetJoin()
+		}
+	}
+	return &livekit.JoinResponse{
+		Participant:       p.ToProto(),
+		SubscriberPrimary: p.SubscriberAsPrimary(),
+		PingTimeout:       15,
+		PingInterval:      5,
+	}
+}
+
+func (r *signalReplay) onResponse(res *livekit.SignalResponse) {
+	kind := negotiationMessageKind(res.GetOffer(), res.GetAnswer())
+	if kind == "" {
+		return
+	}
+
+	r.lock.Lock()
+	r.negotiated[kind]++
+	r.lock.Unlock()
+
+	select {
+	case r.changed <- struct{}{}:
+	default:
+	}
+}
+
+// waitForNegotiated waits until the server has sent at least the expected number of offers and answers
+func (r *signalReplay) waitForNegotiated(expected map[string]int) error {
+	deadline := time.After(signalReplayTimeout)
+	for {
+		r.lock.Lock()
+		var missing []string
+		for kind, count := range expected {
+			if r.negotiated[kind] < count {
+				missing = append(missing, fmt.Sprintf("%s %d/%d", kind, r.negotiated[kind], count))
+			}
+		}
+		r.lock.Unlock()
+		if len(missing) == 0 {
+			return nil
+		}
+
+		select {
+		case <-r.changed:
+		case <-deadline:
+			return fmt.Errorf("server did not send %v", missing)
+		}
+	}
+}
+
+func negotiationMessageKind(offer, answer *livekit.SessionDescription) string {
+	switch {
+	case offer != nil:
+		return "offer"
+	case answer != nil:
+		return "answer"
+	default:
+		return ""
+	}
+}
+
+// negotiationTrace returns what was negotiated in a session, in a form that can be compared between a recording
+// and its replay: the offers and answers exchanged and the negotiation states of each transport.
+// ICE and connection states are left out as a replay does not connect.
+func negotiationTrace(records []*rtc.SignalRecord) map[string][]string {
+	trace := make(map[string][]string)
+	for _, record := range records {
+		switch record.Type {
+		case rtc.SignalRecordTypeRequest:
+			if req, err := record.SignalRequest(); err == nil {
+				if kind := negotiationMessageKind(req.GetOffer(), req.GetAnswer()); kind != "" {
+					trace["signal"] = append(trace["signal"], "client "+kind)
+				}
+			}
+		case rtc.SignalRecordTypeResponse:
+			if res, err := record.SignalResponse(); err == nil {
+				if kind := negotiationMessageKind(res.GetOffer(), res.GetAnswer()); kind != "" {
+					trace["signal"] = append(trace["signal"], "server "+kind)
+				}
+			}
+		case rtc.SignalRecordTypeTransport:
+			if record.Event == rtc.SignalRecordEventNegotiationState {
+				trace[record.Target] = append(trace[record.Target], record.Value)
+			}
+		}
+	}
+	return trace
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 LiveKit, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pion/webrtc/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/livekit/protocol/auth"
+	"github.com/livekit/protocol/livekit"
+
+	"github.com/livekit/livekit-server/pkg/rtc"
+	"github.com/livekit/livekit-server/pkg/rtc/types"
+)
+
+// set to the path of a recording to replay it, e. g.
+// LIVEKIT_SIGNAL_RECORDING=/var/log/livekit/signal/room_identity_PA_xxx.jsonl go test ./test -run TestSignalReplayRecording -v
+const signalRecordingEnv = "LIVEKIT_SIGNAL_RECORDING"
+
+func TestSignalReplayRecording(t *testing.T) {
+	path := os.Getenv(signalRecordingEnv)
+	if path == "" {
+		t.Skipf("%s not set", signalRecordingEnv)
+	}
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	records, err := rtc.ReadSignalRecording(f)
+	require.NoError(t, err)
+
+	replay, err := newSignalReplay(records)
+	require.NoError(t, err)
+	replayed, err := replay.Run()
+	require.NoError(t, err)
+	require.Equal(t, negotiationTrace(records), negotiationTrace(replayed))
+}
+
+func TestSignalReplay(t *testing.T) {
+	for _, single := range []bool{false, true} {
+		t.Run(map[bool]string{false: "dual peer connection", true: "single peer connection"}[single], func(t *testing.T) {
+			// client side of a session publishing audio
+			pc, err := webrtc.NewPeerConnection(webrtc.Configuration{})
+			require.NoError(t, err)
+			defer pc.Close()
+			_, err = pc.AddTransceiverFromKind(webrtc.RTPCodecTypeAudio, webrtc.RTPTransceiverInit{Direction: webrtc.RTPTransceiverDirectionSendonly})
+			require.NoError(t, err)
+			_, err = pc.CreateDataChannel("_reliable", nil)
+			require.NoError(t, err)
+			offer, err := pc.CreateOffer(nil)
+			require.NoError(t, err)
+			require.NoError(t, pc.SetLocalDescription(offer))
+			// renegotiation adding video
+			_, err = pc.AddTransceiverFromKind(webrtc.RTPCodecTypeVideo, webrtc.RTPTransceiverInit{Direction: webrtc.RTPTransceiverDirectionSendonly})
+			require.NoError(t, err)
+			reoffer, err := pc.CreateOffer(nil)
+			require.NoError(t, err)
+
+			grants := &auth.ClaimGrants{Identity: "replay", Video: &auth.VideoGrant{}}
+			grants.Video.SetCanPublish(true)
+			grants.Video.SetCanSubscribe(true)
+			offerRecord := func(sd webrtc.SessionDescription) *rtc.SignalRecord {
+				req, err := protojson.Marshal(&livekit.SignalRequest{
+					Message: &livekit.SignalRequest_Offer{Offer: rtc.ToProtoSessionDescription(sd)},
+				})
+				require.NoError(t, err)
+				return &rtc.SignalRecord{Type: rtc.SignalRecordTypeRequest, Message: req}
+			}
+			// only the kind of server messages is used by the replay
+			answer, err := protojson.Marshal(&livekit.SignalResponse{
+				Message: &livekit.SignalResponse_Answer{Answer: &livekit.SessionDescription{Type: "answer"}},
+			})
+			require.NoError(t, err)
+			records := []*rtc.SignalRecord{
+				{
+					Type: rtc.SignalRecordTypeSession,
+					Session: &rtc.SignalRecordSession{
+						Room:                    "replay",
+						Identity:                "replay",
+						SID:                     "PA_replay",
+						ProtocolVersion:         types.CurrentProtocol,
+						Grants:                  grants,
+						UseSinglePeerConnection: single,
+					},
+				},
+				offerRecord(offer),
+				{Type: rtc.SignalRecordTypeResponse, Message: answer},
+				offerRecord(reoffer),
+			}
+
+			replay, err := newSignalReplay(records)
+			require.NoError(t, err)
+			recorded, err := replay.Run()
+			require.NoError(t, err)
+			trace := negotiationTrace(recorded)
+			require.Equal(t, []string{"client offer", "server answer", "client offer", "server answer"}, trace["signal"])
+
+			// replaying the replay negotiates the same
+			replay, err = newSignalReplay(recorded)
+			require.NoError(t, err)
+			replayed, err := replay.Run()
+			require.NoError(t, err)
+			require.Equal(t, trace, negotiationTrace(replayed))
+		})
+	}
+}